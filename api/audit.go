@@ -0,0 +1,215 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/token"
+
+	"github.com/gin-gonic/gin"
+)
+
+const auditRecordsKey = "audit_records"
+
+// auditIgnoredFields change on every update, they would only add noise to the changes
+var auditIgnoredFields = map[string]bool{
+	"changed_at": true,
+	"version":    true,
+}
+
+// auditRedactedFields are never written to the audit log, only the fact that they changed
+var auditRedactedFields = map[string]bool{
+	"hashed_password": true,
+	"password":        true,
+}
+
+var auditRedacted = json.RawMessage(`"[redacted]"`)
+
+// auditRecord is a record changed by a handler, before is nil when it was created and after is nil when it was deleted
+type auditRecord struct {
+	ID     int64
+	Before interface{}
+	After  interface{}
+}
+
+// auditChange is the value of a field before and after the change
+type auditChange struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// recordAudit tells the audit middleware which record the handler changed and how,
+// a handler that changes several records calls it once for each of them
+func recordAudit(ctx *gin.Context, id int64, before interface{}, after interface{}) {
+	records, _ := ctx.Value(auditRecordsKey).([]auditRecord)
+	ctx.Set(auditRecordsKey, append(records, auditRecord{ID: id, Before: before, After: after}))
+}
+
+// audit writes who did the action on the entity to the audit log once the handler succeeds.
+// The changes come from recordAudit, handlers that don't call it are logged with the id from the url and no changes.
+// A record saved without any change isn't logged.
+func (server *Server) audit(entity string, action string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if ctx.Writer.Status() >= http.StatusMultipleChoices {
+			return
+		}
+
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+		for _, record := range auditRecords(ctx, entity) {
+			changes, err := auditChanges(record.Before, record.After)
+			if err != nil {
+				log.Printf("cannot compare the %s %d for the audit log: %v", entity, record.ID, err)
+				changes = json.RawMessage(`{}`)
+			} else if record.Before != nil && record.After != nil && string(changes) == "{}" {
+				// the record was saved without any change
+				continue
+			}
+
+			// the change is already done, a failure here must not turn it into an error for the admin
+			_, err = server.store.CreateAuditLog(ctx, db.CreateAuditLogParams{
+				Actor:     authPayload.Username,
+				Action:    action,
+				Entity:    entity,
+				EntityID:  record.ID,
+				Changes:   changes,
+				Ip:        ctx.ClientIP(),
+				UserAgent: ctx.Request.UserAgent(),
+			})
+			if err != nil {
+				log.Printf("cannot write the audit log of %s %s %d: %v", action, entity, record.ID, err)
+			}
+		}
+	}
+}
+
+// auditRecords returns the records of recordAudit in order, or the record named in the url
+func auditRecords(ctx *gin.Context, entity string) []auditRecord {
+	if records, _ := ctx.Value(auditRecordsKey).([]auditRecord); len(records) > 0 {
+		return records
+	}
+
+	id, _ := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if id == 0 {
+		// nested routes like /link_images/:image_id/:product_id
+		id, _ = strconv.ParseInt(ctx.Param(entity+"_id"), 10, 64)
+	}
+	return []auditRecord{{ID: id}}
+}
+
+// auditChanges compares the json of the record before and after the change and keeps the fields that changed,
+// as {"field": {"before": ..., "after": ...}}. A created record only has after values and a deleted one only before values.
+func auditChanges(before interface{}, after interface{}) (json.RawMessage, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]auditChange{}
+	for field, value := range beforeFields {
+		if after, ok := afterFields[field]; !ok || !bytes.Equal(value, after) {
+			changes[field] = auditChange{Before: value, After: after}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = auditChange{After: value}
+		}
+	}
+
+	for field, change := range changes {
+		if auditIgnoredFields[field] {
+			delete(changes, field)
+			continue
+		}
+		if auditRedactedFields[field] {
+			if change.Before != nil {
+				change.Before = auditRedacted
+			}
+			if change.After != nil {
+				change.After = auditRedacted
+			}
+			changes[field] = change
+		}
+	}
+
+	return json.Marshal(changes)
+}
+
+// auditFields is the json of each field of the record, nil for no record
+func auditFields(record interface{}) (map[string]json.RawMessage, error) {
+	if record == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+type listAuditLogRequest struct {
+	Entity   string `form:"entity"`
+	ID       int64  `form:"id" binding:"omitempty,min=1"`
+	Actor    string `form:"actor"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type listAuditLogResponse struct {
+	Total     int64         `json:"total"`
+	AuditLogs []db.AuditLog `json:"audit_logs"`
+}
+
+func (server *Server) listAuditLog(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	currentLoggedInUser, _ := server.store.GetUserByUsername(ctx, authPayload.Username)
+
+	if currentLoggedInUser.Role == "User" {
+		ctx.JSON(http.StatusUnauthorized, "You are not authorized to see the audit log, only admins have that privilege")
+		return
+	}
+
+	var req listAuditLogRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	total, err := server.store.CountAuditLogs(ctx, db.CountAuditLogsParams{
+		Entity:   req.Entity,
+		EntityID: req.ID,
+		Actor:    req.Actor,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logs, err := server.store.ListAuditLogs(ctx, db.ListAuditLogsParams{
+		Entity:      req.Entity,
+		EntityID:    req.ID,
+		Actor:       req.Actor,
+		LimitCount:  req.PageSize,
+		OffsetCount: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listAuditLogResponse{Total: total, AuditLogs: logs})
+}
@@ -0,0 +1,399 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/token"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type auditLogMatcher struct {
+	entity string
+	action string
+	id     int64
+}
+
+func (e auditLogMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.CreateAuditLogParams)
+	if !ok {
+		return false
+	}
+
+	return arg.Entity == e.entity && arg.Action == e.action && arg.EntityID == e.id
+}
+
+func (e auditLogMatcher) String() string {
+	return fmt.Sprintf("audit log of %s %s %d", e.action, e.entity, e.id)
+}
+
+// auditLogFor matches the audit log entry of the action on the record, whatever the changes
+func auditLogFor(entity string, action string, id int64) gomock.Matcher {
+	return auditLogMatcher{entity: entity, action: action, id: id}
+}
+
+func TestAuditChanges(t *testing.T) {
+	client := randomClient()
+
+	updated := client
+	updated.AddressStreet = "Rua Nova"
+	updated.Version = client.Version + 1
+	updated.ChangedAt = time.Now()
+
+	changes, err := auditChanges(client, updated)
+	require.NoError(t, err)
+	require.JSONEq(t, fmt.Sprintf(`{"address_street": {"before": %q, "after": "Rua Nova"}}`, client.AddressStreet), string(changes))
+
+	// nothing changed
+	changes, err = auditChanges(client, client)
+	require.NoError(t, err)
+	require.JSONEq(t, `{}`, string(changes))
+
+	// a created record only has the values after
+	changes, err = auditChanges(nil, client)
+	require.NoError(t, err)
+	var fields map[string]auditChange
+	require.NoError(t, json.Unmarshal(changes, &fields))
+	require.Equal(t, json.RawMessage(fmt.Sprintf("%q", client.FullName)), fields["full_name"].After)
+	require.Nil(t, fields["full_name"].Before)
+	require.NotContains(t, fields, "version")
+
+	// a deleted record only has the values before
+	changes, err = auditChanges(client, nil)
+	require.NoError(t, err)
+	fields = nil
+	require.NoError(t, json.Unmarshal(changes, &fields))
+	require.Equal(t, json.RawMessage(fmt.Sprintf("%q", client.FullName)), fields["full_name"].Before)
+	require.Nil(t, fields["full_name"].After)
+
+	// the password hashes never reach the audit log
+	user, _ := randomUser(t)
+	changedUser := user
+	changedUser.HashedPassword = "new hash"
+	changes, err = auditChanges(user, changedUser)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"hashed_password": {"before": "[redacted]", "after": "[redacted]"}}`, string(changes))
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	client := randomClient()
+	updated := client
+	updated.AddressStreet = "Rua Nova"
+
+	testCases := []struct {
+		name          string
+		entity        string
+		action        string
+		route         string
+		url           string
+		handler       gin.HandlerFunc
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Changes",
+			entity: "client",
+			action: "update",
+			route:  "/clients/:id",
+			url:    fmt.Sprintf("/clients/%d", client.ID),
+			handler: func(ctx *gin.Context) {
+				recordAudit(ctx, client.ID, client, updated)
+				ctx.JSON(http.StatusOK, updated)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Eq(db.CreateAuditLogParams{
+						Actor:     "admin",
+						Action:    "update",
+						Entity:    "client",
+						EntityID:  client.ID,
+						Changes:   json.RawMessage(fmt.Sprintf(`{"address_street":{"before":%q,"after":"Rua Nova"}}`, client.AddressStreet)),
+						Ip:        "192.0.2.1",
+						UserAgent: "audit-test",
+					})).
+					Times(1).
+					Return(db.AuditLog{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "SeveralRecords",
+			entity: "sale",
+			action: "delete",
+			route:  "/sales/delete",
+			url:    "/sales/delete",
+			handler: func(ctx *gin.Context) {
+				recordAudit(ctx, 1, db.Sale{ID: 1}, nil)
+				recordAudit(ctx, 2, db.Sale{ID: 2}, nil)
+				ctx.JSON(http.StatusOK, "Sales deleted successfully")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().CreateAuditLog(gomock.Any(), auditLogFor("sale", "delete", 1)).Times(1),
+					store.EXPECT().CreateAuditLog(gomock.Any(), auditLogFor("sale", "delete", 2)).Times(1),
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Unchanged",
+			entity: "client",
+			action: "update",
+			route:  "/clients/:id",
+			url:    fmt.Sprintf("/clients/%d", client.ID),
+			handler: func(ctx *gin.Context) {
+				saved := client
+				saved.Version = client.Version + 1
+				recordAudit(ctx, client.ID, client, saved)
+				ctx.JSON(http.StatusOK, saved)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "IDFromURL",
+			entity: "purchase_order",
+			action: "send",
+			route:  "/purchase_orders/:id/send",
+			url:    "/purchase_orders/12/send",
+			handler: func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, "sent")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Eq(db.CreateAuditLogParams{
+						Actor:     "admin",
+						Action:    "send",
+						Entity:    "purchase_order",
+						EntityID:  12,
+						Changes:   json.RawMessage(`{}`),
+						Ip:        "192.0.2.1",
+						UserAgent: "audit-test",
+					})).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "IDOfEntityFromURL",
+			entity: "product",
+			action: "link_image",
+			route:  "/link_images/:image_id/:product_id",
+			url:    "/link_images/3/7",
+			handler: func(ctx *gin.Context) {
+				ctx.JSON(http.StatusOK, "linked")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAuditLog(gomock.Any(), auditLogFor("product", "link_image", 7)).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "Failed",
+			entity: "client",
+			action: "update",
+			route:  "/clients/:id",
+			url:    fmt.Sprintf("/clients/%d", client.ID),
+			handler: func(ctx *gin.Context) {
+				recordAudit(ctx, client.ID, client, updated)
+				ctx.JSON(http.StatusConflict, errorResponse(db.ErrVersionConflict))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "AuditLogError",
+			entity: "client",
+			action: "update",
+			route:  "/clients/:id",
+			url:    fmt.Sprintf("/clients/%d", client.ID),
+			handler: func(ctx *gin.Context) {
+				recordAudit(ctx, client.ID, client, updated)
+				ctx.JSON(http.StatusOK, updated)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAuditLog(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AuditLog{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				// the change is done, the admin still gets the result
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			// only the middleware, without the expectations of newTestServer
+			server := &Server{store: store}
+			router := gin.New()
+			router.Use(func(ctx *gin.Context) {
+				ctx.Set(authorizationPayloadKey, &token.Payload{Username: "admin"})
+			})
+			router.Handle(http.MethodPost, tc.route, server.audit(tc.entity, tc.action), tc.handler)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, tc.url, nil)
+			request.RemoteAddr = "192.0.2.1:1234"
+			request.Header.Set("User-Agent", "audit-test")
+
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListAuditLogAPI(t *testing.T) {
+	logs := []db.AuditLog{
+		{ID: 2, Actor: "admin", Action: "update", Entity: "client", EntityID: 5, Changes: json.RawMessage(`{}`)},
+		{ID: 1, Actor: "admin", Action: "create", Entity: "client", EntityID: 5, Changes: json.RawMessage(`{}`)},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?entity=client&id=5&actor=admin&page_id=1&page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "admin", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByUsername(gomock.Any(), gomock.Eq("admin")).Times(1).Return(db.User{Username: "admin", Role: "Administrator"}, nil)
+				store.EXPECT().
+					CountAuditLogs(gomock.Any(), gomock.Eq(db.CountAuditLogsParams{Entity: "client", EntityID: 5, Actor: "admin"})).
+					Times(1).
+					Return(int64(2), nil)
+				store.EXPECT().
+					ListAuditLogs(gomock.Any(), gomock.Eq(db.ListAuditLogsParams{Entity: "client", EntityID: 5, Actor: "admin", LimitCount: 5, OffsetCount: 0})).
+					Times(1).
+					Return(logs, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response listAuditLogResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, int64(2), response.Total)
+				require.Len(t, response.AuditLogs, 2)
+				require.Equal(t, logs[0].ID, response.AuditLogs[0].ID)
+			},
+		},
+		{
+			name:  "NotAdministrator",
+			query: "?page_id=1&page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByUsername(gomock.Any(), gomock.Eq("user")).Times(1).Return(db.User{Username: "user", Role: "User"}, nil)
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "NoAuthorization",
+			query: "?page_id=1&page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidID",
+			query: "?id=-1&page_id=1&page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "admin", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).Times(1).Return(db.User{Role: "Administrator"}, nil)
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "?page_id=1&page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "admin", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).Times(1).Return(db.User{Role: "Administrator"}, nil)
+				store.EXPECT().CountAuditLogs(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), sql.ErrConnDone)
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/audit"+tc.query, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
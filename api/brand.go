@@ -0,0 +1,235 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	db "super-pet-delivery/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+type createBrandRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	LogoID      int64  `json:"logo_id" binding:"min=0"`
+}
+
+func (server *Server) createBrand(ctx *gin.Context) {
+	var req createBrandRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.checkLogo(ctx, req.LogoID) {
+		return
+	}
+
+	slug, ok := server.brandSlug(ctx, req.Name, 0)
+	if !ok {
+		return
+	}
+
+	brand, err := server.store.CreateBrand(ctx, db.CreateBrandParams{
+		Name:        req.Name,
+		Slug:        slug,
+		Description: req.Description,
+		LogoID:      req.LogoID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, brand.ID, nil, brand)
+	ctx.JSON(http.StatusOK, brand)
+}
+
+type getBrandRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getBrand(ctx *gin.Context) {
+	var req getBrandRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	brand, err := server.store.GetBrand(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, brand)
+}
+
+// listBrand is public, the storefront shows every brand with its logo
+func (server *Server) listBrand(ctx *gin.Context) {
+	brands, err := server.store.ListBrands(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, brands)
+}
+
+type updateBrandRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	LogoID      *int64  `json:"logo_id" binding:"omitempty,min=0"`
+}
+
+func (server *Server) updateBrand(ctx *gin.Context) {
+	var uri getBrandRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateBrandRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	existing, err := server.store.GetBrand(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	previous := existing
+
+	// Update only the fields that are provided in the request
+	if req.Name != "" && req.Name != existing.Name {
+		slug, ok := server.brandSlug(ctx, req.Name, existing.ID)
+		if !ok {
+			return
+		}
+		existing.Name = req.Name
+		existing.Slug = slug
+	}
+	if req.Description != nil {
+		existing.Description = *req.Description
+	}
+	if req.LogoID != nil && *req.LogoID != existing.LogoID {
+		if !server.checkLogo(ctx, *req.LogoID) {
+			return
+		}
+		existing.LogoID = *req.LogoID
+	}
+
+	brand, err := server.store.UpdateBrand(ctx, db.UpdateBrandParams{
+		ID:          existing.ID,
+		Name:        existing.Name,
+		Slug:        existing.Slug,
+		Description: existing.Description,
+		LogoID:      existing.LogoID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, brand.ID, previous, brand)
+	ctx.JSON(http.StatusOK, brand)
+}
+
+func (server *Server) deleteBrand(ctx *gin.Context) {
+	var req getBrandRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// products keep the brand id, so a brand still in use can't be deleted
+	products, err := server.store.CountProductsByBrand(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if products > 0 {
+		err := fmt.Errorf("brand %d is used by %d products", req.ID, products)
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+
+	brand, err := server.store.GetBrand(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.DeleteBrand(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, brand.ID, brand, nil)
+	ctx.JSON(http.StatusOK, "Brand deleted successfully")
+}
+
+// brandSlug builds a unique slug from the brand name.
+// brandID is the brand being renamed, 0 when creating one.
+func (server *Server) brandSlug(ctx *gin.Context, name string, brandID int64) (string, bool) {
+	return uniqueSlug(ctx, name, brandID, func(slug string) (int64, error) {
+		brand, err := server.store.GetBrandBySlug(ctx, slug)
+		return brand.ID, err
+	})
+}
+
+// checkLogo makes sure the logo of a brand is an uploaded image, 0 means no logo.
+// The error response is already written when it returns false.
+func (server *Server) checkLogo(ctx *gin.Context, imageID int64) bool {
+	if imageID == 0 {
+		return true
+	}
+
+	_, err := server.store.GetImage(ctx, imageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("image %d not found", imageID)))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	return true
+}
+
+// checkBrand makes sure a brand set on a product exists, 0 means no brand.
+// The error response is already written when it returns false.
+func (server *Server) checkBrand(ctx *gin.Context, brandID int64) bool {
+	if brandID == 0 {
+		return true
+	}
+
+	_, err := server.store.GetBrand(ctx, brandID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("brand %d not found", brandID)))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateBrandAPI(t *testing.T) {
+	brand := db.Brand{
+		ID:          util.RandomInt(1, 1000),
+		Name:        "Golden Fórmula",
+		Slug:        "golden-formula-1",
+		Description: util.RandomString(20),
+		LogoID:      util.RandomInt(1, 1000),
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": brand.Name, "description": brand.Description, "logo_id": brand.LogoID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetImage(gomock.Any(), gomock.Eq(brand.LogoID)).Times(1).Return(db.Image{ID: brand.LogoID}, nil)
+				// the first slug is taken by another brand
+				gomock.InOrder(
+					store.EXPECT().GetBrandBySlug(gomock.Any(), gomock.Eq("golden-formula")).Return(db.Brand{ID: brand.ID + 1}, nil),
+					store.EXPECT().GetBrandBySlug(gomock.Any(), gomock.Eq("golden-formula-1")).Return(db.Brand{}, sql.ErrNoRows),
+				)
+				arg := db.CreateBrandParams{
+					Name:        brand.Name,
+					Slug:        brand.Slug,
+					Description: brand.Description,
+					LogoID:      brand.LogoID,
+				}
+				store.EXPECT().CreateBrand(gomock.Any(), gomock.Eq(arg)).Times(1).Return(brand, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var got db.Brand
+				require.NoError(t, json.Unmarshal(data, &got))
+				require.Equal(t, brand, got)
+			},
+		},
+		{
+			name: "LogoNotFound",
+			body: gin.H{"name": brand.Name, "logo_id": brand.LogoID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetImage(gomock.Any(), gomock.Any()).Times(1).Return(db.Image{}, sql.ErrNoRows)
+				store.EXPECT().CreateBrand(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "MissingName",
+			body: gin.H{"description": brand.Description},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateBrand(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/brands", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListBrandAPI(t *testing.T) {
+	brands := []db.ListBrandsRow{
+		{ID: 1, Name: "GranPlus", Slug: "granplus", LogoID: 3, LogoPath: "/images/granplus.png"},
+		{ID: 2, Name: "Purina", Slug: "purina"},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListBrands(gomock.Any()).Times(1).Return(brands, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	// the brands are public, no authorization header
+	request, err := http.NewRequest(http.MethodGet, "/brands", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []db.ListBrandsRow
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, brands, got)
+}
+
+func TestDeleteBrandAPI(t *testing.T) {
+	brandID := util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountProductsByBrand(gomock.Any(), gomock.Eq(brandID)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetBrand(gomock.Any(), gomock.Eq(brandID)).Times(1).Return(db.Brand{ID: brandID}, nil)
+				store.EXPECT().DeleteBrand(gomock.Any(), gomock.Eq(brandID)).Times(1).Return(nil)
+				store.EXPECT().CreateAuditLog(gomock.Any(), auditLogFor("brand", "delete", brandID)).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InUse",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountProductsByBrand(gomock.Any(), gomock.Eq(brandID)).Times(1).Return(int64(4), nil)
+				store.EXPECT().DeleteBrand(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/brands/%d", brandID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListProductByBrandAPI(t *testing.T) {
+	product := randomProduct()
+	product.BrandID = util.RandomInt(1, 1000)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().CountProducts(gomock.Any()).Times(0)
+	store.EXPECT().FilterProducts(gomock.Any(), gomock.Nil(), gomock.Eq([]int64{product.BrandID}), 1, 5, "", "", "", true).
+		Times(1).Return([]db.Product{product}, int64(1), nil)
+	store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+	store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return([]db.ProductCategory{}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/products?page_id=1&page_size=5&brand_ids=%d", product.BrandID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got listProductResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, int64(1), *got.Total)
+	require.Len(t, got.Products, 1)
+	require.Equal(t, product.BrandID, got.Products[0].BrandID)
+}
@@ -11,8 +11,10 @@ import (
 )
 
 type createCategoryRequest struct {
-	Name        string `json:"name" validate:"required"`
-	Description string `json:"description" validate:"required"`
+	Name         string `json:"name" validate:"required"`
+	Description  string `json:"description" validate:"required"`
+	ParentID     int64  `json:"parent_id" binding:"min=0"`
+	DisplayOrder int32  `json:"display_order"`
 }
 
 func (server *Server) createCategory(ctx *gin.Context) {
@@ -22,9 +24,21 @@ func (server *Server) createCategory(ctx *gin.Context) {
 		return
 	}
 
+	if !server.checkCategoryParent(ctx, 0, req.ParentID) {
+		return
+	}
+
+	slug, ok := server.categorySlug(ctx, req.Name, 0)
+	if !ok {
+		return
+	}
+
 	arg := db.CreateCategoryParams{
-		Name:        req.Name,
-		Description: req.Description,
+		Name:         req.Name,
+		Description:  req.Description,
+		ParentID:     req.ParentID,
+		Slug:         slug,
+		DisplayOrder: req.DisplayOrder,
 	}
 
 	category, err := server.store.CreateCategory(ctx, arg)
@@ -34,6 +48,7 @@ func (server *Server) createCategory(ctx *gin.Context) {
 		return
 	}
 
+	recordAudit(ctx, category.ID, nil, category)
 	ctx.JSON(http.StatusOK, category)
 }
 
@@ -62,6 +77,69 @@ func (server *Server) getCategory(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, category)
 }
 
+type getCategoryBySlugRequest struct {
+	Slug string `uri:"slug" binding:"required"`
+}
+
+func (server *Server) getCategoryBySlug(ctx *gin.Context) {
+	var req getCategoryBySlugRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	category, err := server.store.GetCategoryBySlug(ctx, req.Slug)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+type categoryTreeNode struct {
+	db.Category
+	Children []*categoryTreeNode `json:"children"`
+}
+
+// buildCategoryTree nests the categories under their parents keeping the order they are given in.
+// A category whose parent no longer exists is shown at the top level.
+func buildCategoryTree(categories []db.Category) []*categoryTreeNode {
+	nodes := make(map[int64]*categoryTreeNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &categoryTreeNode{Category: category, Children: []*categoryTreeNode{}}
+	}
+
+	roots := []*categoryTreeNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		parent, ok := nodes[category.ParentID]
+		if !ok || category.ParentID == category.ID {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	return roots
+}
+
+func (server *Server) getCategoryTree(ctx *gin.Context) {
+	// categories come ordered by display order, so the children are already in place
+	categories, err := server.store.ListAllCategories(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, buildCategoryTree(categories))
+}
+
 type listCategoryResponse struct {
 	Total      int64         `json:"total"`
 	Categories []db.Category `json:"categories"`
@@ -106,8 +184,10 @@ func (server *Server) listCategory(ctx *gin.Context) {
 }
 
 type updateCategoryRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ParentID     *int64 `json:"parent_id" binding:"omitempty,min=0"`
+	DisplayOrder *int32 `json:"display_order"`
 }
 
 func (server *Server) updateCategory(ctx *gin.Context) {
@@ -132,19 +212,37 @@ func (server *Server) updateCategory(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	previousCategory := existingCategory
 
 	// Update only the fields that are provided in the request
-	if req.Name != "" {
+	if req.Name != "" && req.Name != existingCategory.Name {
+		slug, ok := server.categorySlug(ctx, req.Name, categoryID)
+		if !ok {
+			return
+		}
 		existingCategory.Name = req.Name
+		existingCategory.Slug = slug
 	}
 	if req.Description != "" {
 		existingCategory.Description = req.Description
 	}
+	if req.ParentID != nil && *req.ParentID != existingCategory.ParentID {
+		if !server.checkCategoryParent(ctx, categoryID, *req.ParentID) {
+			return
+		}
+		existingCategory.ParentID = *req.ParentID
+	}
+	if req.DisplayOrder != nil {
+		existingCategory.DisplayOrder = *req.DisplayOrder
+	}
 
 	arg := db.UpdateCategoryParams{
-		ID:          categoryID,
-		Name:        existingCategory.Name,
-		Description: existingCategory.Description,
+		ID:           categoryID,
+		Name:         existingCategory.Name,
+		Description:  existingCategory.Description,
+		ParentID:     existingCategory.ParentID,
+		Slug:         existingCategory.Slug,
+		DisplayOrder: existingCategory.DisplayOrder,
 	}
 
 	// Perform the update operation with the modified category data
@@ -155,16 +253,56 @@ func (server *Server) updateCategory(ctx *gin.Context) {
 		return
 	}
 
+	recordAudit(ctx, category.ID, previousCategory, category)
 	ctx.JSON(http.StatusOK, category)
 }
 
+// categorySlug builds a unique slug from the category name.
+// categoryID is the category being renamed, 0 when creating one.
+func (server *Server) categorySlug(ctx *gin.Context, name string, categoryID int64) (string, bool) {
+	return uniqueSlug(ctx, name, categoryID, func(slug string) (int64, error) {
+		category, err := server.store.GetCategoryBySlug(ctx, slug)
+		return category.ID, err
+	})
+}
+
+// checkCategoryParent makes sure the parent exists and is not the category itself or one of
+// its subcategories, 0 means a top level category. categoryID is 0 when creating a category.
+// The error response is already written when it returns false.
+func (server *Server) checkCategoryParent(ctx *gin.Context, categoryID int64, parentID int64) bool {
+	for id := parentID; id != 0; {
+		if id == categoryID {
+			err := fmt.Errorf("category %d can't be moved under itself or its subcategories", categoryID)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return false
+		}
+
+		parent, err := server.store.GetCategory(ctx, id)
+		if err != nil {
+			if err == sql.ErrNoRows && id == parentID {
+				ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("parent category %d not found", parentID)))
+				return false
+			}
+			if err == sql.ErrNoRows {
+				// an ancestor was deleted, the chain ends here
+				break
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return false
+		}
+		id = parent.ParentID
+	}
+
+	return true
+}
+
 type deleteCategoryRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
 type listTestResponse struct {
-	Response []db.Product `json:"response"`
-	Message  string       `json:"message"`
+	Response []productResponse `json:"response"`
+	Message  string            `json:"message"`
 }
 
 func (server *Server) deleteCategory(ctx *gin.Context) {
@@ -174,72 +312,29 @@ func (server *Server) deleteCategory(ctx *gin.Context) {
 		return
 	}
 
-	response, err := server.store.ListProductsByCategory(ctx, req.ID)
-
+	// the products lose the category and the subcategories move up to its parent in the same
+	// transaction as the delete, a failure in the middle leaves the category as it was
+	result, err := server.store.DeleteCategoryTx(ctx, req.ID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	arg := listTestResponse{
-		Response: response,
-		Message:  "Category has products",
-	}
 
-	// if the category has products, dissasoicate them first through the code
-	if len(response) > 0 {
-		for _, product := range response {
-			arg := db.DisassociateProductFromCategoryParams{
-				CategoryID: req.ID,
-				ProductID:  product.ID,
-			}
-
-			_, err := server.store.DisassociateProductFromCategory(ctx, arg)
-			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-				return
-			}
-
-			// Remove category from product's categories array
-			newCategories := []int64{}
-			for _, category := range product.Categories {
-				if category != req.ID {
-					newCategories = append(newCategories, category)
-				}
-			}
-
-			updateReq := db.UpdateProductParams{
-				ID:          product.ID,
-				Name:        product.Name,
-				Description: product.Description,
-				UserID:      product.UserID,
-				Username:    product.Username,
-				Price:       product.Price,
-				Sku:         product.Sku,
-				Url:         product.Url,
-				Images:      product.Images,
-				Categories:  newCategories,
-			}
-
-			_, err = server.store.UpdateProduct(ctx, updateReq)
-			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-				return
-			}
-		}
-	}
-
-	// delete existing category
-	err = server.store.DeleteCategory(ctx, req.ID)
+	products, err := server.newProductResponses(ctx, result.Products)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	arg = listTestResponse{
-		Response: response,
-		Message:  "Category deleted successfully",
-	}
 
-	ctx.JSON(http.StatusOK, arg)
+	recordAudit(ctx, result.Category.ID, result.Category, nil)
+	ctx.JSON(http.StatusOK, listTestResponse{
+		Response: products,
+		Message:  "Category deleted successfully",
+	})
 }
 
 type associateCategoryWithProductRequest struct {
@@ -254,12 +349,12 @@ func (server *Server) associateCategoryWithProduct(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.AssociateProductWithCategoryParams{
-		CategoryID: req.CategoryID,
-		ProductID:  req.ProductID,
+	arg := db.ProductCategoriesTxParams{
+		ProductID:   req.ProductID,
+		CategoryIDs: []int64{req.CategoryID},
 	}
 
-	category, err := server.store.AssociateProductWithCategory(ctx, arg)
+	_, err := server.store.AssociateCategoriesTx(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -270,7 +365,7 @@ func (server *Server) associateCategoryWithProduct(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, category)
+	ctx.JSON(http.StatusOK, db.ProductCategory{ProductID: req.ProductID, CategoryID: req.CategoryID})
 }
 
 type disassociateCategoryWithProductRequest struct {
@@ -285,12 +380,12 @@ func (server *Server) disassociateCategoryWithProduct(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.DisassociateProductFromCategoryParams{
-		CategoryID: req.CategoryID,
-		ProductID:  req.ProductID,
+	arg := db.ProductCategoriesTxParams{
+		ProductID:   req.ProductID,
+		CategoryIDs: []int64{req.CategoryID},
 	}
 
-	category, err := server.store.DisassociateProductFromCategory(ctx, arg)
+	_, err := server.store.DisassociateCategoriesTx(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -301,7 +396,7 @@ func (server *Server) disassociateCategoryWithProduct(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, category)
+	ctx.JSON(http.StatusOK, db.ProductCategory{ProductID: req.ProductID, CategoryID: req.CategoryID})
 }
 
 type listProductCategoriesRequest struct {
@@ -352,35 +447,21 @@ func (server *Server) associateMultipleCategoriesWithProduct(ctx *gin.Context) {
 		return
 	}
 
-	// Get the list of currently associated categories
-	currentCategories, err := server.store.ListCategoriesByProduct(ctx, uri.ProductID)
+	categoryIDs := []int64{}
+	for _, category := range json.Categories {
+		categoryIDs = append(categoryIDs, category.ID)
+	}
+
+	// categories already associated are skipped, all the new links are created or none
+	_, err := server.store.AssociateCategoriesTx(ctx, db.ProductCategoriesTxParams{
+		ProductID:   uri.ProductID,
+		CategoryIDs: categoryIDs,
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	// Create a map for quick lookup
-	currentCategoryMap := make(map[int64]bool)
-	for _, category := range currentCategories {
-		currentCategoryMap[category.ID] = true
-	}
-
-	for _, category := range json.Categories {
-		// If the category is not already associated, associate it
-		if !currentCategoryMap[category.ID] {
-			arg := db.AssociateProductWithCategoryParams{
-				CategoryID: category.ID,
-				ProductID:  uri.ProductID,
-			}
-
-			_, err = server.store.AssociateProductWithCategory(ctx, arg)
-			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-				return
-			}
-		}
-	}
-
 	ctx.JSON(http.StatusOK, gin.H{"status": "success"})
 }
 
@@ -405,17 +486,18 @@ func (server *Server) disassociateMultipleCategoriesWithProduct(ctx *gin.Context
 		return
 	}
 
-	for _, categoryID := range json.CategoryIDs {
-		arg := db.DisassociateProductFromCategoryParams{
-			CategoryID: categoryID,
-			ProductID:  uri.ProductID,
-		}
-
-		_, err := server.store.DisassociateProductFromCategory(ctx, arg)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	// all the links are removed or none
+	_, err := server.store.DisassociateCategoriesTx(ctx, db.ProductCategoriesTxParams{
+		ProductID:   uri.ProductID,
+		CategoryIDs: json.CategoryIDs,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"status": "success"})
@@ -2,12 +2,12 @@ package api
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
-	"strings"
 	mockdb "super-pet-delivery/db/mock"
 	db "super-pet-delivery/db/sqlc"
 	"super-pet-delivery/token"
@@ -44,6 +44,7 @@ func TestCreateCategoryAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				// Define expectations for the CreateCategory function in your mock store.
+				store.EXPECT().GetCategoryBySlug(gomock.Any(), gomock.Eq(sanitizeName(category.Name))).Times(1).Return(db.Category{}, sql.ErrNoRows)
 				store.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(1).Return(category, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -84,10 +85,12 @@ func TestCreateCategoryAPI(t *testing.T) {
 
 // randomCategory generates a random category for testing
 func randomCategory() db.Category {
+	name := util.RandomString(10)
 	return db.Category{
 		ID:          util.RandomInt(1, 1000),
-		Name:        util.RandomString(10),
+		Name:        name,
 		Description: util.RandomString(50),
+		Slug:        name,
 	}
 }
 
@@ -187,6 +190,7 @@ func TestUpdateCategoryAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				// Define expectations for the GetCategory and UpdateCategory functions in your mock store.
 				store.EXPECT().GetCategory(gomock.Any(), category.ID).Times(1).Return(category, nil)
+				store.EXPECT().GetCategoryBySlug(gomock.Any(), gomock.Eq("updated-category-name")).Times(1).Return(db.Category{}, sql.ErrNoRows)
 				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Times(1).Return(category, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -247,12 +251,66 @@ func TestDeleteCategoryAPI_OK(t *testing.T) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				// Define expectations for the DeleteCategory function in your mock store.
-				store.EXPECT().DeleteCategory(gomock.Any(), category.ID).Times(1).Return(nil)
+				store.EXPECT().DeleteCategoryTx(gomock.Any(), category.ID).Times(1).Return(db.DeleteCategoryTxResult{Category: category, Products: []db.Product{}}, nil)
+				// every step runs inside the transaction
+				store.EXPECT().DeleteCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().MoveChildCategories(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				require.Equal(t, "Category deleted successfully", strings.Trim(recorder.Body.String(), "\n\""))
+				requireDeleteCategoryMessage(t, recorder.Body, "Category deleted successfully")
+			},
+		},
+		{
+			name:       "WithProducts",
+			categoryID: category.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				product := randomProduct()
+
+				result := db.DeleteCategoryTxResult{Category: category, Products: []db.Product{product}}
+				store.EXPECT().DeleteCategoryTx(gomock.Any(), category.ID).Times(1).Return(result, nil)
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+				links := []db.ProductCategory{{ProductID: product.ID, CategoryID: category.ID + 1}}
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return(links, nil)
+				store.EXPECT().DisassociateCategoriesTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listTestResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, "Category deleted successfully", got.Message)
+				require.Len(t, got.Response, 1)
+				require.Equal(t, []int64{category.ID + 1}, got.Response[0].Categories)
+			},
+		},
+		{
+			name:       "NotFound",
+			categoryID: category.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteCategoryTx(gomock.Any(), category.ID).Times(1).Return(db.DeleteCategoryTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			categoryID: category.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteCategoryTx(gomock.Any(), category.ID).Times(1).Return(db.DeleteCategoryTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 	}
@@ -284,3 +342,142 @@ func TestDeleteCategoryAPI_OK(t *testing.T) {
 		})
 	}
 }
+
+func requireDeleteCategoryMessage(t *testing.T, body *bytes.Buffer, message string) {
+	var got listTestResponse
+	require.NoError(t, json.Unmarshal(body.Bytes(), &got))
+	require.Equal(t, message, got.Message)
+}
+
+func TestUpdateCategoryParentAPI(t *testing.T) {
+	parent := randomCategory()
+	category := randomCategory()
+	category.ParentID = parent.ID
+
+	testCases := []struct {
+		name          string
+		parentID      int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "MoveToTopLevel",
+			parentID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				arg := db.UpdateCategoryParams{
+					ID:          category.ID,
+					Name:        category.Name,
+					Description: category.Description,
+					Slug:        category.Slug,
+				}
+				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Category{ID: category.ID}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "UnderItsOwnChild",
+			parentID: category.ID + 1000,
+			buildStubs: func(store *mockdb.MockStore) {
+				child := db.Category{ID: category.ID + 1000, ParentID: category.ID}
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(child.ID)).Times(1).Return(child, nil)
+				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "ParentNotFound",
+			parentID: category.ID + 2000,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID+2000)).Times(1).Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			requestBody, err := json.Marshal(map[string]int64{"parent_id": tc.parentID})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/categories/%d", category.ID), bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	categories := []db.Category{
+		{ID: 1, Name: "Cães"},
+		{ID: 2, Name: "Gatos"},
+		{ID: 3, Name: "Rações", ParentID: 1},
+		{ID: 4, Name: "Petiscos", ParentID: 1},
+		{ID: 5, Name: "Filhotes", ParentID: 3},
+		{ID: 6, Name: "Órfã", ParentID: 99},
+	}
+
+	tree := buildCategoryTree(categories)
+	require.Len(t, tree, 3)
+	require.Equal(t, int64(1), tree[0].ID)
+	require.Equal(t, int64(2), tree[1].ID)
+	require.Equal(t, int64(6), tree[2].ID)
+
+	require.Len(t, tree[0].Children, 2)
+	require.Equal(t, int64(3), tree[0].Children[0].ID)
+	require.Equal(t, int64(4), tree[0].Children[1].ID)
+	require.Len(t, tree[0].Children[0].Children, 1)
+	require.Equal(t, int64(5), tree[0].Children[0].Children[0].ID)
+	require.Empty(t, tree[1].Children)
+}
+
+func TestGetCategoryTreeAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListAllCategories(gomock.Any()).Times(1).Return([]db.Category{
+		{ID: 1, Name: "Cães", Slug: "caes"},
+		{ID: 3, Name: "Rações", Slug: "racoes", ParentID: 1},
+	}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	// the tree is public, no authorization header
+	request, err := http.NewRequest(http.MethodGet, "/categories/tree", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []categoryTreeNode
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	require.Equal(t, "caes", got[0].Slug)
+	require.Len(t, got[0].Children, 1)
+	require.Equal(t, "racoes", got[0].Children[0].Slug)
+}
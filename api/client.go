@@ -2,6 +2,7 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -51,6 +52,7 @@ func (server *Server) createClient(ctx *gin.Context) {
 		return
 	}
 
+	recordAudit(ctx, client.ID, nil, client)
 	ctx.JSON(http.StatusOK, client)
 }
 
@@ -76,20 +78,34 @@ func (server *Server) getClient(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, client)
+	balance, err := server.store.GetLoyaltyBalance(ctx, client.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(ctx, client.Version)
+	ctx.JSON(http.StatusOK, clientResponse{Client: client, LoyaltyPoints: balance})
+}
+
+type clientResponse struct {
+	db.Client
+	LoyaltyPoints int64 `json:"loyalty_points"`
 }
 
 type listClientResponse struct {
-	Total   int64       `json:"total"`
-	Clients []db.Client `json:"clients"`
+	Total      *int64      `json:"total,omitempty"`
+	Clients    []db.Client `json:"clients"`
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
 
 type listClientRequest struct {
-	PageID        int32  `form:"page_id" binding:"required,min=1"`
-	PageSize      int32  `form:"page_size" binding:"required,min=5,max=100"`
-	SortField     string `form:"sort_field" binding:""`
-	SortDirection string `form:"sort_direction" binding:""`
-	Search        string `form:"search" binding:""`
+	PageID        int32   `form:"page_id" binding:"required_without=Cursor,min=0"`
+	Cursor        *string `form:"cursor"`
+	PageSize      int32   `form:"page_size" binding:"required,min=5,max=100"`
+	SortField     string  `form:"sort_field" binding:""`
+	SortDirection string  `form:"sort_direction" binding:""`
+	Search        string  `form:"search" binding:""`
 }
 
 func (server *Server) listClient(ctx *gin.Context) {
@@ -99,47 +115,73 @@ func (server *Server) listClient(ctx *gin.Context) {
 		return
 	}
 
+	if req.Cursor != nil {
+		server.listClientPage(ctx, req)
+		return
+	}
+
 	arg := db.ListClientsParams{
 		Limit:  req.PageSize,
 		Offset: (req.PageID - 1) * req.PageSize,
 	}
 
-	// Fetch the total number of clients
-	total, err := server.store.CountClients(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+	// the searches count the clients they match, the other lists count all of them
+	var total int64
+	var err error
+	if req.Search == "" {
+		total, err = server.store.CountClients(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
 	}
 
 	var clients []db.Client
 	// Check if sort fields and search are provided
 	if req.SortField != "" && req.SortDirection != "" && req.Search != "" {
 		// Fetch the paginated clients with sorting and search
-		clients, err = server.store.SearchClients(ctx, req.Search, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection)
+		clients, total, err = server.store.SearchClients(ctx, req.Search, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection)
 	} else if req.SortField != "" && req.SortDirection != "" {
 		// Fetch the paginated clients with sorting
 		clients, err = server.store.ListClientsSorted(ctx, arg, req.SortField, req.SortDirection)
 	} else if req.Search != "" {
 		// Fetch the paginated clients with search
-		clients, err = server.store.SearchClients(ctx, req.Search, int(req.PageID), int(req.PageSize), "", "")
+		clients, total, err = server.store.SearchClients(ctx, req.Search, int(req.PageID), int(req.PageSize), "", "")
 	} else {
 		// Fetch the paginated clients without sorting or search
 		clients, err = server.store.ListClients(ctx, arg)
 	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeListError(ctx, err)
 		return
 	}
 
 	// Create the response structure
 	response := listClientResponse{
-		Total:   total,
+		Total:   &total,
 		Clients: clients,
 	}
 
 	ctx.JSON(http.StatusOK, response)
 }
 
+// listClientPage is the cursor mode of listClient
+func (server *Server) listClientPage(ctx *gin.Context, req listClientRequest) {
+	clients, next, err := server.store.ListClientsPage(ctx, db.PageParams{
+		Cursor:        *req.Cursor,
+		Limit:         req.PageSize,
+		SortField:     req.SortField,
+		SortDirection: req.SortDirection,
+		Search:        req.Search,
+	})
+	if err != nil {
+		writeListError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listClientResponse{Clients: clients, NextCursor: next})
+}
+
 type updateClientRequest struct {
 	FullName            string `json:"full_name"`
 	PhoneWhatsapp       string `json:"phone_whatsapp"`
@@ -151,6 +193,7 @@ type updateClientRequest struct {
 	AddressNumber       string `json:"address_number"`
 	AddressNeighborhood string `json:"address_neighborhood"`
 	AddressReference    string `json:"address_reference"`
+	Version             int64  `json:"version" binding:"omitempty,min=1"`
 }
 
 func (server *Server) updateClient(ctx *gin.Context) {
@@ -160,6 +203,11 @@ func (server *Server) updateClient(ctx *gin.Context) {
 		return
 	}
 
+	version, ok := expectedVersion(ctx, req.Version)
+	if !ok {
+		return
+	}
+
 	clientID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 	fmt.Println(clientID)
 	if err != nil {
@@ -176,6 +224,11 @@ func (server *Server) updateClient(ctx *gin.Context) {
 		return
 	}
 
+	if !checkVersion(ctx, version, existingClient.Version) {
+		return
+	}
+	previousClient := existingClient
+
 	// Update only the fields that are provided in the request
 	// Conditional Checks for Updating Client Fields
 
@@ -233,16 +286,24 @@ func (server *Server) updateClient(ctx *gin.Context) {
 		AddressNumber:       existingClient.AddressNumber,
 		AddressNeighborhood: existingClient.AddressNeighborhood,
 		AddressReference:    existingClient.AddressReference,
+		Version:             version,
 	}
 
 	// Perform the update operation with the modified client data
 	client, err := server.store.UpdateClient(ctx, arg)
 	if err != nil {
 		fmt.Println("error in updating client")
+		// the version changed after the client was read above
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(db.ErrVersionConflict))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	recordAudit(ctx, client.ID, previousClient, client)
+	setETag(ctx, client.Version)
 	ctx.JSON(http.StatusOK, client)
 }
 
@@ -317,6 +378,7 @@ func (server *Server) deleteClient(ctx *gin.Context) {
 	}
 	log.Println("Sales:", sales)
 
+	var newClientID int64
 	if len(sales) > 0 {
 		var body deleteClientRequestBody
 		if err := ctx.ShouldBindJSON(&body); err != nil {
@@ -326,47 +388,39 @@ func (server *Server) deleteClient(ctx *gin.Context) {
 		}
 		log.Println("Body:", body)
 
-		newClient, err := server.store.GetClient(ctx, body.NewClientID)
-		if err != nil {
-			log.Println("Error getting new client by ID:", err)
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-
 		// If sales are associated, check if a new client ID is provided in the request body
-		if body.NewClientID != 0 && body.NewClientID != req.ID {
-			// Update the sales' client to the new client ID
-			for _, s := range sales {
-				arg := db.UpdateSaleParams{
-					ID:          s.ID,
-					ClientID:    body.NewClientID,
-					ClientName:  newClient.FullName,
-					Product:     s.Product,
-					Price:       s.Price,
-					Observation: s.Observation,
-				}
-				sale, err := server.store.UpdateSale(ctx, arg)
-				log.Println("Sale:", sale)
-				if err != nil {
-					log.Println("Error updating sale:", err)
-					ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-					return
-				}
-			}
-		} else {
-			// If no new client ID is provided, return an error indicating sales are associated
+		if body.NewClientID == 0 || body.NewClientID == req.ID {
 			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Client has associated sales. Provide a new client ID to reassign sales."})
 			return
 		}
+		newClientID = body.NewClientID
 	}
 
-	// delete existing client
-	err = server.store.DeleteClient(ctx, req.ID)
+	// the sales move to the new client and the client is deleted in one transaction
+	err = server.store.DeleteClientTx(ctx, db.DeleteClientTxParams{
+		ID:          req.ID,
+		NewClientID: newClientID,
+	})
 	if err != nil {
 		log.Println("Error deleting client:", err)
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		switch {
+		case errors.Is(err, db.ErrClientHasSales):
+			// a sale was created after the check above
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, db.ErrVersionConflict):
+			// a sale was changed while it was moved to the new client
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, db.ErrClientHasSubscriptions), errors.Is(err, db.ErrClientHasPoints):
+			// the subscriptions and the points would be lost with the client
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, sql.ErrNoRows):
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("new client %d not found", newClientID)))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
 		return
 	}
 
+	recordAudit(ctx, client.ID, client, nil)
 	ctx.JSON(http.StatusOK, "Client deleted successfully")
 }
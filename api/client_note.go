@@ -0,0 +1,344 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/token"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type clientNoteUri struct {
+	ClientID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type createClientNoteRequest struct {
+	Body   string `json:"body" binding:"required"`
+	Pinned bool   `json:"pinned"`
+}
+
+func (server *Server) createClientNote(ctx *gin.Context) {
+	var uri clientNoteUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req createClientNoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.GetClient(ctx, uri.ClientID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.CreateClientNoteParams{
+		ClientID: uri.ClientID,
+		Author:   authPayload.Username,
+		Body:     req.Body,
+		Pinned:   req.Pinned,
+	}
+
+	note, err := server.store.CreateClientNote(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, note.ID, nil, note)
+
+	ctx.JSON(http.StatusOK, note)
+}
+
+func (server *Server) listClientNotes(ctx *gin.Context) {
+	var uri clientNoteUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	notes, err := server.store.ListClientNotes(ctx, uri.ClientID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, notes)
+}
+
+type singleClientNoteUri struct {
+	ClientID int64 `uri:"id" binding:"required,min=1"`
+	NoteID   int64 `uri:"note_id" binding:"required,min=1"`
+}
+
+type updateClientNoteRequest struct {
+	Body   string `json:"body"`
+	Pinned *bool  `json:"pinned"`
+}
+
+func (server *Server) updateClientNote(ctx *gin.Context) {
+	var uri singleClientNoteUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateClientNoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	existingNote, err := server.getNoteOfClient(ctx, uri)
+	if err != nil {
+		return
+	}
+	previousNote := existingNote
+
+	// Update only the fields that are provided in the request
+	if req.Body != "" {
+		existingNote.Body = req.Body
+	}
+	if req.Pinned != nil {
+		existingNote.Pinned = *req.Pinned
+	}
+
+	arg := db.UpdateClientNoteParams{
+		ID:     existingNote.ID,
+		Body:   existingNote.Body,
+		Pinned: existingNote.Pinned,
+	}
+
+	note, err := server.store.UpdateClientNote(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, note.ID, previousNote, note)
+
+	ctx.JSON(http.StatusOK, note)
+}
+
+func (server *Server) deleteClientNote(ctx *gin.Context) {
+	var uri singleClientNoteUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	note, err := server.getNoteOfClient(ctx, uri)
+	if err != nil {
+		return
+	}
+
+	err = server.store.DeleteClientNote(ctx, uri.NoteID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, note.ID, note, nil)
+
+	ctx.JSON(http.StatusOK, "Note deleted successfully")
+}
+
+// getNoteOfClient fetches a note and makes sure it belongs to the client in the url.
+// The error response is already written when it returns an error.
+func (server *Server) getNoteOfClient(ctx *gin.Context, uri singleClientNoteUri) (db.ClientNote, error) {
+	note, err := server.store.GetClientNote(ctx, uri.NoteID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return note, err
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return note, err
+	}
+
+	if note.ClientID != uri.ClientID {
+		err := fmt.Errorf("note %d does not belong to client %d", uri.NoteID, uri.ClientID)
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return note, err
+	}
+
+	return note, nil
+}
+
+// Types of entries in the client timeline
+const (
+	timelineNote   = "note"
+	timelineSale   = "sale"
+	timelineStatus = "status"
+	// payments are the coupon discounts and the loyalty points used on the sales
+	timelinePayment = "payment"
+	timelinePoints  = "points"
+)
+
+type timelineEntry struct {
+	Type       string    `json:"type"`
+	ID         int64     `json:"id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Title      string    `json:"title"`
+	Detail     string    `json:"detail"`
+	Author     string    `json:"author,omitempty"`
+	Pinned     bool      `json:"pinned"`
+}
+
+var saleStatusTitles = map[string]string{
+	db.SaleStatusPending:   "Pedido aguardando confirmação",
+	db.SaleStatusConfirmed: "Pedido confirmado",
+}
+
+var loyaltyTitles = map[string]string{
+	db.LoyaltyKindEarn:     "Pontos ganhos",
+	db.LoyaltyKindRedeem:   "Pontos usados no pagamento",
+	db.LoyaltyKindReversal: "Pontos estornados",
+}
+
+// buildTimeline merges the notes, sales, sale status changes, coupon discounts and loyalty points of a client
+// in chronological order. The points used on a sale and its discounts are its payments.
+func buildTimeline(notes []db.ClientNote, sales []db.Sale, history []db.SaleStatusHistory, discounts []db.SaleDiscount, points []db.LoyaltyLedger) []timelineEntry {
+	timeline := []timelineEntry{}
+
+	for _, note := range notes {
+		timeline = append(timeline, timelineEntry{
+			Type:       timelineNote,
+			ID:         note.ID,
+			OccurredAt: note.CreatedAt,
+			Title:      "Nota",
+			Detail:     note.Body,
+			Author:     note.Author,
+			Pinned:     note.Pinned,
+		})
+	}
+
+	for _, sale := range sales {
+		timeline = append(timeline, timelineEntry{
+			Type:       timelineSale,
+			ID:         sale.ID,
+			OccurredAt: sale.CreatedAt,
+			Title:      fmt.Sprintf("Pedido #%d", sale.ID),
+			Detail:     fmt.Sprintf("%s - R$ %.2f", sale.Product, sale.Price),
+		})
+	}
+
+	for _, change := range history {
+		title, ok := saleStatusTitles[change.Status]
+		if !ok {
+			title = change.Status
+		}
+
+		timeline = append(timeline, timelineEntry{
+			Type:       timelineStatus,
+			ID:         change.SaleID,
+			OccurredAt: change.CreatedAt,
+			Title:      title,
+			Detail:     fmt.Sprintf("Pedido #%d", change.SaleID),
+			Author:     change.ChangedBy,
+		})
+	}
+
+	for _, discount := range discounts {
+		timeline = append(timeline, timelineEntry{
+			Type:       timelinePayment,
+			ID:         discount.SaleID,
+			OccurredAt: discount.CreatedAt,
+			Title:      fmt.Sprintf("Cupom %s", discount.Code),
+			Detail:     fmt.Sprintf("Pedido #%d - desconto de R$ %.2f", discount.SaleID, discount.Amount),
+		})
+	}
+
+	for _, entry := range points {
+		entryType := timelinePoints
+		if entry.Kind == db.LoyaltyKindRedeem {
+			entryType = timelinePayment
+		}
+		title, ok := loyaltyTitles[entry.Kind]
+		if !ok {
+			title = entry.Kind
+		}
+
+		timeline = append(timeline, timelineEntry{
+			Type:       entryType,
+			ID:         entry.SaleID,
+			OccurredAt: entry.CreatedAt,
+			Title:      title,
+			Detail:     fmt.Sprintf("Pedido #%d - %d pontos", entry.SaleID, entry.Points),
+		})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].OccurredAt.Before(timeline[j].OccurredAt)
+	})
+
+	return timeline
+}
+
+type getClientTimelineRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getClientTimeline(ctx *gin.Context) {
+	var req getClientTimelineRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.GetClient(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	notes, err := server.store.ListClientNotes(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	sales, err := server.store.GetSalesByClientID(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	history, err := server.store.ListSaleStatusHistoryByClient(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	discounts, err := server.store.ListSaleDiscountsByClient(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	points, err := server.store.ListLoyaltyEntries(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, buildTimeline(notes, sales, history, discounts, points))
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/token"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateClientNoteAPI(t *testing.T) {
+	client := randomClient()
+	note := db.ClientNote{
+		ID:       util.RandomInt(1, 1000),
+		ClientID: client.ID,
+		Author:   "username",
+		Body:     "cachorro bravo, tocar a campainha duas vezes",
+		Pinned:   true,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"body": note.Body, "pinned": true},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				arg := db.CreateClientNoteParams{
+					ClientID: client.ID,
+					Author:   "username",
+					Body:     note.Body,
+					Pinned:   true,
+				}
+				store.EXPECT().CreateClientNote(gomock.Any(), gomock.Eq(arg)).Times(1).Return(note, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var gotNote db.ClientNote
+				require.NoError(t, json.Unmarshal(data, &gotNote))
+				require.Equal(t, note, gotNote)
+			},
+		},
+		{
+			name: "ClientNotFound",
+			body: gin.H{"body": note.Body},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(db.Client{}, sql.ErrNoRows)
+				store.EXPECT().CreateClientNote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "EmptyBody",
+			body: gin.H{"pinned": true},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateClientNote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			requestBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/clients/%d/notes", client.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestBuildTimeline(t *testing.T) {
+	start := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	notes := []db.ClientNote{
+		{ID: 1, Body: "portão azul", Pinned: true, CreatedAt: start.Add(2 * time.Hour)},
+	}
+	sales := []db.Sale{
+		{ID: 7, Product: "Ração 15kg", Price: 150, CreatedAt: start},
+		{ID: 8, Product: "Areia", Price: 30, CreatedAt: start.Add(3 * time.Hour)},
+	}
+	history := []db.SaleStatusHistory{
+		{SaleID: 7, Status: db.SaleStatusConfirmed, ChangedBy: "admin", CreatedAt: start.Add(time.Hour)},
+	}
+
+	discounts := []db.SaleDiscount{
+		{SaleID: 8, Code: "BEMVINDO", Amount: 5, CreatedAt: start.Add(3*time.Hour + time.Minute)},
+	}
+	points := []db.LoyaltyLedger{
+		{SaleID: 8, Points: -50, Kind: db.LoyaltyKindRedeem, CreatedAt: start.Add(3*time.Hour + 2*time.Minute)},
+		{SaleID: 7, Points: 15, Kind: db.LoyaltyKindEarn, CreatedAt: start.Add(time.Hour + time.Minute)},
+	}
+
+	timeline := buildTimeline(notes, sales, history, discounts, points)
+	require.Len(t, timeline, 7)
+
+	require.Equal(t, timelineSale, timeline[0].Type)
+	require.Equal(t, int64(7), timeline[0].ID)
+	require.Equal(t, timelineStatus, timeline[1].Type)
+	require.Equal(t, "Pedido confirmado", timeline[1].Title)
+	require.Equal(t, "admin", timeline[1].Author)
+	require.Equal(t, timelinePoints, timeline[2].Type)
+	require.Equal(t, "Pontos ganhos", timeline[2].Title)
+	require.Equal(t, timelineNote, timeline[3].Type)
+	require.True(t, timeline[3].Pinned)
+	require.Equal(t, timelineSale, timeline[4].Type)
+	require.Equal(t, int64(8), timeline[4].ID)
+	require.Equal(t, timelinePayment, timeline[5].Type)
+	require.Equal(t, "Pedido #8 - desconto de R$ 5.00", timeline[5].Detail)
+	require.Equal(t, timelinePayment, timeline[6].Type)
+	require.Equal(t, "Pontos usados no pagamento", timeline[6].Title)
+}
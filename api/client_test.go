@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -134,9 +135,11 @@ func TestGetClientAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetLoyaltyBalance(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(int64(120), nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"loyalty_points":120`)
 				requireBodyMatchClient(t, recorder.Body, client)
 			},
 		},
@@ -247,6 +250,10 @@ func TestListClientAPI(t *testing.T) {
 					Offset: 0,
 				}
 
+				store.EXPECT().
+					CountClients(gomock.Any()).
+					Times(1).
+					Return(int64(n), nil)
 				store.EXPECT().
 					ListClients(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
@@ -254,7 +261,7 @@ func TestListClientAPI(t *testing.T) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchClients(t, recorder.Body, clients)
+				requireBodyMatchClients(t, recorder.Body, clients, int64(n))
 			},
 		},
 		{
@@ -267,6 +274,10 @@ func TestListClientAPI(t *testing.T) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CountClients(gomock.Any()).
+					Times(1).
+					Return(int64(n), nil)
 				store.EXPECT().
 					ListClients(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -276,6 +287,28 @@ func TestListClientAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "CountError",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CountClients(gomock.Any()).
+					Times(1).
+					Return(int64(0), sql.ErrConnDone)
+				store.EXPECT().
+					ListClients(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name: "InvalidPageID",
 			query: Query{
@@ -412,34 +445,124 @@ func TestListClientAPI(t *testing.T) {
 	}
 } */
 
-// TODO FIX HERE NOT PASSING
-/* func TestDeleteClientAPI(t *testing.T) {
-	// Generate a random client for testing.
+func TestDeleteClientAPI(t *testing.T) {
 	client := randomClient()
+	newClient := randomClient()
+	newClient.ID = client.ID + 1
+	sales := []db.Sale{{ID: 7, ClientID: client.ID}}
 
 	testCases := []struct {
 		name          string
-		clientID      int64
-		request       deleteClientRequest
+		body          gin.H
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:     "OK",
-			clientID: client.ID,
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetSalesByClientID(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return([]db.Sale{}, nil)
+				store.EXPECT().DeleteClientTx(gomock.Any(), gomock.Eq(db.DeleteClientTxParams{ID: client.ID})).Times(1).Return(nil)
+				store.EXPECT().DeleteClient(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `"Client deleted successfully"`, recorder.Body.String())
+			},
+		},
+		{
+			name: "ReassignSales",
+			body: gin.H{"new_client_id": newClient.ID},
 			buildStubs: func(store *mockdb.MockStore) {
-				// Expect GetClient to return the client.
 				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
-				// Expect DeleteClient to delete the client.
-				store.EXPECT().DeleteClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(nil)
+				store.EXPECT().GetSalesByClientID(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(sales, nil)
+				arg := db.DeleteClientTxParams{ID: client.ID, NewClientID: newClient.ID}
+				store.EXPECT().DeleteClientTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil)
+				// the sales are moved inside the transaction
+				store.EXPECT().UpdateSale(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				// Verify the response message.
-				responseBody, err := io.ReadAll(recorder.Body)
-				require.NoError(t, err)
-				// Updated expected response format to match the actual response.
-				require.Equal(t, `"Client deleted successfully"`, string(responseBody))
+			},
+		},
+		{
+			name: "SalesWithoutNewClient",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetSalesByClientID(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(sales, nil)
+				store.EXPECT().DeleteClientTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "SaleCreatedMeanwhile",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetSalesByClientID(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return([]db.Sale{}, nil)
+				store.EXPECT().DeleteClientTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ErrClientHasSales)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "SaleChangedMeanwhile",
+			body: gin.H{"new_client_id": newClient.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetSalesByClientID(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(sales, nil)
+				store.EXPECT().DeleteClientTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ErrVersionConflict)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "HasSubscriptions",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetSalesByClientID(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return([]db.Sale{}, nil)
+				store.EXPECT().DeleteClientTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ErrClientHasSubscriptions)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "HasPoints",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetSalesByClientID(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return([]db.Sale{}, nil)
+				store.EXPECT().DeleteClientTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ErrClientHasPoints)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NewClientNotFound",
+			body: gin.H{"new_client_id": newClient.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetSalesByClientID(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(sales, nil)
+				store.EXPECT().DeleteClientTx(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetSalesByClientID(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return([]db.Sale{}, nil)
+				store.EXPECT().DeleteClientTx(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 	}
@@ -457,21 +580,19 @@ func TestListClientAPI(t *testing.T) {
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			// Marshal the request body into JSON.
-			requestBody, err := json.Marshal(tc.request)
+			requestBody, err := json.Marshal(tc.body)
 			require.NoError(t, err)
 
-			// Create an HTTP request with the JSON body.
-			url := fmt.Sprintf("/clients/%d", tc.clientID)
+			url := fmt.Sprintf("/clients/%d", client.ID)
 			request, err := http.NewRequest(http.MethodDelete, url, bytes.NewReader(requestBody))
 			require.NoError(t, err)
 
-			// Serve the request and check the response.
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(t, recorder)
 		})
 	}
-} */
+}
 
 // randomClient generates a random client for testing.
 func randomClient() db.Client {
@@ -500,13 +621,14 @@ func requireBodyMatchClient(t *testing.T, body *bytes.Buffer, client db.Client)
 	require.Equal(t, client, gotClient)
 }
 
-// requireBodyMatchClients checks if the response body matches the expected list of clients.
-func requireBodyMatchClients(t *testing.T, body *bytes.Buffer, clients []db.Client) {
+// requireBodyMatchClients checks if the response body matches the expected list of clients and their total.
+func requireBodyMatchClients(t *testing.T, body *bytes.Buffer, clients []db.Client, total int64) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotClients []db.Client
-	err = json.Unmarshal(data, &gotClients)
+	var got listClientResponse
+	err = json.Unmarshal(data, &got)
 	require.NoError(t, err)
-	require.Equal(t, clients, gotClients)
+	require.Equal(t, clients, got.Clients)
+	require.Equal(t, total, *got.Total)
 }
@@ -0,0 +1,408 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/promotion"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type createCouponRequest struct {
+	Code             string    `json:"code" binding:"required"`
+	Description      string    `json:"description"`
+	DiscountType     string    `json:"discount_type" binding:"required,oneof=percentage fixed"`
+	DiscountValue    float64   `json:"discount_value" binding:"required,gt=0"`
+	MinOrderValue    float64   `json:"min_order_value" binding:"min=0"`
+	StartsAt         time.Time `json:"starts_at"`
+	EndsAt           time.Time `json:"ends_at"`
+	MaxUses          int32     `json:"max_uses" binding:"min=0"`
+	MaxUsesPerClient int32     `json:"max_uses_per_client" binding:"min=0"`
+	CategoryIDs      []int64   `json:"category_ids"`
+	ProductIDs       []int64   `json:"product_ids"`
+}
+
+// normalizeCouponCode makes coupon codes case insensitive
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (server *Server) createCoupon(ctx *gin.Context) {
+	var req createCouponRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.StartsAt.IsZero() {
+		req.StartsAt = time.Now()
+	}
+	if req.CategoryIDs == nil {
+		req.CategoryIDs = []int64{}
+	}
+	if req.ProductIDs == nil {
+		req.ProductIDs = []int64{}
+	}
+
+	arg := db.CreateCouponParams{
+		Code:             normalizeCouponCode(req.Code),
+		Description:      req.Description,
+		DiscountType:     req.DiscountType,
+		DiscountValue:    req.DiscountValue,
+		MinOrderValue:    req.MinOrderValue,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		MaxUses:          req.MaxUses,
+		MaxUsesPerClient: req.MaxUsesPerClient,
+		CategoryIds:      req.CategoryIDs,
+		ProductIds:       req.ProductIDs,
+	}
+
+	err := promotion.ValidateCoupon(db.Coupon{
+		DiscountType:  arg.DiscountType,
+		DiscountValue: arg.DiscountValue,
+		StartsAt:      arg.StartsAt,
+		EndsAt:        arg.EndsAt,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	coupon, err := server.store.CreateCoupon(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, coupon.ID, nil, coupon)
+	ctx.JSON(http.StatusOK, coupon)
+}
+
+type getCouponRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getCoupon(ctx *gin.Context) {
+	var req getCouponRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	coupon, err := server.store.GetCoupon(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, coupon)
+}
+
+type listCouponRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type listCouponResponse struct {
+	Total   int64       `json:"total"`
+	Coupons []db.Coupon `json:"coupons"`
+}
+
+func (server *Server) listCoupon(ctx *gin.Context) {
+	var req listCouponRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	total, err := server.store.CountCoupons(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	coupons, err := server.store.ListCoupons(ctx, db.ListCouponsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listCouponResponse{Total: total, Coupons: coupons})
+}
+
+type updateCouponRequest struct {
+	Description      string     `json:"description"`
+	DiscountType     string     `json:"discount_type" binding:"omitempty,oneof=percentage fixed"`
+	DiscountValue    float64    `json:"discount_value" binding:"min=0"`
+	MinOrderValue    *float64   `json:"min_order_value" binding:"omitempty,min=0"`
+	StartsAt         *time.Time `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at"`
+	MaxUses          *int32     `json:"max_uses" binding:"omitempty,min=0"`
+	MaxUsesPerClient *int32     `json:"max_uses_per_client" binding:"omitempty,min=0"`
+	CategoryIDs      []int64    `json:"category_ids"`
+	ProductIDs       []int64    `json:"product_ids"`
+	Active           *bool      `json:"active"`
+}
+
+func (server *Server) updateCoupon(ctx *gin.Context) {
+	var uri getCouponRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateCouponRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	existing, err := server.store.GetCoupon(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	previous := existing
+
+	// Update only the fields that are provided in the request
+	if req.Description != "" {
+		existing.Description = req.Description
+	}
+	if req.DiscountType != "" {
+		existing.DiscountType = req.DiscountType
+	}
+	if req.DiscountValue != 0 {
+		existing.DiscountValue = req.DiscountValue
+	}
+	if req.MinOrderValue != nil {
+		existing.MinOrderValue = *req.MinOrderValue
+	}
+	if req.StartsAt != nil {
+		existing.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		existing.EndsAt = *req.EndsAt
+	}
+	if req.MaxUses != nil {
+		existing.MaxUses = *req.MaxUses
+	}
+	if req.MaxUsesPerClient != nil {
+		existing.MaxUsesPerClient = *req.MaxUsesPerClient
+	}
+	if req.CategoryIDs != nil {
+		existing.CategoryIds = req.CategoryIDs
+	}
+	if req.ProductIDs != nil {
+		existing.ProductIds = req.ProductIDs
+	}
+	if req.Active != nil {
+		existing.Active = *req.Active
+	}
+
+	if err := promotion.ValidateCoupon(existing); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	coupon, err := server.store.UpdateCoupon(ctx, db.UpdateCouponParams{
+		ID:               existing.ID,
+		Description:      existing.Description,
+		DiscountType:     existing.DiscountType,
+		DiscountValue:    existing.DiscountValue,
+		MinOrderValue:    existing.MinOrderValue,
+		StartsAt:         existing.StartsAt,
+		EndsAt:           existing.EndsAt,
+		MaxUses:          existing.MaxUses,
+		MaxUsesPerClient: existing.MaxUsesPerClient,
+		CategoryIds:      existing.CategoryIds,
+		ProductIds:       existing.ProductIds,
+		Active:           existing.Active,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, coupon.ID, previous, coupon)
+	ctx.JSON(http.StatusOK, coupon)
+}
+
+func (server *Server) deactivateCoupon(ctx *gin.Context) {
+	var req getCouponRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	coupon, err := server.store.GetCoupon(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the coupon is only deactivated, the discounts of the past sales and its uses are kept
+	// and its code can't be created again to start the usage limits over
+	deactivated, err := server.store.DeactivateCoupon(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, coupon.ID, coupon, deactivated)
+	ctx.JSON(http.StatusOK, "Coupon deactivated successfully")
+}
+
+type cartItemRequest struct {
+	ProductID int64   `json:"product_id" binding:"required,min=1"`
+	Quantity  int32   `json:"quantity" binding:"omitempty,min=1"`
+	Price     float64 `json:"price" binding:"min=0"`
+}
+
+type validateCouponRequest struct {
+	Code     string            `json:"code" binding:"required"`
+	ClientID int64             `json:"client_id" binding:"required,min=1"`
+	Items    []cartItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type validateCouponResponse struct {
+	Valid   bool             `json:"valid"`
+	Message string           `json:"message,omitempty"`
+	Coupon  db.Coupon        `json:"coupon"`
+	Result  promotion.Result `json:"result"`
+}
+
+// validateCoupon evaluates a coupon against a draft cart without creating a sale
+func (server *Server) validateCoupon(ctx *gin.Context) {
+	var req validateCouponRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cart := promotion.Cart{ClientID: req.ClientID}
+	for _, item := range req.Items {
+		// Items without a price are valued at the current catalog price
+		if item.Price == 0 {
+			product, err := server.store.GetProduct(ctx, item.ProductID)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					ctx.JSON(http.StatusNotFound, errorResponse(err))
+					return
+				}
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+			item.Price = product.Price
+		}
+
+		cartItem, err := server.newCartItem(ctx, item.ProductID, item.Price, item.Quantity)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		cart.Items = append(cart.Items, cartItem)
+	}
+
+	coupon, result, err := server.evaluateCoupon(ctx, req.Code, cart)
+	if err != nil {
+		if isCouponRuleError(err) {
+			ctx.JSON(http.StatusOK, validateCouponResponse{Valid: false, Message: err.Error(), Coupon: coupon, Result: result})
+			return
+		}
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, validateCouponResponse{Valid: true, Coupon: coupon, Result: result})
+}
+
+// newCartItem builds a cart line with the categories of the product, used to scope coupons
+func (server *Server) newCartItem(ctx *gin.Context, productID int64, price float64, quantity int32) (promotion.CartItem, error) {
+	categories, err := server.store.ListCategoriesByProduct(ctx, productID)
+	if err != nil {
+		return promotion.CartItem{}, err
+	}
+
+	return promotion.CartItem{
+		ProductID:   productID,
+		CategoryIDs: categoryIDs(categories),
+		Price:       price,
+		Quantity:    quantity,
+	}, nil
+}
+
+func categoryIDs(categories []db.Category) []int64 {
+	ids := make([]int64, len(categories))
+	for i, category := range categories {
+		ids[i] = category.ID
+	}
+	return ids
+}
+
+// evaluateCoupon loads the coupon by code with its current usage and applies it to the cart
+func (server *Server) evaluateCoupon(ctx *gin.Context, code string, cart promotion.Cart) (db.Coupon, promotion.Result, error) {
+	coupon, err := server.store.GetCouponByCode(ctx, normalizeCouponCode(code))
+	if err != nil {
+		return coupon, promotion.Result{}, err
+	}
+
+	var usage promotion.Usage
+	usage.Total, err = server.store.CountCouponUses(ctx, coupon.ID)
+	if err != nil {
+		return coupon, promotion.Result{}, err
+	}
+
+	usage.ByClient, err = server.store.CountCouponUsesByClient(ctx, db.CountCouponUsesByClientParams{
+		CouponID: coupon.ID,
+		ClientID: cart.ClientID,
+	})
+	if err != nil {
+		return coupon, promotion.Result{}, err
+	}
+
+	result, err := promotion.Evaluate(coupon, cart, usage, time.Now())
+	return coupon, result, err
+}
+
+// isCouponRuleError reports whether err means the coupon does not apply, as opposed to a failure
+func isCouponRuleError(err error) bool {
+	for _, ruleErr := range []error{
+		promotion.ErrCouponInactive,
+		promotion.ErrCouponNotStarted,
+		promotion.ErrCouponExpired,
+		promotion.ErrUsageLimit,
+		promotion.ErrClientUsageLimit,
+		promotion.ErrMinimumOrderValue,
+		promotion.ErrNoEligibleItems,
+		promotion.ErrInvalidDiscountType,
+	} {
+		if errors.Is(err, ruleErr) {
+			return true
+		}
+	}
+	return false
+}
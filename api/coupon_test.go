@@ -0,0 +1,206 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/promotion"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomCoupon() db.Coupon {
+	return db.Coupon{
+		ID:            7,
+		Code:          "RACAO10",
+		Description:   "10% em rações",
+		DiscountType:  promotion.DiscountPercentage,
+		DiscountValue: 10,
+		StartsAt:      time.Now().Add(-time.Hour),
+		CategoryIds:   []int64{3},
+		ProductIds:    []int64{},
+		Active:        true,
+	}
+}
+
+func TestValidateCouponAPI(t *testing.T) {
+	coupon := randomCoupon()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Valid",
+			body: gin.H{"code": "racao10", "client_id": 1, "items": []gin.H{{"product_id": 5, "quantity": 2}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Eq(int64(5))).Times(1).Return(db.Product{ID: 5, Price: 150}, nil)
+				store.EXPECT().ListCategoriesByProduct(gomock.Any(), gomock.Eq(int64(5))).Times(1).Return([]db.Category{{ID: 3}}, nil)
+				store.EXPECT().GetCouponByCode(gomock.Any(), gomock.Eq("RACAO10")).Times(1).Return(coupon, nil)
+				store.EXPECT().CountCouponUses(gomock.Any(), gomock.Eq(coupon.ID)).Times(1).Return(int64(0), nil)
+				store.EXPECT().CountCouponUsesByClient(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response validateCouponResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.True(t, response.Valid)
+				require.Equal(t, 300.0, response.Result.Total)
+				require.Equal(t, 30.0, response.Result.Discount)
+			},
+		},
+		{
+			name: "OutOfScope",
+			body: gin.H{"code": "RACAO10", "client_id": 1, "items": []gin.H{{"product_id": 6, "price": 40}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListCategoriesByProduct(gomock.Any(), gomock.Eq(int64(6))).Times(1).Return([]db.Category{{ID: 4}}, nil)
+				store.EXPECT().GetCouponByCode(gomock.Any(), gomock.Eq("RACAO10")).Times(1).Return(coupon, nil)
+				store.EXPECT().CountCouponUses(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().CountCouponUsesByClient(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response validateCouponResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.False(t, response.Valid)
+				require.Equal(t, promotion.ErrNoEligibleItems.Error(), response.Message)
+			},
+		},
+		{
+			name: "UnknownCode",
+			body: gin.H{"code": "NADA", "client_id": 1, "items": []gin.H{{"product_id": 6, "price": 40}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCategoriesByProduct(gomock.Any(), gomock.Any()).Times(1).Return([]db.Category{}, nil)
+				store.EXPECT().GetCouponByCode(gomock.Any(), gomock.Eq("NADA")).Times(1).Return(db.Coupon{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "EmptyCart",
+			body: gin.H{"code": "RACAO10", "client_id": 1, "items": []gin.H{}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCouponByCode(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			requestBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/coupons/validate", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeactivateCouponAPI(t *testing.T) {
+	coupon := randomCoupon()
+
+	testCases := []struct {
+		name          string
+		couponID      int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			couponID: coupon.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				deactivated := coupon
+				deactivated.Active = false
+
+				store.EXPECT().GetCoupon(gomock.Any(), gomock.Eq(coupon.ID)).Times(1).Return(coupon, nil)
+				store.EXPECT().DeactivateCoupon(gomock.Any(), gomock.Eq(coupon.ID)).Times(1).Return(deactivated, nil)
+				store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateAuditLogParams) (db.AuditLog, error) {
+						require.Equal(t, "deactivate", arg.Action)
+						require.JSONEq(t, `{"active": {"before": true, "after": false}}`, string(arg.Changes))
+						return db.AuditLog{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			couponID: coupon.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCoupon(gomock.Any(), gomock.Eq(coupon.ID)).Times(1).Return(db.Coupon{}, sql.ErrNoRows)
+				store.EXPECT().DeactivateCoupon(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			couponID: coupon.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCoupon(gomock.Any(), gomock.Eq(coupon.ID)).Times(1).Return(coupon, nil)
+				store.EXPECT().DeactivateCoupon(gomock.Any(), gomock.Any()).Times(1).Return(db.Coupon{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/coupons/%d", tc.couponID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -48,6 +48,8 @@ type createPdfRequest struct {
 type Report struct {
 	Sale
 	Client
+	// pinned client notes, printed on the delivery note
+	Notes []string
 }
 
 func (server *Server) fetchSaleData(saleID int64, ctx *gin.Context) (*db.Sale, error) {
@@ -126,10 +128,25 @@ func (server *Server) createPdf(ctx *gin.Context) {
 			AddressReference:    client.AddressReference,
 		}
 
+		// Pinned notes only go on the delivery note
+		var notes []string
+		if req.TypeOfPdf == "delivery" {
+			pinnedNotes, err := server.store.ListPinnedClientNotes(ctx, sale.ClientID)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch client notes"})
+				return
+			}
+
+			for _, note := range pinnedNotes {
+				notes = append(notes, note.Body)
+			}
+		}
+
 		// Create a new Report entry and assign the dereferenced Sale
 		reportEntry := Report{
 			Sale:   saleArg,   // Dereference the pointer here
 			Client: clientArg, // You need to fetch client data and assign it here
+			Notes:  notes,
 		}
 
 		log.Printf("Report Entry: %+v\n", reportEntry)
@@ -140,9 +157,6 @@ func (server *Server) createPdf(ctx *gin.Context) {
 		log.Printf("Report: %+v\n", report)
 	}
 
-	// Create a new buffer to store the HTML output
-	var htmlBuffer = new(strings.Builder)
-
 	switch req.TypeOfPdf {
 	case "delivery":
 		// Define the HTML template with a loop for sections
@@ -248,6 +262,12 @@ func (server *Server) createPdf(ctx *gin.Context) {
 						<td class="data-title">Cidade:</td>
 						<td>{{.Client.AddressCity}}</td>
 					</tr>
+					{{if .Notes}}
+					<tr>
+						<td class="quarter data-title">Notas:</td>
+						<td colspan="3">{{range .Notes}}{{.}}<br>{{end}}</td>
+					</tr>
+					{{end}}
 					{{end}}
 				</table>
 			</body>
@@ -318,15 +338,6 @@ func (server *Server) createPdf(ctx *gin.Context) {
 			</html>`
 	}
 
-	// Parse the HTML template
-	tmpl, err := template.New("pdf").Parse(htmlTemplate)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse HTML template"})
-		return
-	}
-
-	fmt.Println("parsed template")
-
 	// Create a structure to pass to the template
 	templateData := struct {
 		Reports []Report
@@ -336,6 +347,24 @@ func (server *Server) createPdf(ctx *gin.Context) {
 
 	fmt.Println("template data")
 
+	renderPdf(ctx, htmlTemplate, templateData)
+}
+
+// renderPdf fills the HTML template with data, converts it to PDF with Gotenberg
+// and writes the PDF file as the response
+func renderPdf(ctx *gin.Context, htmlTemplate string, templateData interface{}) {
+	// Create a new buffer to store the HTML output
+	var htmlBuffer = new(strings.Builder)
+
+	// Parse the HTML template
+	tmpl, err := template.New("pdf").Parse(htmlTemplate)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse HTML template"})
+		return
+	}
+
+	fmt.Println("parsed template")
+
 	// Execute the template with the data and write to the buffer
 	err = tmpl.Execute(htmlBuffer, templateData)
 	if err != nil {
@@ -478,3 +507,131 @@ func convertHTMLToPDF(filePath, imgPath, pdfPath string) error {
 --form 'files=@"api/pdf/index.html"' \
 --form 'files=@"api/pdf/img.png"' \
 -o test.pdf */
+
+type purchaseOrderPdfLine struct {
+	db.PurchaseOrderLine
+	Subtotal float64
+}
+
+const purchaseOrderTemplate = `
+	<!DOCTYPE html>
+	<html>
+	<head>
+		<title>PEDIDO DE COMPRA</title>
+		<style>
+			body {
+				font-family: Verdana, sans-serif;
+				font-size: 15px;
+			}
+			table {
+				width: 100%;
+				border-collapse: collapse;
+			}
+			th,
+			td {
+				border: 1px solid black;
+				padding: 5px;
+				text-align: left;
+			}
+			.data-title {
+				font-weight: 600;
+			}
+			.center {
+				text-align: center;
+			}
+			.right {
+				text-align: right;
+			}
+		</style>
+		<meta charset="UTF-8">
+	</head>
+	<body>
+		<div class="center">
+			<h1 style="margin: 5px; font-size: 21px;">PEDIDO DE COMPRA #{{.Order.ID}}</h1>
+		</div>
+		<table>
+			<tr>
+				<td class="data-title">Fornecedor:</td>
+				<td colspan="3">{{.Supplier.Name}}</td>
+			</tr>
+			<tr>
+				<td class="data-title">CNPJ/CPF:</td>
+				<td>{{.Supplier.Document}}</td>
+				<td class="data-title">Telefone:</td>
+				<td>{{.Supplier.Phone}}</td>
+			</tr>
+			<tr>
+				<td class="data-title">Criado em:</td>
+				<td>{{.Order.CreatedAt.Format "02/01/2006"}}</td>
+				<td class="data-title">Previsão de entrega:</td>
+				<td>{{if not .Order.ExpectedAt.IsZero}}{{.Order.ExpectedAt.Format "02/01/2006"}}{{end}}</td>
+			</tr>
+			{{if .Order.Notes}}
+			<tr>
+				<td class="data-title">Observação:</td>
+				<td colspan="3">{{.Order.Notes}}</td>
+			</tr>
+			{{end}}
+		</table>
+		<br>
+		<table>
+			<tr>
+				<td class="data-title">Produto</td>
+				<td class="data-title right">Quantidade</td>
+				<td class="data-title right">Custo unitário</td>
+				<td class="data-title right">Subtotal</td>
+			</tr>
+			{{range .Lines}}
+			<tr>
+				<td>{{.ProductName}}</td>
+				<td class="right">{{.Quantity}}</td>
+				<td class="right">{{printf "%.2f" .UnitCost}}</td>
+				<td class="right">{{printf "%.2f" .Subtotal}}</td>
+			</tr>
+			{{end}}
+			<tr>
+				<td colspan="3" class="data-title right">Total</td>
+				<td class="data-title right">{{printf "%.2f" .Total}}</td>
+			</tr>
+		</table>
+	</body>
+	</html>`
+
+// createPurchaseOrderPdf prints a purchase order to send to the supplier
+func (server *Server) createPurchaseOrderPdf(ctx *gin.Context) {
+	var req getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	order, lines, ok := server.fetchPurchaseOrder(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	supplier, err := server.store.GetSupplier(ctx, order.SupplierID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch supplier data"})
+		return
+	}
+
+	pdfLines := []purchaseOrderPdfLine{}
+	for _, line := range lines {
+		pdfLines = append(pdfLines, purchaseOrderPdfLine{PurchaseOrderLine: line, Subtotal: float64(line.Quantity) * line.UnitCost})
+	}
+
+	templateData := struct {
+		Order    db.PurchaseOrder
+		Supplier db.Supplier
+		Lines    []purchaseOrderPdfLine
+		Total    float64
+	}{
+		Order:    order,
+		Supplier: supplier,
+		Lines:    pdfLines,
+		Total:    purchaseOrderTotal(lines),
+	}
+
+	renderPdf(ctx, purchaseOrderTemplate, templateData)
+}
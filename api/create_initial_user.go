@@ -120,6 +120,7 @@ func (server *Server) createDummyData(store db.Store) error {
 				Product:     clientProducts[j%len(clientProducts)],
 				Price:       float64(j * 10),
 				Observation: "Observation " + strconv.Itoa(j),
+				Status:      db.SaleStatusConfirmed,
 			}
 
 			_, err := store.CreateSale(context.Background(), sale)
@@ -165,7 +166,7 @@ func (server *Server) createDummyProducts(store db.Store) error {
 			Description: "Description " + strconv.Itoa(i),
 			UserID:      1,
 			Price:       price,
-			Images:      []string{"https://picsum.photos/200/300?random=" + strconv.Itoa(i)},
+			Status:      db.ProductStatusPublished,
 		}
 
 		_, err := store.CreateProduct(context.Background(), product)
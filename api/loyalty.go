@@ -0,0 +1,51 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	db "super-pet-delivery/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getClientLoyaltyRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type clientLoyaltyResponse struct {
+	Balance int64              `json:"balance"`
+	Entries []db.LoyaltyLedger `json:"entries"`
+}
+
+// getClientLoyalty returns the points balance of a client together with the ledger entries
+func (server *Server) getClientLoyalty(ctx *gin.Context) {
+	var req getClientLoyaltyRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.GetClient(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	balance, err := server.store.GetLoyaltyBalance(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	entries, err := server.store.ListLoyaltyEntries(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, clientLoyaltyResponse{Balance: balance, Entries: entries})
+}
@@ -2,22 +2,36 @@ package api
 
 import (
 	"os"
+	mockdb "super-pet-delivery/db/mock"
 	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/notification"
 	"super-pet-delivery/util"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
-func newTestServer(t *testing.T, store db.Store) *Server {
+func newTestServer(t *testing.T, store db.SortableStore) *Server {
 	config := util.Config{
 		TokenSymmetricKey:   util.RandomString(32),
 		AccessTokenDuration: time.Minute,
 	}
 
-	server, err := NewServer(config, store)
+	if store == nil {
+		store = mockdb.NewMockStore(gomock.NewController(t))
+	}
+
+	// NewServer looks up the initial user before setting up the routes
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		mockStore.EXPECT().GetUser(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(db.User{ID: 1}, nil)
+		// the changes are written to the audit log, the expectations of the test come first and take precedence
+		mockStore.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).AnyTimes().Return(db.AuditLog{}, nil)
+	}
+
+	server, err := NewServer(config, store, notification.NewDispatcher(store))
 	require.NoError(t, err)
 
 	return server
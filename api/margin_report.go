@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	db "super-pet-delivery/db/sqlc"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Groupings of the margin report
+const (
+	marginByProduct  = "product"
+	marginByCategory = "category"
+	marginByPeriod   = "period"
+)
+
+type marginReportRequest struct {
+	// sales created in [from, to), RFC 3339
+	From    time.Time `form:"from" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	To      time.Time `form:"to" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	GroupBy string    `form:"group_by" binding:"omitempty,oneof=product category period"`
+	Period  string    `form:"period" binding:"omitempty,oneof=day week month"`
+}
+
+type marginLine struct {
+	Key           string  `json:"key"`
+	Label         string  `json:"label"`
+	Quantity      int64   `json:"quantity"`
+	Revenue       float64 `json:"revenue"`
+	Cost          float64 `json:"cost"`
+	Margin        float64 `json:"margin"`
+	MarginPercent float64 `json:"margin_percent"`
+}
+
+type marginReportResponse struct {
+	From    time.Time    `json:"from"`
+	To      time.Time    `json:"to"`
+	GroupBy string       `json:"group_by"`
+	Period  string       `json:"period,omitempty"`
+	Lines   []marginLine `json:"lines"`
+	Total   marginLine   `json:"total"`
+}
+
+// newMarginLine computes the gross margin of revenue over cost, rounded to cents
+func newMarginLine(key, label string, quantity int64, revenue, cost float64) marginLine {
+	line := marginLine{
+		Key:      key,
+		Label:    label,
+		Quantity: quantity,
+		Revenue:  roundCents(revenue),
+		Cost:     roundCents(cost),
+		Margin:   roundCents(revenue - cost),
+	}
+	if revenue != 0 {
+		line.MarginPercent = roundCents((revenue - cost) / revenue * 100)
+	}
+	return line
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// productMarginLines converts the report rows and sums the total. Every sale item is in exactly one row.
+func productMarginLines(rows []db.GetMarginByProductRow) ([]marginLine, marginLine) {
+	lines := []marginLine{}
+	var quantity int64
+	var revenue, cost float64
+	for _, row := range rows {
+		lines = append(lines, newMarginLine(strconv.FormatInt(row.ProductID, 10), row.ProductName, row.Quantity, row.Revenue, row.Cost))
+		quantity += row.Quantity
+		revenue += row.Revenue
+		cost += row.Cost
+	}
+	return lines, newMarginLine("total", "Total", quantity, revenue, cost)
+}
+
+// getMarginReport computes the gross margin of the confirmed sales with catalog products
+// grouped by product, category or period. Sales typed without a catalog product have no cost and are left out.
+func (server *Server) getMarginReport(ctx *gin.Context) {
+	var req marginReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !req.To.After(req.From) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("to must be after from")))
+		return
+	}
+	if req.GroupBy == "" {
+		req.GroupBy = marginByProduct
+	}
+
+	response := marginReportResponse{From: req.From, To: req.To, GroupBy: req.GroupBy}
+
+	// the total always comes from the product grouping: a product in two categories
+	// shows up in both category lines and would be counted twice
+	products, err := server.store.GetMarginByProduct(ctx, db.GetMarginByProductParams{FromDate: req.From, ToDate: req.To})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	response.Lines, response.Total = productMarginLines(products)
+
+	switch req.GroupBy {
+	case marginByCategory:
+		rows, err := server.store.GetMarginByCategory(ctx, db.GetMarginByCategoryParams{FromDate: req.From, ToDate: req.To})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		response.Lines = []marginLine{}
+		for _, row := range rows {
+			response.Lines = append(response.Lines, newMarginLine(strconv.FormatInt(row.CategoryID, 10), row.CategoryName, row.Quantity, row.Revenue, row.Cost))
+		}
+	case marginByPeriod:
+		if req.Period == "" {
+			req.Period = "month"
+		}
+		response.Period = req.Period
+
+		rows, err := server.store.GetMarginByPeriod(ctx, db.GetMarginByPeriodParams{Period: req.Period, FromDate: req.From, ToDate: req.To})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		response.Lines = []marginLine{}
+		for _, row := range rows {
+			key := row.PeriodStart.Format("2006-01-02")
+			response.Lines = append(response.Lines, newMarginLine(key, key, row.Quantity, row.Revenue, row.Cost))
+		}
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
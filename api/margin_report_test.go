@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewMarginLine(t *testing.T) {
+	line := newMarginLine("1", "Ração 15kg", 2, 300, 210)
+	require.Equal(t, 90.0, line.Margin)
+	require.Equal(t, 30.0, line.MarginPercent)
+
+	// sold for free, no division by zero
+	line = newMarginLine("2", "Brinde", 1, 0, 5)
+	require.Equal(t, -5.0, line.Margin)
+	require.Zero(t, line.MarginPercent)
+}
+
+func TestGetMarginReportAPI(t *testing.T) {
+	from := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	products := []db.GetMarginByProductRow{
+		{ProductID: 1, ProductName: "Ração 15kg", Quantity: 2, Revenue: 300, Cost: 210},
+		{ProductID: 2, ProductName: "Areia", Quantity: 4, Revenue: 100, Cost: 40},
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "ByProduct",
+			query: url.Values{"from": {from.Format(time.RFC3339)}, "to": {to.Format(time.RFC3339)}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetMarginByProductParams{FromDate: from, ToDate: to}
+				store.EXPECT().GetMarginByProduct(gomock.Any(), gomock.Eq(arg)).Times(1).Return(products, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response marginReportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Lines, 2)
+				require.Equal(t, "Ração 15kg", response.Lines[0].Label)
+				require.Equal(t, int64(6), response.Total.Quantity)
+				require.Equal(t, 150.0, response.Total.Margin)
+				require.Equal(t, 37.5, response.Total.MarginPercent)
+			},
+		},
+		{
+			name:  "ByCategoryKeepsProductTotal",
+			query: url.Values{"from": {from.Format(time.RFC3339)}, "to": {to.Format(time.RFC3339)}, "group_by": {"category"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMarginByProduct(gomock.Any(), gomock.Any()).Times(1).Return(products, nil)
+				// the food is in two categories
+				store.EXPECT().GetMarginByCategory(gomock.Any(), gomock.Any()).Times(1).Return([]db.GetMarginByCategoryRow{
+					{CategoryID: 1, CategoryName: "Cachorro", Quantity: 2, Revenue: 300, Cost: 210},
+					{CategoryID: 2, CategoryName: "Ração", Quantity: 2, Revenue: 300, Cost: 210},
+					{CategoryID: 3, CategoryName: "Higiene", Quantity: 4, Revenue: 100, Cost: 40},
+				}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response marginReportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Lines, 3)
+				require.Equal(t, 400.0, response.Total.Revenue)
+			},
+		},
+		{
+			name:  "ByPeriod",
+			query: url.Values{"from": {from.Format(time.RFC3339)}, "to": {to.Format(time.RFC3339)}, "group_by": {"period"}, "period": {"week"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMarginByProduct(gomock.Any(), gomock.Any()).Times(1).Return(products, nil)
+				arg := db.GetMarginByPeriodParams{Period: "week", FromDate: from, ToDate: to}
+				store.EXPECT().GetMarginByPeriod(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.GetMarginByPeriodRow{
+					{PeriodStart: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), Quantity: 6, Revenue: 400, Cost: 250},
+				}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response marginReportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, "week", response.Period)
+				require.Len(t, response.Lines, 1)
+				require.Equal(t, "2023-05-01", response.Lines[0].Key)
+			},
+		},
+		{
+			name:  "InvalidRange",
+			query: url.Values{"from": {to.Format(time.RFC3339)}, "to": {from.Format(time.RFC3339)}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMarginByProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidGroupBy",
+			query: url.Values{"from": {from.Format(time.RFC3339)}, "to": {to.Format(time.RFC3339)}, "group_by": {"client"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMarginByProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/reports/margin?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
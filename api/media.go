@@ -89,6 +89,7 @@ func (server *Server) createImage(ctx *gin.Context) {
 		}
 
 		images = append(images, image)
+		recordAudit(ctx, image.ID, nil, image)
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%d files uploaded!", len(files)), "images": images})
@@ -174,15 +175,18 @@ func (server *Server) getImagePath(ctx *gin.Context) {
 }
 
 type listImagesResponse struct {
-	Total  int64      `json:"total"`
-	Images []db.Image `json:"images"`
+	Total      *int64     `json:"total,omitempty"`
+	Images     []db.Image `json:"images"`
+	NextCursor string     `json:"next_cursor,omitempty"`
 }
+
 type listImageRequest struct {
-	PageID        int32  `form:"page_id" binding:"required,min=1"`
-	PageSize      int32  `form:"page_size" binding:"required,min=5,max=20"`
-	SortField     string `form:"sort_field" binding:""`
-	SortDirection string `form:"sort_direction" binding:""`
-	Search        string `form:"search" binding:""`
+	PageID        int32   `form:"page_id" binding:"required_without=Cursor,min=0"`
+	Cursor        *string `form:"cursor"`
+	PageSize      int32   `form:"page_size" binding:"required,min=5,max=20"`
+	SortField     string  `form:"sort_field" binding:""`
+	SortDirection string  `form:"sort_direction" binding:""`
+	Search        string  `form:"search" binding:""`
 }
 
 func (server *Server) listImage(ctx *gin.Context) {
@@ -192,45 +196,72 @@ func (server *Server) listImage(ctx *gin.Context) {
 		return
 	}
 
+	if req.Cursor != nil {
+		server.listImagePage(ctx, req)
+		return
+	}
+
 	arg := db.ListImagesParams{
 		Limit:  req.PageSize,
 		Offset: (req.PageID - 1) * req.PageSize,
 	}
 
-	total, err := server.store.CountImages(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+	// the searches count the images they match, the other lists count all of them
+	var total int64
+	var err error
+	if req.Search == "" {
+		total, err = server.store.CountImages(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
 	}
 
 	var images []db.Image
 	// Check if sort fields and search are provided
 	if req.SortField != "" && req.SortDirection != "" && req.Search != "" {
 		// Fetch the paginated images with sorting and search
-		images, err = server.store.SearchImages(ctx, req.Search, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection)
+		images, total, err = server.store.SearchImages(ctx, req.Search, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection)
 	} else if req.SortField != "" && req.SortDirection != "" {
 		// Fetch the paginated images with sorting
 		images, err = server.store.ListImagesSorted(ctx, arg, req.SortField, req.SortDirection)
 	} else if req.Search != "" {
 		// Fetch the paginated images with search
-		images, err = server.store.SearchImages(ctx, req.Search, int(req.PageID), int(req.PageSize), "", "")
+		images, total, err = server.store.SearchImages(ctx, req.Search, int(req.PageID), int(req.PageSize), "", "")
 	} else {
 		// Fetch the paginated images without sorting or search
 		images, err = server.store.ListImages(ctx, arg)
 	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeListError(ctx, err)
 		return
 	}
 
 	response := listImagesResponse{
-		Total:  total,
+		Total:  &total,
 		Images: images,
 	}
 
 	ctx.JSON(http.StatusOK, response)
 }
 
+// listImagePage is the cursor mode of listImage
+func (server *Server) listImagePage(ctx *gin.Context, req listImageRequest) {
+	images, next, err := server.store.ListImagesPage(ctx, db.PageParams{
+		Cursor:        *req.Cursor,
+		Limit:         req.PageSize,
+		SortField:     req.SortField,
+		SortDirection: req.SortDirection,
+		Search:        req.Search,
+	})
+	if err != nil {
+		writeListError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listImagesResponse{Images: images, NextCursor: next})
+}
+
 type updateImageRequest struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
@@ -259,6 +290,7 @@ func (server *Server) updateImage(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	previousImage := existingImage
 
 	// Update only the fields that are provided in the request
 	if req.Name != "" {
@@ -286,6 +318,7 @@ func (server *Server) updateImage(ctx *gin.Context) {
 		return
 	}
 
+	recordAudit(ctx, image.ID, previousImage, image)
 	ctx.JSON(http.StatusOK, image)
 }
 
@@ -325,13 +358,15 @@ func (server *Server) deleteImage(ctx *gin.Context) {
 		return
 	}
 
-	// Delete the image record from the database
-	err = server.store.DeleteImage(ctx, req.ID)
+	// Delete the image record from the database along with its links to products
+	err = server.store.DeleteImageTx(ctx, req.ID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	recordAudit(ctx, image.ID, image, nil)
+
 	ctx.JSON(http.StatusOK, "Image deleted successfully")
 }
 
@@ -347,23 +382,18 @@ func (server *Server) associateImageWithProduct(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.AssociateProductWithImageParams{
-		ImageID:   req.ImageID,
+	arg := db.ProductImagesTxParams{
 		ProductID: req.ProductID,
+		Images:    []db.ProductImageOrder{{ImageID: req.ImageID}},
 	}
 
-	image, err := server.store.AssociateProductWithImage(ctx, arg)
+	_, err := server.store.AssociateImagesTx(ctx, arg)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, image)
+	ctx.JSON(http.StatusOK, db.ProductImage{ProductID: req.ProductID, ImageID: req.ImageID})
 }
 
 type disassociateImageWithProductRequest struct {
@@ -378,12 +408,8 @@ func (server *Server) disassociateImageWithProduct(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.DisassociateProductFromImageParams{
-		ImageID:   req.ImageID,
-		ProductID: req.ProductID,
-	}
-
-	image, err := server.store.DisassociateProductFromImage(ctx, arg)
+	// the product version is bumped with the link removed
+	_, err := server.store.DisassociateImagesTx(ctx, req.ProductID, []int64{req.ImageID})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -394,7 +420,7 @@ func (server *Server) disassociateImageWithProduct(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, image)
+	ctx.JSON(http.StatusOK, db.ProductImage{ProductID: req.ProductID, ImageID: req.ImageID})
 }
 
 type listProductImagesRequest struct {
@@ -446,34 +472,19 @@ func (server *Server) associateMultipleImagesWithProduct(ctx *gin.Context) {
 		return
 	}
 
-	// Get the list of currently associated images
-	currentImages, err := server.store.ListImagesByProduct(ctx, uri.ProductID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+	// the images already associated are skipped
+	arg := db.ProductImagesTxParams{
+		ProductID: uri.ProductID,
+		Images:    make([]db.ProductImageOrder, len(json.Images)),
 	}
-
-	// Create a map for quick lookup
-	currentImageMap := make(map[int64]bool)
-	for _, image := range currentImages {
-		currentImageMap[image.ID] = true
+	for i, image := range json.Images {
+		arg.Images[i] = db.ProductImageOrder{ImageID: image.ID, Order: image.Order}
 	}
 
-	for _, image := range json.Images {
-		// If the image is not already associated, associate it
-		if !currentImageMap[image.ID] {
-			arg := db.AssociateProductWithImageParams{
-				ImageID:   image.ID,
-				ProductID: uri.ProductID,
-				Order:     image.Order,
-			}
-
-			_, err = server.store.AssociateProductWithImage(ctx, arg)
-			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-				return
-			}
-		}
+	_, err := server.store.AssociateImagesTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"status": "success"})
@@ -500,17 +511,15 @@ func (server *Server) disassociateMultipleImagesWithProduct(ctx *gin.Context) {
 		return
 	}
 
-	for _, imageID := range json.ImageIDs {
-		arg := db.DisassociateProductFromImageParams{
-			ImageID:   imageID,
-			ProductID: uri.ProductID,
-		}
-
-		_, err := server.store.DisassociateProductFromImage(ctx, arg)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	_, err := server.store.DisassociateImagesTx(ctx, uri.ProductID, json.ImageIDs)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"status": "success"})
@@ -540,22 +549,26 @@ func (server *Server) editImageOrder(ctx *gin.Context) {
 		return
 	}
 
-	var updatedRows []db.ProductImage
-	for _, image := range json.Images {
-		arg := db.EditAssociationParams{
-			ProductID: uri.ProductID,
-			ImageID:   image.ID,
-			Order:     image.Order,
-		}
+	// every order changes in the same transaction, a single image that isn't
+	// linked to the product leaves the order untouched
+	arg := db.ProductImagesTxParams{
+		ProductID: uri.ProductID,
+		Images:    make([]db.ProductImageOrder, len(json.Images)),
+	}
+	for i, image := range json.Images {
+		arg.Images[i] = db.ProductImageOrder{ImageID: image.ID, Order: image.Order}
+	}
 
-		updatedRow, err := server.store.EditAssociation(ctx, arg)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	result, err := server.store.EditImageOrderTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
 
-		updatedRows = append(updatedRows, updatedRow)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	ctx.JSON(http.StatusOK, updatedRows)
+	ctx.JSON(http.StatusOK, result.Images)
 }
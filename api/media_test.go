@@ -0,0 +1,92 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEditImageOrderAPI(t *testing.T) {
+	productID := util.RandomInt(1, 1000)
+	body := gin.H{"images": []gin.H{{"id": 4, "order": 1}, {"id": 9, "order": 0}}}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ProductImagesTxParams{
+					ProductID: productID,
+					Images:    []db.ProductImageOrder{{ImageID: 4, Order: 1}, {ImageID: 9, Order: 0}},
+				}
+				result := db.EditImageOrderTxResult{
+					Product: db.Product{ID: productID},
+					Images: []db.ProductImage{
+						{ProductID: productID, ImageID: 4, Order: 1},
+						{ProductID: productID, ImageID: 9, Order: 0},
+					},
+				}
+				store.EXPECT().EditImageOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+				store.EXPECT().EditAssociation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.ProductImage
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 2)
+				require.Equal(t, int32(1), got[0].Order)
+			},
+		},
+		{
+			name: "ImageNotLinked",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().EditImageOrderTx(gomock.Any(), gomock.Any()).Times(1).Return(db.EditImageOrderTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/images/by_product/%d", productID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
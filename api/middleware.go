@@ -26,27 +26,30 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
-		fields := strings.Fields(authorizationHeader)
-		if len(fields) < 2 {
-
-			err := errors.New("invalid authorization header format")
+		payload, err := verifyAuthorizationHeader(tokenMaker, authorizationHeader)
+		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
-
 			return
 		}
 
-		authorizationType := strings.ToLower(fields[0])
-		if authorizationType != authorizationTypeBearer {
-			err := fmt.Errorf("unsupported authorization type %s", authorizationType)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+		ctx.Set(authorizationPayloadKey, payload)
+		ctx.Next()
+	}
+}
 
+// optionalAuthMiddleware lets every request through the public routes. A missing, invalid or expired
+// authorization header is an anonymous visitor, the handlers find the payload only for logged in users.
+func optionalAuthMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
+		if len(authorizationHeader) == 0 {
+			ctx.Next()
 			return
 		}
 
-		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
+		payload, err := verifyAuthorizationHeader(tokenMaker, authorizationHeader)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.Next()
 			return
 		}
 
@@ -54,3 +57,25 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+func verifyAuthorizationHeader(tokenMaker token.Maker, authorizationHeader string) (*token.Payload, error) {
+	fields := strings.Fields(authorizationHeader)
+	if len(fields) < 2 {
+		return nil, errors.New("invalid authorization header format")
+	}
+
+	authorizationType := strings.ToLower(fields[0])
+	if authorizationType != authorizationTypeBearer {
+		return nil, fmt.Errorf("unsupported authorization type %s", authorizationType)
+	}
+
+	accessToken := fields[1]
+	return tokenMaker.VerifyToken(accessToken)
+}
+
+// isLoggedIn tells whether the request was made by a logged in user, on the public routes
+// they are the admins and see every product
+func isLoggedIn(ctx *gin.Context) bool {
+	_, ok := ctx.Get(authorizationPayloadKey)
+	return ok
+}
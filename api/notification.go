@@ -0,0 +1,86 @@
+package api
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/notification"
+
+	"github.com/gin-gonic/gin"
+)
+
+// notifySale queues a notification for a sale event.
+// A failure here must not undo the sale, so it is only logged.
+func (server *Server) notifySale(ctx *gin.Context, eventType string, sale db.Sale, client db.Client) {
+	event := notification.Event{
+		Type:   eventType,
+		Sale:   sale,
+		Client: client,
+	}
+
+	if err := server.notifier.Enqueue(ctx, event); err != nil {
+		log.Println("Error queueing notification:", err)
+	}
+}
+
+type saleOutForDeliveryRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) saleOutForDelivery(ctx *gin.Context) {
+	var req saleOutForDeliveryRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sale, err := server.store.GetSale(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	client, err := server.store.GetClient(ctx, sale.ClientID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	event := notification.Event{
+		Type:   notification.EventSaleOutForDelivery,
+		Sale:   sale,
+		Client: client,
+	}
+
+	if err := server.notifier.Enqueue(ctx, event); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sale)
+}
+
+type listSaleNotificationsRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listSaleNotifications(ctx *gin.Context) {
+	var req listSaleNotificationsRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	notifications, err := server.store.ListNotificationsBySale(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, notifications)
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	db "super-pet-delivery/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The list endpoints have two pagination modes. The offset mode takes page_id and returns the total,
+// the cursor mode starts with an empty cursor and each page returns the next_cursor to pass to the
+// following one, it's missing on the last page. The cursor mode doesn't count the rows and the pages
+// don't shift when new rows are added.
+
+// writeListError writes the error of a list, a cursor that can't be read or a sort
+// the list doesn't allow are bad requests
+func writeListError(ctx *gin.Context, err error) {
+	if errors.Is(err, db.ErrInvalidCursor) || errors.Is(err, db.ErrInvalidSort) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
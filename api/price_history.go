@@ -0,0 +1,84 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	db "super-pet-delivery/db/sqlc"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type priceHistoryUri struct {
+	ProductID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listProductPriceHistory(ctx *gin.Context) {
+	var uri priceHistoryUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	history, err := server.store.ListProductPriceHistory(ctx, uri.ProductID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
+
+type priceAtRequest struct {
+	// RFC 3339, e.g. 2023-05-01T00:00:00-03:00
+	At time.Time `form:"at" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// getProductPriceAt returns the price the product had at the given moment
+func (server *Server) getProductPriceAt(ctx *gin.Context) {
+	var uri priceHistoryUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req priceAtRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.GetProductPriceAtParams{
+		ProductID: uri.ProductID,
+		CreatedAt: req.At,
+	}
+
+	price, err := server.store.GetProductPriceAt(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, price)
+}
+
+// listProductPricesAt returns the price every product had at the given moment, used by reports
+func (server *Server) listProductPricesAt(ctx *gin.Context) {
+	var req priceAtRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	prices, err := server.store.ListProductPricesAt(ctx, req.At)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prices)
+}
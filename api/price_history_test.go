@@ -0,0 +1,117 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListProductPriceHistoryAPI(t *testing.T) {
+	product := randomProduct()
+	history := []db.ProductPriceHistory{
+		{ID: 2, ProductID: product.ID, Price: 89.9, OldPrice: 99.9, ChangedBy: "username", Source: db.PriceSourceManual},
+		{ID: 1, ProductID: product.ID, Price: 99.9, ChangedBy: "username", Source: db.PriceSourceInitial},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListProductPriceHistory(gomock.Any(), gomock.Eq(product.ID)).Times(1).Return(history, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/products/%d/price_history", product.ID), nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	data, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+
+	var got []db.ProductPriceHistory
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, history, got)
+}
+
+func TestGetProductPriceAtAPI(t *testing.T) {
+	product := randomProduct()
+	at := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	entry := db.ProductPriceHistory{ID: 1, ProductID: product.ID, Price: 99.9, ChangedBy: "username", Source: db.PriceSourceManual}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: at.Format(time.RFC3339),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetProductPriceAtParams{ProductID: product.ID, CreatedAt: at}
+				store.EXPECT().GetProductPriceAt(gomock.Any(), gomock.Eq(arg)).Times(1).Return(entry, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "BeforeFirstPrice",
+			query: at.Format(time.RFC3339),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProductPriceAt(gomock.Any(), gomock.Any()).Times(1).Return(db.ProductPriceHistory{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidDate",
+			query: "yesterday",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProductPriceAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			requestURL := fmt.Sprintf("/products/%d/price_at?at=%s", product.ID, url.QueryEscape(tc.query))
+			request, err := http.NewRequest(http.MethodGet, requestURL, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
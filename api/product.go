@@ -2,8 +2,10 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"strings"
 	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/token"
 	"unicode"
 
 	"fmt"
@@ -18,14 +20,90 @@ import (
 )
 
 type createProductRequest struct {
-	Name        string   `json:"name" validate:"required"`
-	Description string   `json:"description" validate:"required"`
-	UserID      int64    `json:"user_id" validate:"required"`
-	Price       string   `json:"price"`
-	OldPrice    string   `json:"old_price"`
-	Sku         string   `json:"sku"`
-	Images      []string `json:"images"`
-	Categories  []int64  `json:"categories"`
+	Name        string  `json:"name" validate:"required"`
+	Description string  `json:"description" validate:"required"`
+	UserID      int64   `json:"user_id" validate:"required"`
+	Price       string  `json:"price"`
+	OldPrice    string  `json:"old_price"`
+	CostPrice   string  `json:"cost_price"`
+	SupplierID  int64   `json:"supplier_id" binding:"min=0"`
+	BrandID     int64   `json:"brand_id" binding:"min=0"`
+	Sku         string  `json:"sku"`
+	ImageIDs    []int64 `json:"image_ids"`
+	Categories  []int64 `json:"categories"`
+	Status      string  `json:"status" binding:"omitempty,oneof=draft published archived"`
+}
+
+// productImage is an image of a product as it's embedded in the product responses
+type productImage struct {
+	ID   int64  `json:"id"`
+	Path string `json:"path"`
+	Alt  string `json:"alt"`
+}
+
+// productResponse is a product with its images in order and the ids of its categories,
+// they come from product_images and product_categories.
+type productResponse struct {
+	db.Product
+	Images     []productImage `json:"images"`
+	Categories []int64        `json:"categories"`
+}
+
+// newProductResponses loads the images and the categories of all the products with a query for each
+func (server *Server) newProductResponses(ctx *gin.Context, products []db.Product) ([]productResponse, error) {
+	responses := make([]productResponse, len(products))
+	if len(products) == 0 {
+		return responses, nil
+	}
+
+	ids := make([]int64, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+
+	rows, err := server.store.ListImagesByProducts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[int64][]productImage, len(products))
+	for _, row := range rows {
+		images[row.ProductID] = append(images[row.ProductID], productImage{ID: row.ID, Path: row.ImagePath, Alt: row.Alt})
+	}
+
+	links, err := server.store.ListProductCategoriesByProducts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[int64][]int64, len(products))
+	for _, link := range links {
+		categories[link.ProductID] = append(categories[link.ProductID], link.CategoryID)
+	}
+
+	for i, product := range products {
+		responses[i] = productResponse{Product: product, Images: images[product.ID], Categories: categories[product.ID]}
+		if responses[i].Images == nil {
+			responses[i].Images = []productImage{}
+		}
+		if responses[i].Categories == nil {
+			responses[i].Categories = []int64{}
+		}
+	}
+
+	return responses, nil
+}
+
+// sendProduct writes the product with its images as the response
+func (server *Server) sendProduct(ctx *gin.Context, product db.Product) {
+	responses, err := server.newProductResponses(ctx, []db.Product{product})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(ctx, product.Version)
+	ctx.JSON(http.StatusOK, responses[0])
 }
 
 func sanitizeName(name string) string {
@@ -41,6 +119,31 @@ func sanitizeName(name string) string {
 	return sanitized
 }
 
+// uniqueSlug builds a slug from the name the same way product urls are built, adding a number
+// while the slug is taken. find returns the id of the record using a slug and ownID is the record
+// being renamed, 0 when creating one. The error response is already written when it returns false.
+func uniqueSlug(ctx *gin.Context, name string, ownID int64, find func(slug string) (int64, error)) (string, bool) {
+	baseSlug := sanitizeName(name)
+	slug := baseSlug
+	i := 1
+	for {
+		id, err := find(slug)
+		if err == sql.ErrNoRows {
+			break
+		}
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return "", false
+		}
+		if id == ownID {
+			break
+		}
+		slug = fmt.Sprintf("%s-%d", baseSlug, i)
+		i++
+	}
+	return slug, true
+}
+
 func (server *Server) createProduct(ctx *gin.Context) {
 	var req createProductRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -50,7 +153,6 @@ func (server *Server) createProduct(ctx *gin.Context) {
 
 	productPrice := 0.0
 	oldPrice := 0.0
-	productImages := []string{}
 	productSku := ""
 	productCategories := []int64{}
 	if req.Price != "" {
@@ -69,8 +171,14 @@ func (server *Server) createProduct(ctx *gin.Context) {
 		}
 		oldPrice = price
 	}
-	if len(req.Images) > 0 {
-		productImages = req.Images
+	costPrice := 0.0
+	if req.CostPrice != "" {
+		price, err := strconv.ParseFloat(strings.Replace(req.CostPrice, ",", ".", -1), 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		costPrice = price
 	}
 	if req.Sku != "" {
 		productSku = req.Sku
@@ -78,6 +186,11 @@ func (server *Server) createProduct(ctx *gin.Context) {
 	if len(req.Categories) > 0 {
 		productCategories = req.Categories
 	}
+	// new products stay out of the store until they are published
+	productStatus := db.ProductStatusDraft
+	if req.Status != "" {
+		productStatus = req.Status
+	}
 
 	user, err := server.store.GetUser(ctx, req.UserID)
 	if err != nil {
@@ -90,6 +203,14 @@ func (server *Server) createProduct(ctx *gin.Context) {
 		return
 	}
 
+	if !server.checkSupplier(ctx, req.SupplierID) {
+		return
+	}
+
+	if !server.checkBrand(ctx, req.BrandID) {
+		return
+	}
+
 	baseURL := sanitizeName(req.Name)
 	url := baseURL
 	i := 1
@@ -106,27 +227,37 @@ func (server *Server) createProduct(ctx *gin.Context) {
 		i++
 	}
 
-	arg := db.CreateProductParams{
-		Name:        req.Name,
-		Description: req.Description,
-		UserID:      req.UserID,
-		Username:    user.Username,
-		Price:       productPrice,
-		OldPrice:    oldPrice,
-		Sku:         productSku,
-		Url:         url,
-		Images:      productImages,
-		Categories:  productCategories,
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.CreateProductTxParams{
+		CreateProductParams: db.CreateProductParams{
+			Name:        req.Name,
+			Description: req.Description,
+			UserID:      req.UserID,
+			Username:    user.Username,
+			Price:       productPrice,
+			OldPrice:    oldPrice,
+			Sku:         productSku,
+			Url:         url,
+			SupplierID:  req.SupplierID,
+			CostPrice:   costPrice,
+			BrandID:     req.BrandID,
+			Status:      productStatus,
+		},
+		Categories: productCategories,
+		ImageIDs:   req.ImageIDs,
+		ChangedBy:  authPayload.Username,
 	}
 
-	product, err := server.store.CreateProduct(ctx, arg)
+	product, err := server.store.CreateProductTx(ctx, arg)
 
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, product)
+	recordAudit(ctx, product.ID, nil, product)
+	server.sendProduct(ctx, product)
 }
 
 type getProductByURLRequest struct {
@@ -151,7 +282,12 @@ func (server *Server) getProductByURL(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, product)
+	if !isLoggedIn(ctx) && !product.IsPublic() {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	server.sendProduct(ctx, product)
 }
 
 type getProductRequest struct {
@@ -176,21 +312,31 @@ func (server *Server) getProduct(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, product)
+	if !isLoggedIn(ctx) && !product.IsPublic() {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	server.sendProduct(ctx, product)
 }
 
 type listProductResponse struct {
-	Total    int64        `json:"total"`
-	Products []db.Product `json:"products"`
+	Total      *int64            `json:"total,omitempty"`
+	Products   []productResponse `json:"products"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	Facets     *db.ProductFacets `json:"facets,omitempty"`
 }
 
 type listProductRequest struct {
-	PageID        int32   `form:"page_id" binding:"required,min=1"`
+	PageID        int32   `form:"page_id" binding:"required_without=Cursor,min=0"`
+	Cursor        *string `form:"cursor"`
 	PageSize      int32   `form:"page_size" binding:"required,min=5,max=100"`
 	SortField     string  `form:"sort_field" binding:""`
 	SortDirection string  `form:"sort_direction" binding:""`
 	Search        string  `form:"search" binding:""`
 	CategoryIDs   []int64 `form:"category_ids" binding:""`
+	BrandIDs      []int64 `form:"brand_ids" binding:""`
+	Facets        bool    `form:"facets"`
 }
 
 func (server *Server) listProduct(ctx *gin.Context) {
@@ -200,6 +346,56 @@ func (server *Server) listProduct(ctx *gin.Context) {
 		return
 	}
 
+	// visitors only see the published products, the admins see all of them
+	publicOnly := !isLoggedIn(ctx)
+
+	var response listProductResponse
+	var products []db.Product
+	var err error
+	if req.Cursor != nil {
+		products, response.NextCursor, err = server.store.ListProductsPage(ctx, db.ProductPageParams{
+			PageParams: db.PageParams{
+				Cursor:        *req.Cursor,
+				Limit:         req.PageSize,
+				SortField:     req.SortField,
+				SortDirection: req.SortDirection,
+				Search:        req.Search,
+			},
+			CategoryIDs: req.CategoryIDs,
+			BrandIDs:    req.BrandIDs,
+			PublicOnly:  publicOnly,
+		})
+	} else {
+		var total int64
+		products, total, err = server.listProductOffset(ctx, req, publicOnly)
+		response.Total = &total
+	}
+	if err != nil {
+		writeListError(ctx, err)
+		return
+	}
+
+	response.Products, err = server.newProductResponses(ctx, products)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the counts of the filters for the same search, the storefront renders them next to the products
+	if req.Facets {
+		facets, err := server.store.ProductFacets(ctx, req.CategoryIDs, req.BrandIDs, req.Search, publicOnly)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		response.Facets = &facets
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// listProductOffset is the offset mode of listProduct
+func (server *Server) listProductOffset(ctx *gin.Context, req listProductRequest, publicOnly bool) ([]db.Product, int64, error) {
 	arg := db.ListProductsParams{
 		Limit:  req.PageSize,
 		Offset: (req.PageID - 1) * req.PageSize,
@@ -207,42 +403,40 @@ func (server *Server) listProduct(ctx *gin.Context) {
 
 	var total int64
 	var err error
-	if len(req.CategoryIDs) == 0 && req.SortField == "" && req.SortDirection == "" && req.Search == "" {
-		total, err = server.store.CountProducts(ctx)
+	if len(req.CategoryIDs) == 0 && len(req.BrandIDs) == 0 && req.SortField == "" && req.SortDirection == "" && req.Search == "" {
+		if publicOnly {
+			total, err = server.store.CountPublishedProducts(ctx)
+		} else {
+			total, err = server.store.CountProducts(ctx)
+		}
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
+			return nil, 0, err
 		}
 	}
 
 	var products []db.Product
-	if len(req.CategoryIDs) != 0 {
-		// Fetch the paginated products for the given category
-		products, total, err = server.store.FilterProducts(ctx, req.CategoryIDs, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection, req.Search)
+	if len(req.CategoryIDs) != 0 || len(req.BrandIDs) != 0 {
+		// Fetch the paginated products for the given categories and brands
+		products, total, err = server.store.FilterProducts(ctx, req.CategoryIDs, req.BrandIDs, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection, req.Search, publicOnly)
 	} else if req.SortField != "" && req.SortDirection != "" && req.Search != "" {
 		// Fetch the paginated products with sorting and search
-		products, total, err = server.store.SearchProducts(ctx, req.Search, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection)
+		products, total, err = server.store.SearchProducts(ctx, req.Search, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection, publicOnly)
 	} else if req.SortField != "" && req.SortDirection != "" {
 		// Fetch the paginated products with sorting
-		products, total, err = server.store.ListProductsSorted(ctx, arg, req.SortField, req.SortDirection)
+		products, total, err = server.store.ListProductsSorted(ctx, arg, req.SortField, req.SortDirection, publicOnly)
 	} else if req.Search != "" {
 		// Fetch the paginated products with search
-		products, total, err = server.store.SearchProducts(ctx, req.Search, int(req.PageID), int(req.PageSize), "", "")
+		products, total, err = server.store.SearchProducts(ctx, req.Search, int(req.PageID), int(req.PageSize), "", "", publicOnly)
 	} else {
 		// Fetch the paginated products without sorting or search
-		products, err = server.store.ListProducts(ctx, arg)
-	}
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	response := listProductResponse{
-		Total:    total,
-		Products: products,
+		if publicOnly {
+			products, err = server.store.ListPublishedProducts(ctx, db.ListPublishedProductsParams(arg))
+		} else {
+			products, err = server.store.ListProducts(ctx, arg)
+		}
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	return products, total, err
 }
 
 type listProductsByUserRequest struct {
@@ -262,18 +456,39 @@ func (server *Server) listProductsByUser(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, products)
+	if !isLoggedIn(ctx) {
+		public := make([]db.Product, 0, len(products))
+		for _, product := range products {
+			if product.IsPublic() {
+				public = append(public, product)
+			}
+		}
+		products = public
+	}
+
+	responses, err := server.newProductResponses(ctx, products)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses)
 }
 
 type updateProductRequest struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	UserID      int64    `json:"user_id"`
-	Price       string   `json:"price"`
-	OldPrice    string   `json:"old_price"`
-	Sku         string   `json:"sku"`
-	Images      []string `json:"images"`
-	Categories  []int64  `json:"categories"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	UserID      int64   `json:"user_id"`
+	Price       string  `json:"price"`
+	OldPrice    string  `json:"old_price"`
+	CostPrice   string  `json:"cost_price"`
+	SupplierID  *int64  `json:"supplier_id" binding:"omitempty,min=0"`
+	BrandID     *int64  `json:"brand_id" binding:"omitempty,min=0"`
+	Sku         string  `json:"sku"`
+	ImageIDs    []int64 `json:"image_ids"`
+	Categories  []int64 `json:"categories"`
+	Status      string  `json:"status" binding:"omitempty,oneof=draft published archived"`
+	Version     int64   `json:"version" binding:"omitempty,min=1"`
 }
 
 func (server *Server) updateProduct(ctx *gin.Context) {
@@ -283,6 +498,11 @@ func (server *Server) updateProduct(ctx *gin.Context) {
 		return
 	}
 
+	version, ok := expectedVersion(ctx, req.Version)
+	if !ok {
+		return
+	}
+
 	productID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 	fmt.Println(productID)
 	if err != nil {
@@ -299,6 +519,11 @@ func (server *Server) updateProduct(ctx *gin.Context) {
 		return
 	}
 
+	if !checkVersion(ctx, version, existingProduct.Version) {
+		return
+	}
+	previousProduct := existingProduct
+
 	user, err := server.store.GetUser(ctx, req.UserID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -308,17 +533,6 @@ func (server *Server) updateProduct(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	price, err := strconv.ParseFloat(strings.Replace(req.Price, ",", ".", -1), 64)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	oldPrice, err := strconv.ParseFloat(strings.Replace(req.OldPrice, ",", ".", -1), 64)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
 
 	// Update only the fields that are provided in the request
 	if req.Name != "" {
@@ -331,19 +545,56 @@ func (server *Server) updateProduct(ctx *gin.Context) {
 		existingProduct.UserID = req.UserID
 	}
 	if req.Price != "" {
+		price, err := strconv.ParseFloat(strings.Replace(req.Price, ",", ".", -1), 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		existingProduct.Price = price
 	}
 	if req.OldPrice != "" {
+		oldPrice, err := strconv.ParseFloat(strings.Replace(req.OldPrice, ",", ".", -1), 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		existingProduct.OldPrice = oldPrice
 	}
+	if req.CostPrice != "" {
+		costPrice, err := strconv.ParseFloat(strings.Replace(req.CostPrice, ",", ".", -1), 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		existingProduct.CostPrice = costPrice
+	}
+	if req.SupplierID != nil && *req.SupplierID != existingProduct.SupplierID {
+		if !server.checkSupplier(ctx, *req.SupplierID) {
+			return
+		}
+		existingProduct.SupplierID = *req.SupplierID
+	}
+	if req.BrandID != nil && *req.BrandID != existingProduct.BrandID {
+		if !server.checkBrand(ctx, *req.BrandID) {
+			return
+		}
+		existingProduct.BrandID = *req.BrandID
+	}
 	if req.Sku != "" {
 		existingProduct.Sku = req.Sku
 	}
-	if len(req.Images) > 0 {
-		existingProduct.Images = req.Images
+	if req.Status != "" {
+		existingProduct.Status = req.Status
 	}
+	// categories and images are replaced only when sent, they are linked through
+	// product_categories and product_images
+	var categories []int64
 	if len(req.Categories) > 0 {
-		existingProduct.Categories = req.Categories
+		categories = req.Categories
+	}
+	var imageIDs []int64
+	if len(req.ImageIDs) > 0 {
+		imageIDs = req.ImageIDs
 	}
 
 	baseURL := sanitizeName(existingProduct.Name)
@@ -365,35 +616,72 @@ func (server *Server) updateProduct(ctx *gin.Context) {
 		i++
 	}
 
-	arg := db.UpdateProductParams{
-		ID:          productID,
-		Name:        existingProduct.Name,
-		Description: existingProduct.Description,
-		UserID:      existingProduct.UserID,
-		Username:    user.Username,
-		Price:       existingProduct.Price,
-		OldPrice:    existingProduct.OldPrice,
-		Sku:         existingProduct.Sku,
-		Url:         url,
-		Images:      existingProduct.Images,
-		Categories:  existingProduct.Categories,
-	}
-
-	// Perform the update operation with the modified product data
-	product, err := server.store.UpdateProduct(ctx, arg)
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.UpdateProductTxParams{
+		UpdateProductParams: db.UpdateProductParams{
+			ID:          productID,
+			Name:        existingProduct.Name,
+			Description: existingProduct.Description,
+			UserID:      existingProduct.UserID,
+			Username:    user.Username,
+			Price:       existingProduct.Price,
+			OldPrice:    existingProduct.OldPrice,
+			Sku:         existingProduct.Sku,
+			Url:         url,
+			SupplierID:  existingProduct.SupplierID,
+			CostPrice:   existingProduct.CostPrice,
+			BrandID:     existingProduct.BrandID,
+			Status:      existingProduct.Status,
+			Version:     version,
+		},
+		Categories: categories,
+		ImageIDs:   imageIDs,
+		ChangedBy:  authPayload.Username,
+	}
+
+	// Perform the update operation with the modified product data,
+	// price changes are recorded in the price history
+	product, err := server.store.UpdateProductTx(ctx, arg)
 	if err != nil {
 		fmt.Println("error in updating product")
+		if errors.Is(err, db.ErrVersionConflict) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, product)
+	recordAudit(ctx, product.ID, previousProduct, product)
+	server.sendProduct(ctx, product)
+}
+
+// checkSupplier makes sure a supplier set on a product exists, 0 means no supplier.
+// The error response is already written when it returns false.
+func (server *Server) checkSupplier(ctx *gin.Context, supplierID int64) bool {
+	if supplierID == 0 {
+		return true
+	}
+
+	_, err := server.store.GetSupplier(ctx, supplierID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("supplier %d not found", supplierID)))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	return true
 }
 
 type deleteProductRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
+// deleteProduct moves the product to the trash, it can be restored later
 func (server *Server) deleteProduct(ctx *gin.Context) {
 	var req deleteProductRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
@@ -401,12 +689,51 @@ func (server *Server) deleteProduct(ctx *gin.Context) {
 		return
 	}
 
-	// delete existing product
-	err := server.store.DeleteProduct(ctx, req.ID)
+	existingProduct, err := server.store.GetProduct(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// a product already in the trash isn't deleted again and nothing is logged
+	product, err := server.store.SoftDeleteProduct(ctx, req.ID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	// the product is kept in the trash, the log has the deleted_at it got
+	recordAudit(ctx, product.ID, existingProduct, product)
+
 	ctx.JSON(http.StatusOK, "Product deleted successfully")
 }
+
+func (server *Server) restoreProduct(ctx *gin.Context) {
+	var req deleteProductRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// only deleted products are restored
+	product, err := server.store.RestoreProduct(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, product.ID, nil, product)
+	server.sendProduct(ctx, product)
+}
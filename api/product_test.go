@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -44,12 +45,28 @@ func TestCreateProductAPI(t *testing.T) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				// Define expectations for the CreateProduct function in your mock store.
-				store.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).Times(1).Return(product, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(product.UserID)).Times(1).Return(db.User{ID: product.UserID, Username: "owner"}, nil)
+				store.EXPECT().GetProductByURL(gomock.Any(), gomock.Any()).Times(1).Return(db.Product{}, sql.ErrNoRows)
+				// the initial price is recorded by the transaction on behalf of the logged in user
+				arg := db.CreateProductTxParams{
+					CreateProductParams: db.CreateProductParams{
+						Name:        product.Name,
+						Description: product.Description,
+						UserID:      product.UserID,
+						Username:    "owner",
+						Url:         sanitizeName(product.Name),
+						Status:      db.ProductStatusDraft,
+					},
+					Categories: []int64{},
+					ChangedBy:  "username",
+				}
+				store.EXPECT().CreateProductTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(product, nil)
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return([]db.ProductCategory{}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchProduct(t, recorder.Body, product)
+				requireBodyMatchProduct(t, recorder.Body, product, []productImage{})
 			},
 		},
 	}
@@ -89,29 +106,34 @@ func randomProduct() db.Product {
 		ID:          util.RandomInt(1, 1000),
 		Name:        util.RandomString(10),
 		Description: util.RandomString(50),
-		UserID:      util.RandomInt(1, 1000),
+		UserID:      util.RandomInt(2, 1000),
+		Status:      db.ProductStatusPublished,
 	}
 }
 
-// requireBodyMatchProduct checks if the response body matches the expected product
-func requireBodyMatchProduct(t *testing.T, body *bytes.Buffer, product db.Product) {
+// requireBodyMatchProduct checks if the response body matches the expected product and its images
+func requireBodyMatchProduct(t *testing.T, body *bytes.Buffer, product db.Product, images []productImage) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var gotProduct db.Product
+	var gotProduct productResponse
 	err = json.Unmarshal(data, &gotProduct)
 	require.NoError(t, err)
-	require.Equal(t, product, gotProduct)
+	require.Equal(t, product, gotProduct.Product)
+	require.Equal(t, images, gotProduct.Images)
 }
 
 // TestGetProductAPI tests the GetProduct API endpoint.
 func TestGetProductAPI(t *testing.T) {
 	// Generate a random product for testing.
 	product := randomProduct()
+	draft := randomProduct()
+	draft.Status = db.ProductStatusDraft
 
 	testCases := []struct {
 		name          string
 		productID     int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
@@ -121,10 +143,89 @@ func TestGetProductAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				// Define expectations for the GetProduct function in your mock store.
 				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
+				// the images come ordered from product_images
+				rows := []db.ListImagesByProductsRow{
+					{ProductID: product.ID, ID: 7, ImagePath: "/media/2023/10/front.jpg", Alt: "front"},
+					{ProductID: product.ID, ID: 3, ImagePath: "/media/2023/10/back.jpg", Alt: "back"},
+				}
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return(rows, nil)
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return([]db.ProductCategory{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchProduct(t, recorder.Body, product, []productImage{
+					{ID: 7, Path: "/media/2023/10/front.jpg", Alt: "front"},
+					{ID: 3, Path: "/media/2023/10/back.jpg", Alt: "back"},
+				})
+			},
+		},
+		{
+			name:      "ImagesError",
+			productID: product.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:      "DraftHiddenFromVisitors",
+			productID: draft.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), draft.ID).Times(1).Return(draft, nil)
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "DraftSeenByAdmins",
+			productID: draft.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), draft.ID).Times(1).Return(draft, nil)
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ProductCategory{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchProduct(t, recorder.Body, draft, []productImage{})
+			},
+		},
+		{
+			name:      "InvalidTokenIsVisitor",
+			productID: draft.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				request.Header.Set(authorizationHeaderKey, "bearer invalid")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), draft.ID).Times(1).Return(draft, nil)
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "ExpiredTokenIsVisitor",
+			productID: product.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", -time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ProductCategory{}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchProduct(t, recorder.Body, product)
 			},
 		},
 	}
@@ -149,7 +250,10 @@ func TestGetProductAPI(t *testing.T) {
 			request, err := http.NewRequest(http.MethodGet, requestURI, nil)
 			require.NoError(t, err)
 
-			// Serve the request and check the response
+			// Serve the request and check the response, visitors send no authorization
+			if tc.setupAuth != nil {
+				tc.setupAuth(t, request, server.tokenMaker)
+			}
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(t, recorder)
 		})
@@ -159,18 +263,21 @@ func TestGetProductAPI(t *testing.T) {
 func TestUpdateProductAPI(t *testing.T) {
 	// Generate a random product for testing.
 	product := randomProduct()
+	product.Version = 3
 
 	// Create an update request with modified data.
 	updateRequest := updateProductRequest{
 		Name:        "Updated Product Name",
 		Description: "Updated Product Description",
 		UserID:      123,
+		Version:     3,
 	}
 
 	testCases := []struct {
 		name          string
 		productID     int64
 		requestBody   interface{}
+		ifMatch       string
 		setupAuth     func(t *testing.T, request *http.Request, tokenMkaer token.Maker)
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
@@ -183,16 +290,188 @@ func TestUpdateProductAPI(t *testing.T) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				// Define expectations for the GetProduct and UpdateProduct functions in your mock store.
+				// Define expectations for the GetProduct and UpdateProductTx functions in your mock store.
 				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
-				store.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).Times(1).Return(product, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(int64(123))).Times(1).Return(db.User{ID: 123, Username: "owner"}, nil)
+				store.EXPECT().GetProductByURL(gomock.Any(), gomock.Any()).Times(1).Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(1).Return(product, nil)
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return([]db.ProductCategory{}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchProduct(t, recorder.Body, product)
+				require.Equal(t, `"3"`, recorder.Header().Get("ETag"))
+				requireBodyMatchProduct(t, recorder.Body, product, []productImage{})
+			},
+		},
+		{
+			name:      "ReplaceImages",
+			productID: product.ID,
+			requestBody: updateProductRequest{
+				UserID:   123,
+				ImageIDs: []int64{9, 4},
+				Version:  3,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(int64(123))).Times(1).Return(db.User{ID: 123, Username: "owner"}, nil)
+				store.EXPECT().GetProductByURL(gomock.Any(), gomock.Any()).Times(1).Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdateProductTxParams) (db.Product, error) {
+						require.Equal(t, []int64{9, 4}, arg.ImageIDs)
+						require.Nil(t, arg.Categories)
+						return product, nil
+					})
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ProductCategory{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "PriceChange",
+			productID: product.ID,
+			requestBody: updateProductRequest{
+				UserID:   123,
+				Price:    "89,90",
+				OldPrice: "99,90",
+				Version:  3,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(int64(123))).Times(1).Return(db.User{ID: 123, Username: "owner"}, nil)
+				store.EXPECT().GetProductByURL(gomock.Any(), gomock.Any()).Times(1).Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdateProductTxParams) (db.Product, error) {
+						require.Equal(t, 89.9, arg.Price)
+						require.Equal(t, 99.9, arg.OldPrice)
+						require.Equal(t, "username", arg.ChangedBy)
+						require.Nil(t, arg.ImageIDs)
+						return product, nil
+					})
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ProductCategory{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidPrice",
+			productID: product.ID,
+			requestBody: updateProductRequest{
+				UserID:  123,
+				Price:   "abc",
+				Version: 3,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(int64(123))).Times(1).Return(db.User{ID: 123}, nil)
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "IfMatch",
+			productID:   product.ID,
+			requestBody: updateProductRequest{UserID: 123},
+			ifMatch:     `"3"`,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(int64(123))).Times(1).Return(db.User{ID: 123, Username: "owner"}, nil)
+				store.EXPECT().GetProductByURL(gomock.Any(), gomock.Any()).Times(1).Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdateProductTxParams) (db.Product, error) {
+						require.Equal(t, int64(3), arg.Version)
+						updated := product
+						updated.Version = 4
+						return updated, nil
+					})
+				store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+				store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ProductCategory{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `"4"`, recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name:        "VersionRequired",
+			productID:   product.ID,
+			requestBody: updateProductRequest{UserID: 123},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidIfMatch",
+			productID:   product.ID,
+			requestBody: updateProductRequest{UserID: 123},
+			ifMatch:     "*",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "StaleVersion",
+			productID:   product.ID,
+			requestBody: updateProductRequest{UserID: 123, Version: 2},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "ChangedMeanwhile",
+			productID:   product.ID,
+			requestBody: updateRequest,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(int64(123))).Times(1).Return(db.User{ID: 123, Username: "owner"}, nil)
+				store.EXPECT().GetProductByURL(gomock.Any(), gomock.Any()).Times(1).Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().UpdateProductTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Product{}, db.ErrVersionConflict)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
 			},
 		},
-		// Add more test cases for different scenarios as needed.
 	}
 
 	for i := range testCases {
@@ -218,6 +497,9 @@ func TestUpdateProductAPI(t *testing.T) {
 			// Create an HTTP request with the specified URI and JSON body.
 			request, err := http.NewRequest(http.MethodPut, requestURI, bytes.NewReader(requestBody))
 			require.NoError(t, err)
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			// Serve the request and check the response.
 			tc.setupAuth(t, request, server.tokenMaker)
@@ -245,14 +527,57 @@ func TestDeleteProductAPI_OK(t *testing.T) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				// Define expectations for the DeleteProduct function in your mock store.
-				store.EXPECT().DeleteProduct(gomock.Any(), product.ID).Times(1).Return(nil)
+				deleted := product
+				deleted.DeletedAt = time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC)
+				deleted.Version = product.Version + 1
+
+				// products are moved to the trash instead of being deleted
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(product, nil)
+				store.EXPECT().SoftDeleteProduct(gomock.Any(), product.ID).Times(1).Return(deleted, nil)
+				store.EXPECT().DeleteProduct(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateAuditLogParams) (db.AuditLog, error) {
+						require.Equal(t, product.ID, arg.EntityID)
+						require.JSONEq(t, `{"deleted_at": {"before": "0001-01-01T00:00:00Z", "after": "2023-10-05T12:00:00Z"}}`, string(arg.Changes))
+						return db.AuditLog{}, nil
+					})
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				require.Equal(t, "Product deleted successfully", strings.Trim(recorder.Body.String(), "\n\""))
 			},
 		},
+		{
+			name:      "AlreadyDeleted",
+			productID: product.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				deleted := product
+				deleted.DeletedAt = time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC)
+
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(deleted, nil)
+				store.EXPECT().SoftDeleteProduct(gomock.Any(), product.ID).Times(1).Return(db.Product{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			productID: product.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), product.ID).Times(1).Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().SoftDeleteProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -282,3 +607,199 @@ func TestDeleteProductAPI_OK(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreProductAPI(t *testing.T) {
+	product := randomProduct()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().RestoreProduct(gomock.Any(), gomock.Eq(product.ID)).Times(1).Return(product, nil)
+	store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+	store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Eq([]int64{product.ID})).Times(1).Return([]db.ProductCategory{}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/products/%d/restore", product.ID), nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	requireBodyMatchProduct(t, recorder.Body, product, []productImage{})
+}
+
+func TestListProductPublicOnlyAPI(t *testing.T) {
+	product := randomProduct()
+
+	testCases := []struct {
+		name       string
+		loggedIn   bool
+		buildStubs func(store *mockdb.MockStore)
+	}{
+		{
+			name: "Visitor",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountPublishedProducts(gomock.Any()).Times(1).Return(int64(1), nil)
+				store.EXPECT().ListPublishedProducts(gomock.Any(), gomock.Eq(db.ListPublishedProductsParams{Limit: 5, Offset: 0})).
+					Times(1).Return([]db.Product{product}, nil)
+				store.EXPECT().CountProducts(gomock.Any()).Times(0)
+				store.EXPECT().ListProducts(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:     "Admin",
+			loggedIn: true,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountProducts(gomock.Any()).Times(1).Return(int64(1), nil)
+				store.EXPECT().ListProducts(gomock.Any(), gomock.Eq(db.ListProductsParams{Limit: 5, Offset: 0})).
+					Times(1).Return([]db.Product{product}, nil)
+				store.EXPECT().ListPublishedProducts(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+			store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ProductCategory{}, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/products?page_id=1&page_size=5", nil)
+			require.NoError(t, err)
+
+			if tc.loggedIn {
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			}
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+		})
+	}
+}
+
+func TestListProductFacetsAPI(t *testing.T) {
+	product := randomProduct()
+	facets := db.ProductFacets{
+		Categories: []db.ProductFacet{{ID: 1, Name: "Cães", Slug: "caes", Count: 42}, {ID: 2, Name: "Gatos", Slug: "gatos", Count: 17}},
+		Brands:     []db.ProductFacet{{ID: 3, Name: "Golden", Slug: "golden", Count: 5}},
+		Prices:     []db.PriceFacet{{Min: 0, Max: 50, Count: 30}, {Min: 50, Max: 0, Count: 29}},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "page_id=1&page_size=5&search=racao&category_ids=1&facets=true",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FilterProducts(gomock.Any(), []int64{1}, gomock.Nil(), 1, 5, "", "", "racao", true).
+					Times(1).Return([]db.Product{product}, int64(1), nil)
+				store.EXPECT().ProductFacets(gomock.Any(), []int64{1}, gomock.Nil(), "racao", true).
+					Times(1).Return(facets, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listProductResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, &facets, got.Facets)
+			},
+		},
+		{
+			name:  "NotRequested",
+			query: "page_id=1&page_size=5&search=racao",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchProducts(gomock.Any(), "racao", 1, 5, "", "", true).
+					Times(1).Return([]db.Product{product}, int64(1), nil)
+				store.EXPECT().ProductFacets(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), "facets")
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "page_id=1&page_size=5&facets=true",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountPublishedProducts(gomock.Any()).Times(1).Return(int64(1), nil)
+				store.EXPECT().ListPublishedProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.Product{product}, nil)
+				store.EXPECT().ProductFacets(gomock.Any(), gomock.Any(), gomock.Any(), "", true).
+					Times(1).Return(db.ProductFacets{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+			store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ProductCategory{}, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/products?"+tc.query, nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListProductPageAPI(t *testing.T) {
+	product := randomProduct()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	arg := db.ProductPageParams{
+		PageParams:  db.PageParams{Cursor: "abc", Limit: 5, Search: "racao"},
+		CategoryIDs: []int64{1},
+		PublicOnly:  true,
+	}
+	store.EXPECT().ListProductsPage(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Product{product}, "def", nil)
+	store.EXPECT().FilterProducts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().ListImagesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListImagesByProductsRow{}, nil)
+	store.EXPECT().ListProductCategoriesByProducts(gomock.Any(), gomock.Any()).Times(1).Return([]db.ProductCategory{}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/products?cursor=abc&page_size=5&search=racao&category_ids=1", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got listProductResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Nil(t, got.Total)
+	require.Len(t, got.Products, 1)
+	require.Equal(t, "def", got.NextCursor)
+}
@@ -0,0 +1,367 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	db "super-pet-delivery/db/sqlc"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type purchaseOrderLineRequest struct {
+	ProductID int64   `json:"product_id" binding:"required,min=1"`
+	Quantity  int32   `json:"quantity" binding:"required,min=1"`
+	UnitCost  float64 `json:"unit_cost" binding:"min=0"`
+}
+
+type createPurchaseOrderRequest struct {
+	SupplierID int64                      `json:"supplier_id" binding:"required,min=1"`
+	Notes      string                     `json:"notes"`
+	ExpectedAt time.Time                  `json:"expected_at"`
+	Lines      []purchaseOrderLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+type purchaseOrderResponse struct {
+	db.PurchaseOrder
+	Lines []db.PurchaseOrderLine `json:"lines"`
+	Total float64                `json:"total"`
+}
+
+func newPurchaseOrderResponse(order db.PurchaseOrder, lines []db.PurchaseOrderLine) purchaseOrderResponse {
+	return purchaseOrderResponse{
+		PurchaseOrder: order,
+		Lines:         lines,
+		Total:         purchaseOrderTotal(lines),
+	}
+}
+
+// purchaseOrderTotal is the ordered cost of all lines
+func purchaseOrderTotal(lines []db.PurchaseOrderLine) float64 {
+	total := 0.0
+	for _, line := range lines {
+		total += float64(line.Quantity) * line.UnitCost
+	}
+	return roundCents(total)
+}
+
+// purchaseOrderLines snapshots the product names of the lines. A line without a unit cost
+// uses the last cost paid for the product, or its cost price when it was never received.
+func (server *Server) purchaseOrderLines(ctx *gin.Context, lines []purchaseOrderLineRequest) ([]db.PurchaseOrderLineParams, bool) {
+	params := []db.PurchaseOrderLineParams{}
+	for _, line := range lines {
+		product, err := server.store.GetProduct(ctx, line.ProductID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("product %d not found", line.ProductID)))
+				return nil, false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return nil, false
+		}
+
+		unitCost := line.UnitCost
+		if unitCost == 0 {
+			unitCost = product.LastCost
+		}
+		if unitCost == 0 {
+			unitCost = product.CostPrice
+		}
+
+		params = append(params, db.PurchaseOrderLineParams{
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			Quantity:    line.Quantity,
+			UnitCost:    unitCost,
+		})
+	}
+	return params, true
+}
+
+func (server *Server) createPurchaseOrder(ctx *gin.Context) {
+	var req createPurchaseOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	supplier, err := server.store.GetSupplier(ctx, req.SupplierID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	lines, ok := server.purchaseOrderLines(ctx, req.Lines)
+	if !ok {
+		return
+	}
+
+	result, err := server.store.CreatePurchaseOrderTx(ctx, db.CreatePurchaseOrderTxParams{
+		CreatePurchaseOrderParams: db.CreatePurchaseOrderParams{
+			SupplierID:   supplier.ID,
+			SupplierName: supplier.Name,
+			Notes:        req.Notes,
+			ExpectedAt:   req.ExpectedAt,
+		},
+		Lines: lines,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, result.Order.ID, nil, result.Order)
+	ctx.JSON(http.StatusOK, newPurchaseOrderResponse(result.Order, result.Lines))
+}
+
+type getPurchaseOrderRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// fetchPurchaseOrder loads an order with its lines, writing the error response when it fails
+func (server *Server) fetchPurchaseOrder(ctx *gin.Context, id int64) (db.PurchaseOrder, []db.PurchaseOrderLine, bool) {
+	order, err := server.store.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return order, nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return order, nil, false
+	}
+
+	lines, err := server.store.ListPurchaseOrderLines(ctx, id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return order, nil, false
+	}
+
+	return order, lines, true
+}
+
+func (server *Server) getPurchaseOrder(ctx *gin.Context) {
+	var req getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	order, lines, ok := server.fetchPurchaseOrder(ctx, req.ID)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPurchaseOrderResponse(order, lines))
+}
+
+type listPurchaseOrderRequest struct {
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=100"`
+	Status   string `form:"status" binding:"omitempty,oneof=draft sent partially_received received"`
+}
+
+type listPurchaseOrderResponse struct {
+	Total          int64              `json:"total"`
+	PurchaseOrders []db.PurchaseOrder `json:"purchase_orders"`
+}
+
+func (server *Server) listPurchaseOrder(ctx *gin.Context) {
+	var req listPurchaseOrderRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	total, err := server.store.CountPurchaseOrders(ctx, req.Status)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	orders, err := server.store.ListPurchaseOrders(ctx, db.ListPurchaseOrdersParams{
+		Status:      req.Status,
+		LimitCount:  req.PageSize,
+		OffsetCount: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listPurchaseOrderResponse{Total: total, PurchaseOrders: orders})
+}
+
+type updatePurchaseOrderRequest struct {
+	Notes      *string                    `json:"notes"`
+	ExpectedAt *time.Time                 `json:"expected_at"`
+	Lines      []purchaseOrderLineRequest `json:"lines" binding:"omitempty,min=1,dive"`
+}
+
+func (server *Server) updatePurchaseOrder(ctx *gin.Context) {
+	var uri getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updatePurchaseOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	existing, err := server.store.GetPurchaseOrder(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	previous := existing
+
+	// Update only the fields that are provided in the request
+	if req.Notes != nil {
+		existing.Notes = *req.Notes
+	}
+	if req.ExpectedAt != nil {
+		existing.ExpectedAt = *req.ExpectedAt
+	}
+
+	var lines []db.PurchaseOrderLineParams
+	if req.Lines != nil {
+		var ok bool
+		lines, ok = server.purchaseOrderLines(ctx, req.Lines)
+		if !ok {
+			return
+		}
+	}
+
+	result, err := server.store.UpdatePurchaseOrderTx(ctx, db.UpdatePurchaseOrderTxParams{
+		UpdatePurchaseOrderParams: db.UpdatePurchaseOrderParams{
+			ID:         existing.ID,
+			Notes:      existing.Notes,
+			ExpectedAt: existing.ExpectedAt,
+		},
+		Lines: lines,
+	})
+	if err != nil {
+		server.purchaseOrderError(ctx, err)
+		return
+	}
+
+	recordAudit(ctx, result.Order.ID, previous, result.Order)
+	ctx.JSON(http.StatusOK, newPurchaseOrderResponse(result.Order, result.Lines))
+}
+
+func (server *Server) sendPurchaseOrder(ctx *gin.Context) {
+	var req getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	order, err := server.store.SendPurchaseOrderTx(ctx, req.ID)
+	if err != nil {
+		server.purchaseOrderError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+type receiveLineRequest struct {
+	LineID   int64   `json:"line_id" binding:"required,min=1"`
+	Quantity int32   `json:"quantity" binding:"required,min=1"`
+	UnitCost float64 `json:"unit_cost" binding:"min=0"`
+}
+
+type receivePurchaseOrderRequest struct {
+	Lines []receiveLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+func (server *Server) receivePurchaseOrder(ctx *gin.Context) {
+	var uri getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req receivePurchaseOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ReceivePurchaseOrderTxParams{ID: uri.ID}
+	for _, line := range req.Lines {
+		arg.Lines = append(arg.Lines, db.ReceiveLineParams{
+			LineID:   line.LineID,
+			Quantity: line.Quantity,
+			UnitCost: line.UnitCost,
+		})
+	}
+
+	result, err := server.store.ReceivePurchaseOrderTx(ctx, arg)
+	if err != nil {
+		server.purchaseOrderError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newPurchaseOrderResponse(result.Order, result.Lines))
+}
+
+func (server *Server) deletePurchaseOrder(ctx *gin.Context) {
+	var req getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	order, err := server.store.GetPurchaseOrder(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// orders already sent to the supplier are kept as a record of the purchase
+	if order.Status != db.PurchaseOrderDraft {
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrPurchaseOrderStatus))
+		return
+	}
+
+	err = server.store.DeletePurchaseOrder(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, order.ID, order, nil)
+
+	ctx.JSON(http.StatusOK, "Purchase order deleted successfully")
+}
+
+// purchaseOrderError maps the errors of the purchase order transactions to a response
+func (server *Server) purchaseOrderError(ctx *gin.Context, err error) {
+	switch {
+	case err == sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, db.ErrPurchaseOrderStatus):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	case errors.Is(err, db.ErrReceiveExceedsOrdered), errors.Is(err, db.ErrUnknownPurchaseOrderLine):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
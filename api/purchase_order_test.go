@@ -0,0 +1,305 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreatePurchaseOrderAPI(t *testing.T) {
+	supplier := randomSupplier()
+	product := randomProduct()
+	product.CostPrice = 30
+	product.LastCost = 32.5
+
+	order := db.PurchaseOrder{
+		ID:           util.RandomInt(1, 1000),
+		SupplierID:   supplier.ID,
+		SupplierName: supplier.Name,
+		Status:       db.PurchaseOrderDraft,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"supplier_id": supplier.ID,
+				"lines":       []gin.H{{"product_id": product.ID, "quantity": 4}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(supplier, nil)
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Eq(product.ID)).Times(1).Return(product, nil)
+				arg := db.CreatePurchaseOrderTxParams{
+					CreatePurchaseOrderParams: db.CreatePurchaseOrderParams{
+						SupplierID:   supplier.ID,
+						SupplierName: supplier.Name,
+					},
+					// without a unit cost the line uses the last cost paid
+					Lines: []db.PurchaseOrderLineParams{{ProductID: product.ID, ProductName: product.Name, Quantity: 4, UnitCost: 32.5}},
+				}
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrderTxResult{
+					Order: order,
+					Lines: []db.PurchaseOrderLine{{ID: 1, PurchaseOrderID: order.ID, ProductID: product.ID, ProductName: product.Name, Quantity: 4, UnitCost: 32.5}},
+				}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var got purchaseOrderResponse
+				require.NoError(t, json.Unmarshal(data, &got))
+				require.Equal(t, order.ID, got.ID)
+				require.Len(t, got.Lines, 1)
+				require.Equal(t, 130.0, got.Total)
+			},
+		},
+		{
+			name: "SupplierNotFound",
+			body: gin.H{
+				"supplier_id": supplier.ID,
+				"lines":       []gin.H{{"product_id": product.ID, "quantity": 4}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSupplier(gomock.Any(), gomock.Any()).Times(1).Return(db.Supplier{}, sql.ErrNoRows)
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "ProductNotFound",
+			body: gin.H{
+				"supplier_id": supplier.ID,
+				"lines":       []gin.H{{"product_id": product.ID, "quantity": 4}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSupplier(gomock.Any(), gomock.Any()).Times(1).Return(supplier, nil)
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Times(1).Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NoLines",
+			body: gin.H{"supplier_id": supplier.ID, "lines": []gin.H{}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSupplier(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/purchase_orders", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestReceivePurchaseOrderAPI(t *testing.T) {
+	orderID := util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "PartiallyReceived",
+			body: gin.H{"lines": []gin.H{{"line_id": 1, "quantity": 2, "unit_cost": 31}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReceivePurchaseOrderTxParams{
+					ID:    orderID,
+					Lines: []db.ReceiveLineParams{{LineID: 1, Quantity: 2, UnitCost: 31}},
+				}
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrderTxResult{
+					Order: db.PurchaseOrder{ID: orderID, Status: db.PurchaseOrderPartiallyReceived},
+					Lines: []db.PurchaseOrderLine{{ID: 1, PurchaseOrderID: orderID, Quantity: 4, ReceivedQuantity: 2, UnitCost: 32.5, ReceivedUnitCost: 31}},
+				}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var got purchaseOrderResponse
+				require.NoError(t, json.Unmarshal(data, &got))
+				require.Equal(t, db.PurchaseOrderPartiallyReceived, got.Status)
+				require.Equal(t, 130.0, got.Total)
+				require.Equal(t, 31.0, got.Lines[0].ReceivedUnitCost)
+			},
+		},
+		{
+			name: "NotSent",
+			body: gin.H{"lines": []gin.H{{"line_id": 1, "quantity": 2}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.PurchaseOrderTxResult{}, db.ErrPurchaseOrderStatus)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "ExceedsOrdered",
+			body: gin.H{"lines": []gin.H{{"line_id": 1, "quantity": 20}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.PurchaseOrderTxResult{}, db.ErrReceiveExceedsOrdered)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownLine",
+			body: gin.H{"lines": []gin.H{{"line_id": 99, "quantity": 1}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.PurchaseOrderTxResult{}, fmt.Errorf("%w: line 99", db.ErrUnknownPurchaseOrderLine))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"lines": []gin.H{{"line_id": 1, "quantity": 1}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.PurchaseOrderTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/purchase_orders/%d/receive", orderID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeletePurchaseOrderAPI(t *testing.T) {
+	order := db.PurchaseOrder{ID: util.RandomInt(1, 1000), Status: db.PurchaseOrderDraft}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPurchaseOrder(gomock.Any(), gomock.Eq(order.ID)).Times(1).Return(order, nil)
+				store.EXPECT().DeletePurchaseOrder(gomock.Any(), gomock.Eq(order.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadySent",
+			buildStubs: func(store *mockdb.MockStore) {
+				sent := order
+				sent.Status = db.PurchaseOrderSent
+				store.EXPECT().GetPurchaseOrder(gomock.Any(), gomock.Eq(order.ID)).Times(1).Return(sent, nil)
+				store.EXPECT().DeletePurchaseOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/purchase_orders/%d", order.ID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
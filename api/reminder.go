@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"super-pet-delivery/reminder"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type listDueRemindersRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=90"`
+}
+
+// listDueReminders lists the clients likely to run out of a product in the next days
+func (server *Server) listDueReminders(ctx *gin.Context) {
+	var req listDueRemindersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Days == 0 {
+		req.Days = 7
+	}
+
+	clientIDs, err := server.store.ListSaleClientIDs(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	now := time.Now()
+	options := reminder.OptionsFromConfig(server.config)
+	reminders := []reminder.Reminder{}
+
+	for _, clientID := range clientIDs {
+		sales, err := server.store.GetSalesByClientID(ctx, clientID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		reminders = append(reminders, reminder.Estimate(sales, now, req.Days, options)...)
+	}
+
+	reminder.Sort(reminders)
+
+	ctx.JSON(http.StatusOK, reminders)
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/reminder"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListDueRemindersAPI(t *testing.T) {
+	client := randomClient()
+	now := time.Now()
+
+	sales := make([]db.Sale, 3)
+	for i := range sales {
+		sales[i] = db.Sale{
+			ID:         int64(i + 1),
+			ClientID:   client.ID,
+			ClientName: client.FullName,
+			Product:    "Ração 15kg",
+			Status:     db.SaleStatusConfirmed,
+			CreatedAt:  now.AddDate(0, 0, -(85 - 30*i)),
+		}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListSaleClientIDs(gomock.Any()).Times(1).Return([]int64{client.ID}, nil)
+	store.EXPECT().GetSalesByClientID(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(sales, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/reminders/due?days=7", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var reminders []reminder.Reminder
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &reminders))
+	require.Len(t, reminders, 1)
+	require.Equal(t, client.ID, reminders[0].ClientID)
+	require.Equal(t, "Ração 15kg", reminders[0].Product)
+}
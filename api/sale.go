@@ -2,21 +2,34 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/notification"
+	"super-pet-delivery/promotion"
+	"super-pet-delivery/token"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type createSaleRequest struct {
-	ClientID    int64  `json:"client_id" binding:"required"`
-	Product     string `json:"product" binding:"required"`
-	Price       string `json:"price" binding:"required"`
-	Observation string `json:"observation" binding:"required"`
+	ClientID     int64  `json:"client_id" binding:"required"`
+	Product      string `json:"product" binding:"required"`
+	Price        string `json:"price" binding:"required"`
+	Observation  string `json:"observation" binding:"required"`
+	ProductID    int64  `json:"product_id" binding:"omitempty,min=1"`
+	CouponCode   string `json:"coupon_code"`
+	RedeemPoints int64  `json:"redeem_points" binding:"omitempty,min=1"`
+}
+
+type saleResponse struct {
+	db.Sale
+	Items     []db.SaleItem     `json:"items"`
+	Discounts []db.SaleDiscount `json:"discounts"`
 }
 
 func (server *Server) createSale(ctx *gin.Context) {
@@ -42,22 +55,105 @@ func (server *Server) createSale(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.CreateSaleParams{
-		ClientID:    req.ClientID,
-		ClientName:  client.FullName,
-		Product:     req.Product,
-		Price:       price,
-		Observation: req.Observation,
+	// The catalog product is recorded as the sale item with its current cost.
+	// Its categories decide the loyalty multiplier and the coupon scope
+	var product db.Product
+	categories := []db.Category{}
+	if req.ProductID != 0 {
+		product, err = server.store.GetProduct(ctx, req.ProductID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		categories, err = server.store.ListCategoriesByProduct(ctx, req.ProductID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
 	}
 
-	sale, err := server.store.CreateSale(ctx, arg)
+	// The coupon is applied first and recorded as a discount line of the sale
+	var coupon *db.SaleCouponParams
+	if req.CouponCode != "" {
+		item := promotion.CartItem{ProductID: req.ProductID, CategoryIDs: categoryIDs(categories), Price: price, Quantity: 1}
+
+		applied, result, err := server.evaluateCoupon(ctx, req.CouponCode, promotion.Cart{ClientID: req.ClientID, Items: []promotion.CartItem{item}})
+		if err != nil {
+			if isCouponRuleError(err) {
+				ctx.JSON(http.StatusBadRequest, errorResponse(err))
+				return
+			}
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
 
+		coupon = &db.SaleCouponParams{
+			CouponID:    applied.ID,
+			Code:        applied.Code,
+			Description: applied.Description,
+			Amount:      result.Discount,
+		}
+		price -= result.Discount
+	}
+
+	// Redeemed points are discounted from the price before the earned points are computed
+	discount := server.loyalty.Discount(req.RedeemPoints)
+	if discount > price {
+		err := fmt.Errorf("discount of %.2f is greater than the sale price", discount)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	price -= discount
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.CreateSaleTxParams{
+		CreateSaleParams: db.CreateSaleParams{
+			ClientID:    req.ClientID,
+			ClientName:  client.FullName,
+			Product:     req.Product,
+			Price:       price,
+			Observation: req.Observation,
+			Status:      db.SaleStatusConfirmed,
+		},
+		ChangedBy:    authPayload.Username,
+		Coupon:       coupon,
+		RedeemPoints: req.RedeemPoints,
+		EarnPoints:   server.loyalty.Earned(price, categories),
+	}
+	if req.ProductID != 0 {
+		arg.Items = []db.SaleItemParams{{
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			Quantity:    1,
+			UnitPrice:   price,
+			UnitCost:    product.CostPrice,
+		}}
+	}
+
+	result, err := server.store.CreateSaleTx(ctx, arg)
 	if err != nil {
+		if err == db.ErrInsufficientPoints || err == db.ErrCouponUsageLimit {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, sale)
+	server.notifySale(ctx, notification.EventSaleCreated, result.Sale, client)
+	recordAudit(ctx, result.Sale.ID, nil, result.Sale)
+
+	ctx.JSON(http.StatusOK, saleResponse{Sale: result.Sale, Items: result.Items, Discounts: result.Discounts})
 }
 
 type getSaleRequest struct {
@@ -82,20 +178,93 @@ func (server *Server) getSale(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, sale)
+	items, err := server.store.ListSaleItems(ctx, sale.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	discounts, err := server.store.ListSaleDiscounts(ctx, sale.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(ctx, sale.Version)
+	ctx.JSON(http.StatusOK, saleResponse{Sale: sale, Items: items, Discounts: discounts})
 }
 
 type listSaleResponse struct {
-	Total int64     `json:"total"`
-	Sales []db.Sale `json:"sales"`
+	Total      *int64    `json:"total,omitempty"`
+	Sales      []db.Sale `json:"sales"`
+	NextCursor string    `json:"next_cursor,omitempty"`
 }
 
 type listSaleRequest struct {
-	PageID        int32  `form:"page_id" binding:"required,min=1"`
-	PageSize      int32  `form:"page_size" binding:"required,min=5,max=100"`
-	SortField     string `form:"sort_field" binding:""`
-	SortDirection string `form:"sort_direction" binding:""`
-	Search        string `form:"search" binding:""`
+	PageID        int32   `form:"page_id" binding:"required_without=Cursor,min=0"`
+	Cursor        *string `form:"cursor"`
+	PageSize      int32   `form:"page_size" binding:"required,min=5,max=100"`
+	SortField     string  `form:"sort_field" binding:""`
+	SortDirection string  `form:"sort_direction" binding:""`
+	Search        string  `form:"search" binding:""`
+	// the filters, from and to are dates or RFC 3339 times and a date includes the whole day
+	From     string  `form:"from"`
+	To       string  `form:"to"`
+	ClientID int64   `form:"client_id" binding:"omitempty,min=1"`
+	MinPrice float64 `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice float64 `form:"max_price" binding:"omitempty,min=0"`
+	Product  string  `form:"product"`
+	Status   string  `form:"status" binding:"omitempty,oneof=pending confirmed"`
+	Printed  *bool   `form:"printed"`
+}
+
+// saleFilter checks the filters of the request and converts them to the filter of the store
+func (req listSaleRequest) saleFilter() (db.SaleFilter, error) {
+	filter := db.SaleFilter{
+		ClientID: req.ClientID,
+		MinPrice: req.MinPrice,
+		MaxPrice: req.MaxPrice,
+		Product:  req.Product,
+		Status:   req.Status,
+		Printed:  req.Printed,
+	}
+
+	var err error
+	if req.From != "" {
+		if filter.From, err = parseSaleDate(req.From, false); err != nil {
+			return filter, fmt.Errorf("invalid from: %s", req.From)
+		}
+	}
+	if req.To != "" {
+		if filter.To, err = parseSaleDate(req.To, true); err != nil {
+			return filter, fmt.Errorf("invalid to: %s", req.To)
+		}
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return filter, errors.New("from must not be after to")
+	}
+	if filter.MaxPrice != 0 && filter.MinPrice > filter.MaxPrice {
+		return filter, errors.New("min_price must not be greater than max_price")
+	}
+
+	return filter, nil
+}
+
+// parseSaleDate reads a RFC 3339 time or a date, endOfDay moves a date to the last instant of the day
+func parseSaleDate(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return t, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Microsecond)
+	}
+	return t, nil
 }
 
 func (server *Server) listSale(ctx *gin.Context) {
@@ -105,47 +274,92 @@ func (server *Server) listSale(ctx *gin.Context) {
 		return
 	}
 
+	filter, err := req.saleFilter()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Cursor != nil {
+		server.listSalePage(ctx, req, filter)
+		return
+	}
+
+	if !filter.IsZero() {
+		sales, total, err := server.store.FilterSales(ctx, filter, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection, req.Search)
+		if err != nil {
+			writeListError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, listSaleResponse{Total: &total, Sales: sales})
+		return
+	}
+
 	arg := db.ListSalesParams{
 		Limit:  req.PageSize,
 		Offset: (req.PageID - 1) * req.PageSize,
 	}
 
-	// Fetch the total number of sales
-	total, err := server.store.CountSales(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+	// the searches count the sales they match, the other lists count all of them
+	var total int64
+	if req.Search == "" {
+		total, err = server.store.CountSales(ctx)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
 	}
 
 	var sales []db.Sale
 	// Check if sort fields and search are provided
 	if req.SortField != "" && req.SortDirection != "" && req.Search != "" {
 		// Fetch the paginated sales with sorting and search
-		sales, err = server.store.SearchSales(ctx, req.Search, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection)
+		sales, total, err = server.store.SearchSales(ctx, req.Search, int(req.PageID), int(req.PageSize), req.SortField, req.SortDirection)
 	} else if req.SortField != "" && req.SortDirection != "" {
 		// Fetch the paginated sales with sorting
 		sales, err = server.store.ListSalesSorted(ctx, arg, req.SortField, req.SortDirection)
 	} else if req.Search != "" {
 		// Fetch the paginated sales with search
-		sales, err = server.store.SearchSales(ctx, req.Search, int(req.PageID), int(req.PageSize), "", "")
+		sales, total, err = server.store.SearchSales(ctx, req.Search, int(req.PageID), int(req.PageSize), "", "")
 	} else {
 		// Fetch the paginated sales without sorting or search
 		sales, err = server.store.ListSales(ctx, arg)
 	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeListError(ctx, err)
 		return
 	}
 
 	// Create the response structure
 	response := listSaleResponse{
-		Total: total,
+		Total: &total,
 		Sales: sales,
 	}
 
 	ctx.JSON(http.StatusOK, response)
 }
 
+// listSalePage is the cursor mode of listSale
+func (server *Server) listSalePage(ctx *gin.Context, req listSaleRequest, filter db.SaleFilter) {
+	sales, next, err := server.store.ListSalesPage(ctx, db.SalePageParams{
+		PageParams: db.PageParams{
+			Cursor:        *req.Cursor,
+			Limit:         req.PageSize,
+			SortField:     req.SortField,
+			SortDirection: req.SortDirection,
+			Search:        req.Search,
+		},
+		SaleFilter: filter,
+	})
+	if err != nil {
+		writeListError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listSaleResponse{Sales: sales, NextCursor: next})
+}
+
 func (server *Server) listAllSales(ctx *gin.Context) {
 	saleIDs, err := server.store.GetAllSaleIDs(ctx)
 	if err != nil {
@@ -161,7 +375,12 @@ type GetSalesByDateRequest struct {
 	EndDate   string `json:"end_date"`
 }
 
+// GetSalesByDate returns the ids of the sales created between the dates.
+//
+// Deprecated: use GET /sales with the from and to filters, it returns the sales themselves.
 func (server *Server) GetSalesByDate(ctx *gin.Context) {
+	deprecated(ctx, "/sales")
+
 	var req GetSalesByDateRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -196,7 +415,12 @@ type GetSalesByClientIDRequest struct {
 	ClientID int64 `uri:"client_id" binding:"required,min=1"`
 }
 
+// GetSalesByClientID returns the ids of the sales of a client.
+//
+// Deprecated: use GET /sales with the client_id filter, it returns the sales themselves.
 func (server *Server) GetSalesByClientID(ctx *gin.Context) {
+	deprecated(ctx, "/sales")
+
 	var req GetSalesByClientIDRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
@@ -221,11 +445,19 @@ func (server *Server) GetSalesByClientID(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"total": total, "sales": saleIDs})
 }
 
+// deprecated tells the clients the endpoint will be removed and which one replaces it
+func deprecated(ctx *gin.Context, successor string) {
+	ctx.Header("Deprecation", "true")
+	ctx.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+}
+
 type updateSaleRequest struct {
 	ClientID    int64  `json:"client_id"`
 	Product     string `json:"product"`
 	Price       string `json:"price"`
 	Observation string `json:"observation"`
+	Status      string `json:"status" binding:"omitempty,oneof=pending confirmed"`
+	Version     int64  `json:"version" binding:"omitempty,min=1"`
 }
 
 func (server *Server) updateSale(ctx *gin.Context) {
@@ -235,6 +467,11 @@ func (server *Server) updateSale(ctx *gin.Context) {
 		return
 	}
 
+	version, ok := expectedVersion(ctx, req.Version)
+	if !ok {
+		return
+	}
+
 	saleID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 	fmt.Println(saleID)
 	if err != nil {
@@ -251,6 +488,11 @@ func (server *Server) updateSale(ctx *gin.Context) {
 		return
 	}
 
+	if !checkVersion(ctx, version, existingSale.Version) {
+		return
+	}
+	previousSale := existingSale
+
 	price, err := strconv.ParseFloat(strings.Replace(req.Price, ",", ".", -1), 64)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
@@ -286,23 +528,53 @@ func (server *Server) updateSale(ctx *gin.Context) {
 		existingSale.Observation = req.Observation
 	}
 
-	arg := db.UpdateSaleParams{
-		ID:          saleID,
-		ClientID:    existingSale.ClientID,
-		ClientName:  client.FullName,
-		Product:     existingSale.Product,
-		Price:       existingSale.Price,
-		Observation: existingSale.Observation,
+	// A pending sale earns its loyalty points once it is confirmed, with the multipliers of the categories of its products
+	var earnPoints int64
+	if existingSale.Status == db.SaleStatusPending && req.Status == db.SaleStatusConfirmed {
+		categories, err := server.store.ListCategoriesBySale(ctx, saleID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		earnPoints = server.loyalty.Earned(existingSale.Price, categories)
+	}
+
+	if req.Status != "" {
+		existingSale.Status = req.Status
 	}
 
-	// Perform the update operation with the modified sale data
-	sale, err := server.store.UpdateSale(ctx, arg)
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.UpdateSaleTxParams{
+		UpdateSaleParams: db.UpdateSaleParams{
+			ID:          saleID,
+			ClientID:    existingSale.ClientID,
+			ClientName:  client.FullName,
+			Product:     existingSale.Product,
+			Price:       existingSale.Price,
+			Observation: existingSale.Observation,
+			Status:      existingSale.Status,
+			Version:     version,
+		},
+		ChangedBy:  authPayload.Username,
+		EarnPoints: earnPoints,
+	}
+
+	// Perform the update operation with the modified sale data, record the status change and credit the points
+	sale, err := server.store.UpdateSaleTx(ctx, arg)
 	if err != nil {
 		fmt.Println("error in updating sale")
+		// the version changed after the sale was read above
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(db.ErrVersionConflict))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	recordAudit(ctx, sale.ID, previousSale, sale)
+	setETag(ctx, sale.Version)
 	ctx.JSON(http.StatusOK, sale)
 }
 
@@ -317,13 +589,25 @@ func (server *Server) deleteSale(ctx *gin.Context) {
 		return
 	}
 
-	// Delete existing sale
-	err := server.store.DeleteSale(ctx, req.ID)
+	// the sale is kept for the audit log. A sale that doesn't exist is still deleted without an error,
+	// as it always was, and only its id from the url is logged
+	sale, err := server.store.GetSale(ctx, req.ID)
+	if err != nil && err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Delete existing sale and reverse its loyalty points
+	err = server.store.DeleteSaleTx(ctx, req.ID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	if sale.ID != 0 {
+		recordAudit(ctx, sale.ID, sale, nil)
+	}
+
 	ctx.JSON(http.StatusOK, "Sale deleted successfully")
 }
 
@@ -338,12 +622,30 @@ func (server *Server) deleteSales(ctx *gin.Context) {
 		return
 	}
 
-	// Delete existing sales
-	err := server.store.DeleteSales(ctx, req.IDs)
+	// the sales are kept for the audit log, the ids that don't exist are skipped like in the delete
+	sales := make([]db.Sale, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		sale, err := server.store.GetSale(ctx, int64(id))
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		sales = append(sales, sale)
+	}
+
+	// Delete existing sales and reverse their loyalty points
+	err := server.store.DeleteSalesTx(ctx, req.IDs)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	for _, sale := range sales {
+		recordAudit(ctx, sale.ID, sale, nil)
+	}
+
 	ctx.JSON(http.StatusOK, "Sales deleted successfully")
 }
@@ -0,0 +1,852 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/token"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateSaleAPI(t *testing.T) {
+	client := randomClient()
+	sale := db.Sale{
+		ID:          1,
+		ClientID:    client.ID,
+		ClientName:  client.FullName,
+		Product:     "Ração 15kg",
+		Price:       145,
+		Observation: "entregar pela manhã",
+		Status:      db.SaleStatusConfirmed,
+	}
+	product := db.Product{ID: 9, Name: sale.Product, Price: 150, CostPrice: 90}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "EarnsPoints",
+			body: gin.H{"client_id": client.ID, "product": sale.Product, "price": "150,00", "observation": sale.Observation},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().ListCategoriesByProduct(gomock.Any(), gomock.Any()).Times(0)
+				arg := db.CreateSaleTxParams{
+					CreateSaleParams: db.CreateSaleParams{
+						ClientID:    client.ID,
+						ClientName:  client.FullName,
+						Product:     sale.Product,
+						Price:       150,
+						Observation: sale.Observation,
+						Status:      db.SaleStatusConfirmed,
+					},
+					ChangedBy:  "username",
+					EarnPoints: 150,
+				}
+				store.EXPECT().CreateSaleTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CreateSaleTxResult{Sale: sale}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotSale db.Sale
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &gotSale))
+				require.Equal(t, sale, gotSale)
+			},
+		},
+		{
+			name: "RedeemsPoints",
+			body: gin.H{"client_id": client.ID, "product": sale.Product, "price": "150", "observation": sale.Observation, "product_id": 9, "redeem_points": 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Eq(int64(9))).Times(1).Return(product, nil)
+				store.EXPECT().ListCategoriesByProduct(gomock.Any(), gomock.Eq(int64(9))).Times(1).
+					Return([]db.Category{{ID: 2, Name: "Ração"}}, nil)
+				arg := db.CreateSaleTxParams{
+					CreateSaleParams: db.CreateSaleParams{
+						ClientID:    client.ID,
+						ClientName:  client.FullName,
+						Product:     sale.Product,
+						Price:       145,
+						Observation: sale.Observation,
+						Status:      db.SaleStatusConfirmed,
+					},
+					ChangedBy:    "username",
+					Items:        []db.SaleItemParams{{ProductID: 9, ProductName: product.Name, Quantity: 1, UnitPrice: 145, UnitCost: 90}},
+					RedeemPoints: 100,
+					EarnPoints:   145,
+				}
+				store.EXPECT().CreateSaleTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CreateSaleTxResult{Sale: sale}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AppliesCoupon",
+			body: gin.H{"client_id": client.ID, "product": sale.Product, "price": "150", "observation": sale.Observation, "product_id": 9, "coupon_code": "racao10"},
+			buildStubs: func(store *mockdb.MockStore) {
+				coupon := randomCoupon()
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Eq(int64(9))).Times(1).Return(product, nil)
+				store.EXPECT().ListCategoriesByProduct(gomock.Any(), gomock.Eq(int64(9))).Times(1).
+					Return([]db.Category{{ID: 3, Name: "Ração"}}, nil)
+				store.EXPECT().GetCouponByCode(gomock.Any(), gomock.Eq("RACAO10")).Times(1).Return(coupon, nil)
+				store.EXPECT().CountCouponUses(gomock.Any(), gomock.Eq(coupon.ID)).Times(1).Return(int64(0), nil)
+				store.EXPECT().CountCouponUsesByClient(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				arg := db.CreateSaleTxParams{
+					CreateSaleParams: db.CreateSaleParams{
+						ClientID:    client.ID,
+						ClientName:  client.FullName,
+						Product:     sale.Product,
+						Price:       135,
+						Observation: sale.Observation,
+						Status:      db.SaleStatusConfirmed,
+					},
+					ChangedBy: "username",
+					Items:     []db.SaleItemParams{{ProductID: 9, ProductName: product.Name, Quantity: 1, UnitPrice: 135, UnitCost: 90}},
+					Coupon: &db.SaleCouponParams{
+						CouponID:    coupon.ID,
+						Code:        coupon.Code,
+						Description: coupon.Description,
+						Amount:      15,
+					},
+					EarnPoints: 135,
+				}
+				discount := db.SaleDiscount{ID: 1, SaleID: sale.ID, CouponID: coupon.ID, Code: coupon.Code, Amount: 15}
+				store.EXPECT().CreateSaleTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.CreateSaleTxResult{Sale: sale, Discounts: []db.SaleDiscount{discount}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response saleResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Discounts, 1)
+				require.Equal(t, 15.0, response.Discounts[0].Amount)
+			},
+		},
+		{
+			name: "CouponUsageLimit",
+			body: gin.H{"client_id": client.ID, "product": sale.Product, "price": "150", "observation": sale.Observation, "coupon_code": "RACAO10"},
+			buildStubs: func(store *mockdb.MockStore) {
+				coupon := randomCoupon()
+				coupon.CategoryIds = []int64{}
+				coupon.MaxUsesPerClient = 1
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().GetCouponByCode(gomock.Any(), gomock.Any()).Times(1).Return(coupon, nil)
+				store.EXPECT().CountCouponUses(gomock.Any(), gomock.Any()).Times(1).Return(int64(3), nil)
+				store.EXPECT().CountCouponUsesByClient(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+				store.EXPECT().CreateSaleTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientPoints",
+			body: gin.H{"client_id": client.ID, "product": sale.Product, "price": "150", "observation": sale.Observation, "redeem_points": 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().CreateSaleTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CreateSaleTxResult{}, db.ErrInsufficientPoints)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DiscountGreaterThanPrice",
+			body: gin.H{"client_id": client.ID, "product": sale.Product, "price": "10", "observation": sale.Observation, "redeem_points": 1000},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				store.EXPECT().CreateSaleTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			requestBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/sales", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteSalesAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"ids": []int32{1, 2}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSale(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(db.Sale{ID: 1}, nil)
+				store.EXPECT().GetSale(gomock.Any(), gomock.Eq(int64(2))).Times(1).Return(db.Sale{}, sql.ErrNoRows)
+				store.EXPECT().DeleteSalesTx(gomock.Any(), gomock.Eq([]int32{1, 2})).Times(1).Return(nil)
+				store.EXPECT().DeleteSales(gomock.Any(), gomock.Any()).Times(0)
+				// the sale that was deleted is in the audit log
+				store.EXPECT().CreateAuditLog(gomock.Any(), auditLogFor("sale", "delete", 1)).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			body: gin.H{"ids": []int32{1, 2}},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteSalesTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			requestBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodDelete, "/sales/delete", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteSaleAPI(t *testing.T) {
+	sale := db.Sale{ID: 5, ClientID: 2, Product: "Ração 15kg", Price: 100}
+
+	testCases := []struct {
+		name          string
+		saleID        int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			saleID: sale.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSale(gomock.Any(), gomock.Eq(sale.ID)).Times(1).Return(sale, nil)
+				store.EXPECT().DeleteSaleTx(gomock.Any(), gomock.Eq(sale.ID)).Times(1).Return(nil)
+				store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateAuditLogParams) (db.AuditLog, error) {
+						require.Equal(t, sale.ID, arg.EntityID)
+						require.Contains(t, string(arg.Changes), `"product":{"before":"Ração 15kg"}`)
+						return db.AuditLog{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			// a sale that doesn't exist is deleted without an error, only its id is logged
+			name:   "NotFound",
+			saleID: 6,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSale(gomock.Any(), gomock.Eq(int64(6))).Times(1).Return(db.Sale{}, sql.ErrNoRows)
+				store.EXPECT().DeleteSaleTx(gomock.Any(), gomock.Eq(int64(6))).Times(1).Return(nil)
+				store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateAuditLogParams) (db.AuditLog, error) {
+						require.Equal(t, int64(6), arg.EntityID)
+						require.JSONEq(t, `{}`, string(arg.Changes))
+						return db.AuditLog{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			saleID: sale.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSale(gomock.Any(), gomock.Eq(sale.ID)).Times(1).Return(db.Sale{}, sql.ErrConnDone)
+				store.EXPECT().DeleteSaleTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/sales/%d", tc.saleID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListSalePageAPI(t *testing.T) {
+	sales := []db.Sale{{ID: 9, Product: "Ração 15kg"}, {ID: 8, Product: "Areia 4kg"}}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "FirstPage",
+			query: "cursor=&page_size=5&sort_field=created_at&sort_direction=desc",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SalePageParams{PageParams: db.PageParams{Limit: 5, SortField: "created_at", SortDirection: "desc"}}
+				store.EXPECT().ListSalesPage(gomock.Any(), gomock.Eq(arg)).Times(1).Return(sales, "next", nil)
+				store.EXPECT().CountSales(gomock.Any()).Times(0)
+				store.EXPECT().ListSales(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listSaleResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Nil(t, got.Total)
+				require.Equal(t, sales, got.Sales)
+				require.Equal(t, "next", got.NextCursor)
+			},
+		},
+		{
+			name:  "LastPage",
+			query: "cursor=next&page_size=5&search=racao",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SalePageParams{PageParams: db.PageParams{Cursor: "next", Limit: 5, Search: "racao"}}
+				store.EXPECT().ListSalesPage(gomock.Any(), gomock.Eq(arg)).Times(1).Return(sales[1:], "", nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), "next_cursor")
+			},
+		},
+		{
+			name:  "InvalidCursor",
+			query: "cursor=bad&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSalesPage(gomock.Any(), gomock.Any()).Times(1).Return(nil, "", db.ErrInvalidCursor)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "NoPageNorCursor",
+			query: "page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSalesPage(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListSales(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "OffsetMode",
+			query: "page_id=2&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountSales(gomock.Any()).Times(1).Return(int64(7), nil)
+				store.EXPECT().ListSales(gomock.Any(), gomock.Eq(db.ListSalesParams{Limit: 5, Offset: 5})).Times(1).Return(sales, nil)
+				store.EXPECT().ListSalesPage(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listSaleResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, int64(7), *got.Total)
+				require.Empty(t, got.NextCursor)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/sales?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListSaleSearchAPI(t *testing.T) {
+	sales := []db.Sale{{ID: 9, Product: "Ração 15kg"}}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "TotalOfTheSearch",
+			query: "page_id=1&page_size=5&search=racao",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountSales(gomock.Any()).Times(0)
+				store.EXPECT().SearchSales(gomock.Any(), "racao", 1, 5, "", "").Times(1).Return(sales, int64(1), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listSaleResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, int64(1), *got.Total)
+				require.Equal(t, sales, got.Sales)
+			},
+		},
+		{
+			name:  "InvalidSortField",
+			query: "page_id=1&page_size=5&search=racao&sort_field=id%3BDROP%20TABLE%20sale&sort_direction=asc",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchSales(gomock.Any(), "racao", 1, 5, "id;DROP TABLE sale", "asc").
+					Times(1).Return(nil, int64(0), fmt.Errorf("%w field: id;DROP TABLE sale", db.ErrInvalidSort))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/sales?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListSaleFilterAPI(t *testing.T) {
+	sales := []db.Sale{{ID: 9, ClientID: 3, Product: "Ração 15kg", Price: 150}}
+	printed := true
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "AllFilters",
+			query: "page_id=1&page_size=5&from=2024-01-01&to=2024-01-31&client_id=3&min_price=100&max_price=200&product=racao&status=confirmed&printed=true",
+			buildStubs: func(store *mockdb.MockStore) {
+				filter := db.SaleFilter{
+					From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC).Add(-time.Microsecond),
+					ClientID: 3,
+					MinPrice: 100,
+					MaxPrice: 200,
+					Product:  "racao",
+					Status:   db.SaleStatusConfirmed,
+					Printed:  &printed,
+				}
+				store.EXPECT().FilterSales(gomock.Any(), gomock.Eq(filter), 1, 5, "", "", "").Times(1).Return(sales, int64(1), nil)
+				store.EXPECT().CountSales(gomock.Any()).Times(0)
+				store.EXPECT().ListSales(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got listSaleResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, int64(1), *got.Total)
+				require.Equal(t, sales, got.Sales)
+			},
+		},
+		{
+			name:  "TimeWithSearchAndSort",
+			query: "page_id=2&page_size=5&from=2024-01-01T10:00:00Z&search=rex&sort_field=price&sort_direction=desc",
+			buildStubs: func(store *mockdb.MockStore) {
+				filter := db.SaleFilter{From: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+				store.EXPECT().FilterSales(gomock.Any(), gomock.Eq(filter), 2, 5, "price", "desc", "rex").Times(1).Return(sales, int64(6), nil)
+				store.EXPECT().SearchSales(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "CursorMode",
+			query: "cursor=&page_size=5&client_id=3",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SalePageParams{PageParams: db.PageParams{Limit: 5}, SaleFilter: db.SaleFilter{ClientID: 3}}
+				store.EXPECT().ListSalesPage(gomock.Any(), gomock.Eq(arg)).Times(1).Return(sales, "", nil)
+				store.EXPECT().FilterSales(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidDate",
+			query: "page_id=1&page_size=5&from=01/02/2024",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FilterSales(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "FromAfterTo",
+			query: "page_id=1&page_size=5&from=2024-02-01&to=2024-01-01",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FilterSales(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "MinPriceAboveMaxPrice",
+			query: "page_id=1&page_size=5&min_price=200&max_price=100",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FilterSales(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: "page_id=1&page_size=5&status=shipped",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FilterSales(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/sales?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeprecatedSaleEndpointsAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSalesByClientID(gomock.Any(), int64(3)).Times(1).Return([]db.Sale{{ID: 9}}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/sales/by_client/3", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "true", recorder.Header().Get("Deprecation"))
+	require.Contains(t, recorder.Header().Get("Link"), "</sales>")
+}
+
+func TestUpdateSaleVersionAPI(t *testing.T) {
+	client := randomClient()
+	sale := db.Sale{ID: 5, ClientID: client.ID, ClientName: client.FullName, Product: "Ração", Price: 100, Status: db.SaleStatusConfirmed, Version: 2}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		ifMatch       string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			body:    gin.H{"client_id": client.ID, "price": "120"},
+			ifMatch: `W/"2"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSale(gomock.Any(), sale.ID).Times(1).Return(sale, nil)
+				store.EXPECT().GetClient(gomock.Any(), client.ID).Times(1).Return(client, nil)
+				store.EXPECT().UpdateSaleTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdateSaleTxParams) (db.Sale, error) {
+						require.Equal(t, int64(2), arg.Version)
+						require.Equal(t, "username", arg.ChangedBy)
+						updated := sale
+						updated.Price = arg.Price
+						updated.Version = 3
+						return updated, nil
+					})
+				// the version is left out of the changes
+				store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateAuditLogParams) (db.AuditLog, error) {
+						require.Equal(t, "sale", arg.Entity)
+						require.Equal(t, "update", arg.Action)
+						require.Equal(t, sale.ID, arg.EntityID)
+						require.Equal(t, "username", arg.Actor)
+						require.JSONEq(t, `{"price": {"before": 100, "after": 120}}`, string(arg.Changes))
+						return db.AuditLog{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `"3"`, recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name: "StaleVersion",
+			body: gin.H{"client_id": client.ID, "price": "120", "version": 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSale(gomock.Any(), sale.ID).Times(1).Return(sale, nil)
+				store.EXPECT().UpdateSaleTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "ChangedMeanwhile",
+			body: gin.H{"client_id": client.ID, "price": "120", "version": 2},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSale(gomock.Any(), sale.ID).Times(1).Return(sale, nil)
+				store.EXPECT().GetClient(gomock.Any(), client.ID).Times(1).Return(client, nil)
+				store.EXPECT().UpdateSaleTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Sale{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:    "DifferentVersions",
+			body:    gin.H{"client_id": client.ID, "price": "120", "version": 1},
+			ifMatch: `"2"`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSale(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "VersionRequired",
+			body: gin.H{"client_id": client.ID, "price": "120"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSale(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/sales/%d", sale.ID), bytes.NewReader(body))
+			require.NoError(t, err)
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestConfirmSaleAPI(t *testing.T) {
+	client := randomClient()
+	pending := db.Sale{ID: 5, ClientID: client.ID, ClientName: client.FullName, Product: "Ração", Price: 100, Status: db.SaleStatusPending, Version: 2}
+
+	testCases := []struct {
+		name       string
+		sale       db.Sale
+		status     string
+		buildStubs func(store *mockdb.MockStore, sale db.Sale)
+	}{
+		{
+			name:   "EarnsPointsWithCategories",
+			sale:   pending,
+			status: db.SaleStatusConfirmed,
+			buildStubs: func(store *mockdb.MockStore, sale db.Sale) {
+				store.EXPECT().ListCategoriesBySale(gomock.Any(), gomock.Eq(sale.ID)).Times(1).
+					Return([]db.Category{{ID: 2, Name: "Ração"}}, nil)
+				store.EXPECT().UpdateSaleTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdateSaleTxParams) (db.Sale, error) {
+						require.Equal(t, db.SaleStatusConfirmed, arg.Status)
+						require.Equal(t, int64(200), arg.EarnPoints)
+						updated := sale
+						updated.Status = arg.Status
+						updated.Version = 3
+						return updated, nil
+					})
+			},
+		},
+		{
+			name:   "KeepsPending",
+			sale:   pending,
+			status: db.SaleStatusPending,
+			buildStubs: func(store *mockdb.MockStore, sale db.Sale) {
+				store.EXPECT().ListCategoriesBySale(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateSaleTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdateSaleTxParams) (db.Sale, error) {
+						require.Zero(t, arg.EarnPoints)
+						return sale, nil
+					})
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetSale(gomock.Any(), tc.sale.ID).Times(1).Return(tc.sale, nil)
+			store.EXPECT().GetClient(gomock.Any(), client.ID).Times(1).Return(client, nil)
+			tc.buildStubs(store, tc.sale)
+
+			server := newTestServer(t, store)
+			server.loyalty.CategoryMultipliers["ração"] = 2
+			recorder := httptest.NewRecorder()
+
+			body, err := json.Marshal(gin.H{"client_id": client.ID, "price": "100", "status": tc.status, "version": tc.sale.Version})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/sales/%d", tc.sale.ID), bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+		})
+	}
+}
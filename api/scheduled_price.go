@@ -0,0 +1,159 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/pricing"
+	"super-pet-delivery/token"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type scheduledPriceUri struct {
+	ProductID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type createScheduledPriceRequest struct {
+	Price    float64   `json:"price" binding:"required,gt=0"`
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	// optional, without it the new price is permanent
+	EndsAt time.Time `json:"ends_at"`
+}
+
+func (server *Server) createScheduledPrice(ctx *gin.Context) {
+	var uri scheduledPriceUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req createScheduledPriceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !req.EndsAt.IsZero() && !req.EndsAt.After(req.StartsAt) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("ends_at must be after starts_at")))
+		return
+	}
+	if !req.EndsAt.IsZero() && !req.EndsAt.After(time.Now()) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("ends_at must be in the future")))
+		return
+	}
+
+	_, err := server.store.GetProduct(ctx, uri.ProductID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	existing, err := server.store.ListScheduledPricesByProduct(ctx, uri.ProductID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err := pricing.CheckOverlap(existing, req.StartsAt, req.EndsAt); err != nil {
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateScheduledPriceParams{
+		ProductID: uri.ProductID,
+		Price:     req.Price,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+	}
+
+	scheduledPrice, err := server.store.CreateScheduledPrice(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, scheduledPrice.ID, nil, scheduledPrice)
+	ctx.JSON(http.StatusOK, scheduledPrice)
+}
+
+func (server *Server) listScheduledPrices(ctx *gin.Context) {
+	var uri scheduledPriceUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	scheduledPrices, err := server.store.ListScheduledPricesByProduct(ctx, uri.ProductID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scheduledPrices)
+}
+
+type singleScheduledPriceUri struct {
+	ProductID int64 `uri:"id" binding:"required,min=1"`
+	PriceID   int64 `uri:"price_id" binding:"required,min=1"`
+}
+
+// cancelScheduledPrice cancels an entry that did not start yet or ends an active promotion right away,
+// restoring the prices the product had before it
+func (server *Server) cancelScheduledPrice(ctx *gin.Context) {
+	var uri singleScheduledPriceUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	scheduledPrice, err := server.store.GetScheduledPrice(ctx, uri.PriceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if scheduledPrice.ProductID != uri.ProductID {
+		err := fmt.Errorf("scheduled price %d does not belong to product %d", uri.PriceID, uri.ProductID)
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.store.FinishScheduledPriceTx(ctx, db.FinishScheduledPriceTxParams{
+		ID:        uri.PriceID,
+		Status:    db.ScheduledPriceCancelled,
+		ChangedBy: authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if result.Skipped {
+		err := fmt.Errorf("scheduled price %d is already %s", uri.PriceID, result.ScheduledPrice.Status)
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+
+	if result.PriceKept {
+		log.Printf("scheduled price %d cancelled without restoring the prices of product %d, its price changed to %.2f while it was active",
+			uri.PriceID, result.Product.ID, result.Product.Price)
+	}
+
+	recordAudit(ctx, scheduledPrice.ID, scheduledPrice, result.ScheduledPrice)
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/token"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateScheduledPriceAPI(t *testing.T) {
+	product := randomProduct()
+	startsAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	endsAt := startsAt.Add(7 * 24 * time.Hour)
+
+	scheduledPrice := db.ScheduledPrice{
+		ID:        util.RandomInt(1, 1000),
+		ProductID: product.ID,
+		Price:     49.9,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		Status:    db.ScheduledPriceScheduled,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"price": scheduledPrice.Price, "starts_at": startsAt, "ends_at": endsAt},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Eq(product.ID)).Times(1).Return(product, nil)
+				store.EXPECT().ListScheduledPricesByProduct(gomock.Any(), gomock.Eq(product.ID)).Times(1).Return([]db.ScheduledPrice{}, nil)
+				arg := db.CreateScheduledPriceParams{
+					ProductID: product.ID,
+					Price:     scheduledPrice.Price,
+					StartsAt:  startsAt,
+					EndsAt:    endsAt,
+				}
+				store.EXPECT().CreateScheduledPrice(gomock.Any(), gomock.Eq(arg)).Times(1).Return(scheduledPrice, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var got db.ScheduledPrice
+				require.NoError(t, json.Unmarshal(data, &got))
+				require.Equal(t, scheduledPrice.ID, got.ID)
+				require.Equal(t, db.ScheduledPriceScheduled, got.Status)
+			},
+		},
+		{
+			name: "Overlap",
+			body: gin.H{"price": scheduledPrice.Price, "starts_at": startsAt, "ends_at": endsAt},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Eq(product.ID)).Times(1).Return(product, nil)
+				store.EXPECT().ListScheduledPricesByProduct(gomock.Any(), gomock.Eq(product.ID)).Times(1).Return([]db.ScheduledPrice{scheduledPrice}, nil)
+				store.EXPECT().CreateScheduledPrice(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "EndsBeforeStart",
+			body: gin.H{"price": scheduledPrice.Price, "starts_at": endsAt, "ends_at": startsAt},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateScheduledPrice(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ProductNotFound",
+			body: gin.H{"price": scheduledPrice.Price, "starts_at": startsAt},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Eq(product.ID)).Times(1).Return(db.Product{}, sql.ErrNoRows)
+				store.EXPECT().CreateScheduledPrice(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			body: gin.H{"price": scheduledPrice.Price, "starts_at": startsAt},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateScheduledPrice(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			requestBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/products/%d/scheduled_prices", product.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCancelScheduledPriceAPI(t *testing.T) {
+	product := randomProduct()
+	scheduledPrice := db.ScheduledPrice{
+		ID:        util.RandomInt(1, 1000),
+		ProductID: product.ID,
+		Price:     49.9,
+		Status:    db.ScheduledPriceActive,
+	}
+
+	testCases := []struct {
+		name          string
+		productID     int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			productID: product.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledPrice(gomock.Any(), gomock.Eq(scheduledPrice.ID)).Times(1).Return(scheduledPrice, nil)
+				cancelled := scheduledPrice
+				cancelled.Status = db.ScheduledPriceCancelled
+				store.EXPECT().FinishScheduledPriceTx(gomock.Any(), gomock.Eq(db.FinishScheduledPriceTxParams{
+					ID:        scheduledPrice.ID,
+					Status:    db.ScheduledPriceCancelled,
+					ChangedBy: "username",
+				})).Times(1).
+					Return(db.ScheduledPriceTxResult{ScheduledPrice: cancelled, Product: product}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "OtherProduct",
+			productID: product.ID + 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledPrice(gomock.Any(), gomock.Eq(scheduledPrice.ID)).Times(1).Return(scheduledPrice, nil)
+				store.EXPECT().FinishScheduledPriceTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "AlreadyFinished",
+			productID: product.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				finished := scheduledPrice
+				finished.Status = db.ScheduledPriceFinished
+				store.EXPECT().GetScheduledPrice(gomock.Any(), gomock.Eq(scheduledPrice.ID)).Times(1).Return(finished, nil)
+				store.EXPECT().FinishScheduledPriceTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ScheduledPriceTxResult{ScheduledPrice: finished, Skipped: true}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/products/%d/scheduled_prices/%d", tc.productID, scheduledPrice.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	searchHitClient  = "client"
+	searchHitSale    = "sale"
+	searchHitProduct = "product"
+	searchHitImage   = "image"
+)
+
+// defaultSearchTimeout is used when SEARCH_TIMEOUT isn't configured
+const defaultSearchTimeout = 3 * time.Second
+
+// snippetLength is the maximum number of characters of a search hit snippet
+const snippetLength = 120
+
+type searchHit struct {
+	Type    string `json:"type"`
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Snippet string `json:"snippet"`
+}
+
+type searchResponse struct {
+	Clients  []searchHit `json:"clients"`
+	Sales    []searchHit `json:"sales"`
+	Products []searchHit `json:"products"`
+	Images   []searchHit `json:"images"`
+	// Errors has the error of each group whose search failed, by the name of the group
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+type searchRequest struct {
+	Query string `form:"q" binding:"required"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+// search looks for the query in the clients, sales, products and images at the same time
+// and returns the top hits of each one. All the queries share the same timeout.
+// A group whose search failed comes back empty with its error in errors, the response
+// is only an error when every group failed.
+func (server *Server) search(ctx *gin.Context) {
+	var req searchRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+
+	timeout := server.config.SearchTimeout
+	if timeout <= 0 {
+		timeout = defaultSearchTimeout
+	}
+	searchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	response := searchResponse{}
+	searches := []struct {
+		group string
+		hits  *[]searchHit
+		find  func() ([]searchHit, error)
+	}{
+		{"clients", &response.Clients, func() ([]searchHit, error) { return server.searchClients(searchCtx, req.Query, req.Limit) }},
+		{"sales", &response.Sales, func() ([]searchHit, error) { return server.searchSales(searchCtx, req.Query, req.Limit) }},
+		{"products", &response.Products, func() ([]searchHit, error) { return server.searchProducts(searchCtx, req.Query, req.Limit) }},
+		{"images", &response.Images, func() ([]searchHit, error) { return server.searchImages(searchCtx, req.Query, req.Limit) }},
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(searches))
+	for i := range searches {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			*searches[i].hits, errs[i] = searches[i].find()
+		}(i)
+	}
+	wg.Wait()
+
+	timedOut := fmt.Errorf("search took longer than %s", timeout)
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			errs[i] = timedOut
+		}
+		if response.Errors == nil {
+			response.Errors = make(map[string]string)
+		}
+		response.Errors[searches[i].group] = errs[i].Error()
+		*searches[i].hits = []searchHit{}
+	}
+
+	if len(response.Errors) == len(searches) {
+		if errors.Is(searchCtx.Err(), context.DeadlineExceeded) {
+			ctx.JSON(http.StatusGatewayTimeout, errorResponse(timedOut))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(errors.Join(errs...)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (server *Server) searchClients(ctx context.Context, query string, limit int) ([]searchHit, error) {
+	clients, err := server.store.FindClients(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]searchHit, 0, len(clients))
+	for _, client := range clients {
+		hits = append(hits, searchHit{
+			Type:    searchHitClient,
+			ID:      client.ID,
+			Title:   client.FullName,
+			Snippet: snippet(client.PetName, client.PhoneWhatsapp, client.AddressStreet+" "+client.AddressNumber, client.AddressNeighborhood),
+		})
+	}
+	return hits, nil
+}
+
+func (server *Server) searchSales(ctx context.Context, query string, limit int) ([]searchHit, error) {
+	sales, err := server.store.FindSales(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]searchHit, 0, len(sales))
+	for _, sale := range sales {
+		hits = append(hits, searchHit{
+			Type:    searchHitSale,
+			ID:      sale.ID,
+			Title:   fmt.Sprintf("#%d %s", sale.ID, sale.ClientName),
+			Snippet: snippet(sale.Product, fmt.Sprintf("R$ %.2f", sale.Price), sale.Observation),
+		})
+	}
+	return hits, nil
+}
+
+// searchProducts includes the drafts and archived products, only the admins use the search
+func (server *Server) searchProducts(ctx context.Context, query string, limit int) ([]searchHit, error) {
+	products, err := server.store.FindProducts(ctx, query, limit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]searchHit, 0, len(products))
+	for _, product := range products {
+		hits = append(hits, searchHit{
+			Type:    searchHitProduct,
+			ID:      product.ID,
+			Title:   product.Name,
+			Snippet: snippet(product.Sku, product.Description),
+		})
+	}
+	return hits, nil
+}
+
+func (server *Server) searchImages(ctx context.Context, query string, limit int) ([]searchHit, error) {
+	images, err := server.store.FindImages(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]searchHit, 0, len(images))
+	for _, image := range images {
+		hits = append(hits, searchHit{
+			Type:    searchHitImage,
+			ID:      image.ID,
+			Title:   image.Name,
+			Snippet: snippet(image.Alt, image.ImagePath),
+		})
+	}
+	return hits, nil
+}
+
+// snippet joins the non empty parts and cuts the text to snippetLength characters
+func snippet(parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+
+	text := []rune(strings.Join(kept, " · "))
+	if len(text) > snippetLength {
+		return strings.TrimSpace(string(text[:snippetLength])) + "…"
+	}
+	return string(text)
+}
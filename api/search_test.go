@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchAPI(t *testing.T) {
+	client := randomClient()
+	sale := db.Sale{ID: 7, ClientName: client.FullName, Product: "Ração Golden 15kg", Price: 189.9}
+	product := randomProduct()
+	image := db.Image{ID: 3, Name: "golden", Alt: "Ração Golden", ImagePath: "/media/2023/10/golden.jpg"}
+
+	testCases := []struct {
+		name          string
+		query         string
+		timeout       time.Duration
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "q=golden",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FindClients(gomock.Any(), "golden", 5).Times(1).Return([]db.Client{client}, nil)
+				store.EXPECT().FindSales(gomock.Any(), "golden", 5).Times(1).Return([]db.Sale{sale}, nil)
+				store.EXPECT().FindProducts(gomock.Any(), "golden", 5, false).Times(1).Return([]db.Product{product}, nil)
+				store.EXPECT().FindImages(gomock.Any(), "golden", 5).Times(1).Return([]db.Image{image}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got searchResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, []searchHit{{Type: searchHitClient, ID: client.ID, Title: client.FullName,
+					Snippet: snippet(client.PetName, client.PhoneWhatsapp, client.AddressStreet+" "+client.AddressNumber, client.AddressNeighborhood)}}, got.Clients)
+				require.Equal(t, []searchHit{{Type: searchHitSale, ID: 7, Title: "#7 " + client.FullName, Snippet: "Ração Golden 15kg · R$ 189.90"}}, got.Sales)
+				require.Len(t, got.Products, 1)
+				require.Equal(t, product.ID, got.Products[0].ID)
+				require.Equal(t, []searchHit{{Type: searchHitImage, ID: 3, Title: "golden", Snippet: "Ração Golden · /media/2023/10/golden.jpg"}}, got.Images)
+			},
+		},
+		{
+			name:  "Limit",
+			query: "q=golden&limit=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FindClients(gomock.Any(), "golden", 2).Times(1).Return([]db.Client{}, nil)
+				store.EXPECT().FindSales(gomock.Any(), "golden", 2).Times(1).Return([]db.Sale{}, nil)
+				store.EXPECT().FindProducts(gomock.Any(), "golden", 2, false).Times(1).Return([]db.Product{}, nil)
+				store.EXPECT().FindImages(gomock.Any(), "golden", 2).Times(1).Return([]db.Image{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"clients":[],"sales":[],"products":[],"images":[]}`, recorder.Body.String())
+			},
+		},
+		{
+			name:  "MissingQuery",
+			query: "limit=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FindClients(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "Timeout",
+			query:   "q=golden",
+			timeout: 20 * time.Millisecond,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FindClients(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]db.Client{}, nil)
+				store.EXPECT().FindSales(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(ctx context.Context, _ string, _ int) ([]db.Sale, error) {
+						<-ctx.Done()
+						return nil, ctx.Err()
+					})
+				store.EXPECT().FindProducts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]db.Product{}, nil)
+				store.EXPECT().FindImages(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]db.Image{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"clients":[],"sales":[],"products":[],"images":[],"errors":{"sales":"search took longer than 20ms"}}`, recorder.Body.String())
+			},
+		},
+		{
+			name:  "GroupFailed",
+			query: "q=golden",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FindClients(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+				store.EXPECT().FindSales(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]db.Sale{sale}, nil)
+				store.EXPECT().FindProducts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]db.Product{}, nil)
+				store.EXPECT().FindImages(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]db.Image{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got searchResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, []searchHit{}, got.Clients)
+				require.Equal(t, []searchHit{{Type: searchHitSale, ID: 7, Title: "#7 " + client.FullName, Snippet: "Ração Golden 15kg · R$ 189.90"}}, got.Sales)
+				require.Equal(t, map[string]string{"clients": sql.ErrConnDone.Error()}, got.Errors)
+			},
+		},
+		{
+			name:  "AllFailed",
+			query: "q=golden",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().FindClients(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+				store.EXPECT().FindSales(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+				store.EXPECT().FindProducts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+				store.EXPECT().FindImages(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.config.SearchTimeout = tc.timeout
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/search?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	require.Equal(t, "Rex · 11999999999", snippet("Rex", "", " 11999999999 "))
+
+	long := snippet(strings.Repeat("ração", 40))
+	require.Len(t, []rune(long), snippetLength+1)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/loyalty"
+	"super-pet-delivery/notification"
 	"super-pet-delivery/token"
 	"super-pet-delivery/util"
 
@@ -17,20 +19,29 @@ type Server struct {
 	config     util.Config
 	store      db.SortableStore
 	tokenMaker token.Maker
+	notifier   *notification.Dispatcher
+	loyalty    loyalty.Rules
 	router     *gin.Engine
 }
 
 // NewServer creates a new HTTP server and set up routing.
-func NewServer(config util.Config, store db.SortableStore) (*Server, error) {
+func NewServer(config util.Config, store db.SortableStore, notifier *notification.Dispatcher) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	loyaltyRules, err := loyalty.RulesFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load loyalty rules: %w", err)
+	}
+
 	server := &Server{
 		config:     config,
 		store:      store,
 		tokenMaker: tokenMaker,
+		notifier:   notifier,
+		loyalty:    loyaltyRules,
 	}
 
 	// Create the initial user
@@ -71,6 +82,8 @@ func (server *Server) setupRouter() {
 	config := cors.DefaultConfig()
 	router.Use(CORSMiddleware())
 	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	// routes open to everyone that show more to the logged in users
+	publicRoutes := router.Group("/").Use(optionalAuthMiddleware(server.tokenMaker))
 
 	config.AllowOrigins = []string{"http://localhost:3000"}
 	config.AllowHeaders = []string{"Authorization", "Cookie"}
@@ -83,74 +96,136 @@ func (server *Server) setupRouter() {
 	authRoutes.POST("/tokens/renew_access", server.RenewAccessTokenHeader)
 	authRoutes.POST("/users/logout", server.logoutUser)
 
-	authRoutes.POST("/users", server.createUser)
+	authRoutes.POST("/users", server.audit("user", "create"), server.createUser)
 	authRoutes.GET("/users/:id", server.getUser)
 	authRoutes.GET("/current_user", server.GetLoggedInUser)
 	authRoutes.GET("/users", server.listUser)
-	authRoutes.PUT("/users/:id", server.updateUser)
-	authRoutes.DELETE("/users/:id", server.deleteUser)
+	authRoutes.PUT("/users/:id", server.audit("user", "update"), server.updateUser)
+	authRoutes.DELETE("/users/:id", server.audit("user", "delete"), server.deleteUser)
 
-	authRoutes.POST("/products", server.createProduct)
-	router.GET("/product/:url", server.getProductByURL)
-	router.GET("/products/:id", server.getProduct)
-	router.GET("/products", server.listProduct)
+	authRoutes.POST("/products", server.audit("product", "create"), server.createProduct)
+	publicRoutes.GET("/product/:url", server.getProductByURL)
+	publicRoutes.GET("/products/:id", server.getProduct)
+	publicRoutes.GET("/products", server.listProduct)
 	// ideally would be paginated as well but for now its good enough
-	router.GET("/users/:id/products", server.listProductsByUser)
-	authRoutes.PUT("/products/:id", server.updateProduct)
-	authRoutes.DELETE("/products/:id", server.deleteProduct)
-
-	authRoutes.POST("/categories", server.createCategory)
+	publicRoutes.GET("/users/:id/products", server.listProductsByUser)
+	authRoutes.PUT("/products/:id", server.audit("product", "update"), server.updateProduct)
+	authRoutes.DELETE("/products/:id", server.audit("product", "delete"), server.deleteProduct)
+	authRoutes.POST("/products/:id/restore", server.audit("product", "restore"), server.restoreProduct)
+	authRoutes.POST("/products/:id/scheduled_prices", server.audit("scheduled_price", "create"), server.createScheduledPrice)
+	authRoutes.GET("/products/:id/scheduled_prices", server.listScheduledPrices)
+	authRoutes.DELETE("/products/:id/scheduled_prices/:price_id", server.audit("scheduled_price", "cancel"), server.cancelScheduledPrice)
+	authRoutes.GET("/products/:id/price_history", server.listProductPriceHistory)
+	authRoutes.GET("/products/:id/price_at", server.getProductPriceAt)
+	authRoutes.GET("/product_prices", server.listProductPricesAt)
+
+	authRoutes.POST("/categories", server.audit("category", "create"), server.createCategory)
+	router.GET("/category/:slug", server.getCategoryBySlug)
+	router.GET("/categories/tree", server.getCategoryTree)
 	router.GET("/categories/:id", server.getCategory)
 	router.GET("/categories", server.listCategory)
-	authRoutes.PUT("/categories/:id", server.updateCategory)
-	authRoutes.DELETE("/categories/:id", server.deleteCategory)
+	authRoutes.PUT("/categories/:id", server.audit("category", "update"), server.updateCategory)
+	authRoutes.DELETE("/categories/:id", server.audit("category", "delete"), server.deleteCategory)
 
-	authRoutes.POST("/link_categories/:category_id/:product_id", server.associateCategoryWithProduct)
-	authRoutes.POST("/link_categories/multiple/:product_id", server.associateMultipleCategoriesWithProduct)
-	authRoutes.DELETE("/link_categories/:category_id/:product_id", server.disassociateCategoryWithProduct)
-	authRoutes.DELETE("/link_categories/multiple/:product_id", server.disassociateMultipleCategoriesWithProduct)
+	authRoutes.POST("/link_categories/:category_id/:product_id", server.audit("product", "link_category"), server.associateCategoryWithProduct)
+	authRoutes.POST("/link_categories/multiple/:product_id", server.audit("product", "link_categories"), server.associateMultipleCategoriesWithProduct)
+	authRoutes.DELETE("/link_categories/:category_id/:product_id", server.audit("product", "unlink_category"), server.disassociateCategoryWithProduct)
+	authRoutes.DELETE("/link_categories/multiple/:product_id", server.audit("product", "unlink_categories"), server.disassociateMultipleCategoriesWithProduct)
 	router.GET("/categories/by_product/:product_id", server.listProductCategories)
 
-	authRoutes.POST("/clients", server.createClient)
+	authRoutes.POST("/brands", server.audit("brand", "create"), server.createBrand)
+	router.GET("/brands", server.listBrand)
+	router.GET("/brands/:id", server.getBrand)
+	authRoutes.PUT("/brands/:id", server.audit("brand", "update"), server.updateBrand)
+	authRoutes.DELETE("/brands/:id", server.audit("brand", "delete"), server.deleteBrand)
+
+	authRoutes.POST("/clients", server.audit("client", "create"), server.createClient)
 	authRoutes.GET("/clients/:id", server.getClient)
 	authRoutes.GET("/clients", server.listClient)
-	authRoutes.PUT("/clients/:id", server.updateClient)
-	authRoutes.DELETE("/clients/:id", server.deleteClient)
+	authRoutes.PUT("/clients/:id", server.audit("client", "update"), server.updateClient)
+	authRoutes.DELETE("/clients/:id", server.audit("client", "delete"), server.deleteClient)
+	authRoutes.GET("/clients/:id/timeline", server.getClientTimeline)
+	authRoutes.GET("/clients/:id/loyalty", server.getClientLoyalty)
+
+	authRoutes.POST("/clients/:id/notes", server.audit("client_note", "create"), server.createClientNote)
+	authRoutes.GET("/clients/:id/notes", server.listClientNotes)
+	authRoutes.PUT("/clients/:id/notes/:note_id", server.audit("client_note", "update"), server.updateClientNote)
+	authRoutes.DELETE("/clients/:id/notes/:note_id", server.audit("client_note", "delete"), server.deleteClientNote)
 
-	authRoutes.POST("/sales", server.createSale)
+	authRoutes.POST("/sales", server.audit("sale", "create"), server.createSale)
 	authRoutes.GET("/sales/:id", server.getSale)
 	authRoutes.GET("/sales", server.listSale)
 	authRoutes.GET("/sales/all", server.listAllSales)
 	authRoutes.POST("/sales/by_date", server.GetSalesByDate)
 	authRoutes.GET("/sales/by_client/:client_id", server.GetSalesByClientID)
-	authRoutes.PUT("/sales/:id", server.updateSale)
-	authRoutes.DELETE("/sales/:id", server.deleteSale)
-	authRoutes.DELETE("/sales/delete", server.deleteSales)
+	authRoutes.PUT("/sales/:id", server.audit("sale", "update"), server.updateSale)
+	authRoutes.DELETE("/sales/:id", server.audit("sale", "delete"), server.deleteSale)
+	authRoutes.DELETE("/sales/delete", server.audit("sale", "delete"), server.deleteSales)
+	authRoutes.POST("/sales/:id/out_for_delivery", server.audit("sale", "out_for_delivery"), server.saleOutForDelivery)
+	authRoutes.GET("/sales/:id/notifications", server.listSaleNotifications)
+
+	authRoutes.POST("/subscriptions", server.audit("subscription", "create"), server.createSubscription)
+	authRoutes.GET("/subscriptions", server.listSubscription)
+	authRoutes.GET("/subscriptions/upcoming", server.listUpcomingOrders)
+	authRoutes.GET("/subscriptions/:id", server.getSubscription)
+	authRoutes.PUT("/subscriptions/:id", server.audit("subscription", "update"), server.updateSubscription)
+	authRoutes.DELETE("/subscriptions/:id", server.audit("subscription", "delete"), server.deleteSubscription)
+	authRoutes.GET("/subscriptions/:id/runs", server.listSubscriptionRuns)
+
+	authRoutes.GET("/reminders/due", server.listDueReminders)
+
+	authRoutes.POST("/coupons", server.audit("coupon", "create"), server.createCoupon)
+	authRoutes.GET("/coupons", server.listCoupon)
+	authRoutes.POST("/coupons/validate", server.validateCoupon)
+	authRoutes.GET("/coupons/:id", server.getCoupon)
+	authRoutes.PUT("/coupons/:id", server.audit("coupon", "update"), server.updateCoupon)
+	authRoutes.DELETE("/coupons/:id", server.audit("coupon", "deactivate"), server.deactivateCoupon)
+
+	authRoutes.POST("/suppliers", server.audit("supplier", "create"), server.createSupplier)
+	authRoutes.GET("/suppliers", server.listSupplier)
+	authRoutes.GET("/suppliers/:id", server.getSupplier)
+	authRoutes.PUT("/suppliers/:id", server.audit("supplier", "update"), server.updateSupplier)
+	authRoutes.DELETE("/suppliers/:id", server.audit("supplier", "delete"), server.deleteSupplier)
+
+	authRoutes.POST("/purchase_orders", server.audit("purchase_order", "create"), server.createPurchaseOrder)
+	authRoutes.GET("/purchase_orders", server.listPurchaseOrder)
+	authRoutes.GET("/purchase_orders/:id", server.getPurchaseOrder)
+	authRoutes.PUT("/purchase_orders/:id", server.audit("purchase_order", "update"), server.updatePurchaseOrder)
+	authRoutes.DELETE("/purchase_orders/:id", server.audit("purchase_order", "delete"), server.deletePurchaseOrder)
+	authRoutes.POST("/purchase_orders/:id/send", server.audit("purchase_order", "send"), server.sendPurchaseOrder)
+	authRoutes.POST("/purchase_orders/:id/receive", server.audit("purchase_order", "receive"), server.receivePurchaseOrder)
+	authRoutes.GET("/purchase_orders/:id/pdf", server.createPurchaseOrderPdf)
+
+	authRoutes.GET("/reports/margin", server.getMarginReport)
+
+	authRoutes.GET("/search", server.search)
+
+	authRoutes.GET("/audit", server.listAuditLog)
 
 	authRoutes.POST("/pdf/", server.createPdf)
 	//authRoutes.GET("/pdf/", server.getPdf)
 
-	authRoutes.POST("/images", server.createImage)
+	authRoutes.POST("/images", server.audit("image", "create"), server.createImage)
 	router.GET("/images/:id", server.getImage)
 	router.POST("/images-multiple", server.getImages)
 	router.GET("/media/:year/:month/:filename", server.getImagePath)
 	authRoutes.GET("/images", server.listImage)
-	authRoutes.PUT("/images/:id", server.updateImage)
-	authRoutes.DELETE("/images/:id", server.deleteImage)
+	authRoutes.PUT("/images/:id", server.audit("image", "update"), server.updateImage)
+	authRoutes.DELETE("/images/:id", server.audit("image", "delete"), server.deleteImage)
 
-	authRoutes.POST("/link_images/:image_id/:product_id", server.associateImageWithProduct)
-	authRoutes.POST("/link_images/multiple/:product_id", server.associateMultipleImagesWithProduct)
-	authRoutes.DELETE("/link_images/:image_id/:product_id", server.disassociateImageWithProduct)
-	authRoutes.DELETE("/link_images/multiple/:product_id", server.disassociateMultipleImagesWithProduct)
+	authRoutes.POST("/link_images/:image_id/:product_id", server.audit("product", "link_image"), server.associateImageWithProduct)
+	authRoutes.POST("/link_images/multiple/:product_id", server.audit("product", "link_images"), server.associateMultipleImagesWithProduct)
+	authRoutes.DELETE("/link_images/:image_id/:product_id", server.audit("product", "unlink_image"), server.disassociateImageWithProduct)
+	authRoutes.DELETE("/link_images/multiple/:product_id", server.audit("product", "unlink_images"), server.disassociateMultipleImagesWithProduct)
 	router.GET("/images/by_product/:product_id", server.listProductImages)
-	authRoutes.PUT("/images/by_product/:product_id", server.editImageOrder)
+	authRoutes.PUT("/images/by_product/:product_id", server.audit("product", "reorder_images"), server.editImageOrder)
 
-	authRoutes.POST("/slider_images", server.CreateSliderImage)
+	authRoutes.POST("/slider_images", server.audit("slider_image", "create"), server.CreateSliderImage)
 	router.GET("/slider_images", server.ListSliderImages)
-	authRoutes.POST("/slider_images/update", server.UpdateSliderImage)
-	authRoutes.POST("/slider_images/update_by_image_id", server.UpdateSliderImageByImageId)
-	authRoutes.POST("/slider_images/delete", server.DeleteSliderImages)
-	authRoutes.POST("/slider_images/delete_by_image_id", server.DeleteSliderImagesByImageId)
+	authRoutes.POST("/slider_images/update", server.audit("slider_image", "update"), server.UpdateSliderImage)
+	authRoutes.POST("/slider_images/update_by_image_id", server.audit("slider_image", "update"), server.UpdateSliderImageByImageId)
+	authRoutes.POST("/slider_images/delete", server.audit("slider_image", "delete"), server.DeleteSliderImages)
+	authRoutes.POST("/slider_images/delete_by_image_id", server.audit("slider_image", "delete"), server.DeleteSliderImagesByImageId)
 
 	router.POST("/contact", server.HandleForm)
 
@@ -0,0 +1,370 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/subscription"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const subscriptionDateLayout = "2006-01-02"
+
+type subscriptionItemRequest struct {
+	Product  string  `json:"product" binding:"required"`
+	Quantity int32   `json:"quantity" binding:"required,min=1"`
+	Price    float64 `json:"price" binding:"min=0"`
+}
+
+type subscriptionResponse struct {
+	db.Subscription
+	Items []db.SubscriptionItem `json:"items"`
+}
+
+func newSubscriptionResponse(result db.SubscriptionTxResult) subscriptionResponse {
+	return subscriptionResponse{
+		Subscription: result.Subscription,
+		Items:        result.Items,
+	}
+}
+
+func subscriptionItemParams(items []subscriptionItemRequest) []db.SubscriptionItemParams {
+	params := make([]db.SubscriptionItemParams, len(items))
+	for i, item := range items {
+		params[i] = db.SubscriptionItemParams{
+			Product:  item.Product,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+		}
+	}
+	return params
+}
+
+// parseSubscriptionDate parses a YYYY-MM-DD date, defaulting to today when empty
+func parseSubscriptionDate(value string) (time.Time, error) {
+	if value == "" {
+		return subscription.Date(time.Now()), nil
+	}
+	date, err := time.Parse(subscriptionDateLayout, value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date, expected YYYY-MM-DD")
+	}
+	return date, nil
+}
+
+type createSubscriptionRequest struct {
+	ClientID     int64                     `json:"client_id" binding:"required,min=1"`
+	IntervalDays int32                     `json:"interval_days" binding:"required,min=1"`
+	StartDate    string                    `json:"start_date"`
+	Observation  string                    `json:"observation"`
+	Items        []subscriptionItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+func (server *Server) createSubscription(ctx *gin.Context) {
+	var req createSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	startDate, err := parseSubscriptionDate(req.StartDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.GetClient(ctx, req.ClientID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateSubscriptionTxParams{
+		CreateSubscriptionParams: db.CreateSubscriptionParams{
+			ClientID:     req.ClientID,
+			IntervalDays: req.IntervalDays,
+			NextRunDate:  startDate,
+			Observation:  req.Observation,
+		},
+		Items: subscriptionItemParams(req.Items),
+	}
+
+	result, err := server.store.CreateSubscriptionTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, result.Subscription.ID, nil, result.Subscription)
+	ctx.JSON(http.StatusOK, newSubscriptionResponse(result))
+}
+
+type getSubscriptionRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getSubscription(ctx *gin.Context) {
+	var req getSubscriptionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sub, err := server.store.GetSubscription(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	items, err := server.store.ListSubscriptionItems(ctx, sub.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, subscriptionResponse{Subscription: sub, Items: items})
+}
+
+type listSubscriptionRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type listSubscriptionResponse struct {
+	Total         int64             `json:"total"`
+	Subscriptions []db.Subscription `json:"subscriptions"`
+}
+
+func (server *Server) listSubscription(ctx *gin.Context) {
+	var req listSubscriptionRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	total, err := server.store.CountSubscriptions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	subscriptions, err := server.store.ListSubscriptions(ctx, db.ListSubscriptionsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listSubscriptionResponse{Total: total, Subscriptions: subscriptions})
+}
+
+type updateSubscriptionRequest struct {
+	IntervalDays int32                      `json:"interval_days" binding:"omitempty,min=1"`
+	NextRunDate  string                     `json:"next_run_date"`
+	Paused       *bool                      `json:"paused"`
+	Observation  string                     `json:"observation"`
+	Items        *[]subscriptionItemRequest `json:"items" binding:"omitempty,min=1,dive"`
+}
+
+func (server *Server) updateSubscription(ctx *gin.Context) {
+	var uri getSubscriptionRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	existing, err := server.store.GetSubscription(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	previous := existing
+
+	// Update only the fields that are provided in the request
+	if req.IntervalDays != 0 {
+		existing.IntervalDays = req.IntervalDays
+	}
+	if req.NextRunDate != "" {
+		existing.NextRunDate, err = parseSubscriptionDate(req.NextRunDate)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	if req.Paused != nil {
+		existing.Paused = *req.Paused
+	}
+	if req.Observation != "" {
+		existing.Observation = req.Observation
+	}
+
+	arg := db.UpdateSubscriptionTxParams{
+		UpdateSubscriptionParams: db.UpdateSubscriptionParams{
+			ID:           existing.ID,
+			IntervalDays: existing.IntervalDays,
+			NextRunDate:  existing.NextRunDate,
+			Paused:       existing.Paused,
+			Observation:  existing.Observation,
+		},
+	}
+	if req.Items != nil {
+		arg.ReplaceItems = true
+		arg.Items = subscriptionItemParams(*req.Items)
+	}
+
+	result, err := server.store.UpdateSubscriptionTx(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, result.Subscription.ID, previous, result.Subscription)
+	ctx.JSON(http.StatusOK, newSubscriptionResponse(result))
+}
+
+func (server *Server) deleteSubscription(ctx *gin.Context) {
+	var req getSubscriptionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	subscription, err := server.store.GetSubscription(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.DeleteSubscription(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, subscription.ID, subscription, nil)
+	ctx.JSON(http.StatusOK, "Subscription deleted successfully")
+}
+
+// listSubscriptionRuns lists the sales already generated for a subscription
+func (server *Server) listSubscriptionRuns(ctx *gin.Context) {
+	var req getSubscriptionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	runs, err := server.store.ListSubscriptionRuns(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, runs)
+}
+
+type listUpcomingOrdersRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=365"`
+}
+
+type upcomingOrder struct {
+	SubscriptionID int64     `json:"subscription_id"`
+	ClientID       int64     `json:"client_id"`
+	ClientName     string    `json:"client_name"`
+	RunDate        time.Time `json:"run_date"`
+	Product        string    `json:"product"`
+	Price          float64   `json:"price"`
+}
+
+// listUpcomingOrders projects the sales active subscriptions will generate in the next days
+func (server *Server) listUpcomingOrders(ctx *gin.Context) {
+	var req listUpcomingOrdersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Days == 0 {
+		req.Days = 30
+	}
+
+	subscriptions, err := server.store.ListActiveSubscriptions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	until := subscription.Date(time.Now()).AddDate(0, 0, req.Days)
+	clients := make(map[int64]db.Client)
+	orders := []upcomingOrder{}
+
+	for _, sub := range subscriptions {
+		dates := subscription.UpcomingRunDates(sub, until)
+		if len(dates) == 0 {
+			continue
+		}
+
+		items, err := server.store.ListSubscriptionItems(ctx, sub.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		client, ok := clients[sub.ClientID]
+		if !ok {
+			client, err = server.store.GetClient(ctx, sub.ClientID)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+			clients[sub.ClientID] = client
+		}
+
+		product, total := subscription.DescribeItems(items)
+		for _, date := range dates {
+			orders = append(orders, upcomingOrder{
+				SubscriptionID: sub.ID,
+				ClientID:       client.ID,
+				ClientName:     client.FullName,
+				RunDate:        date,
+				Product:        product,
+				Price:          total,
+			})
+		}
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].RunDate.Before(orders[j].RunDate)
+	})
+
+	ctx.JSON(http.StatusOK, orders)
+}
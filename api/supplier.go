@@ -0,0 +1,218 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	db "super-pet-delivery/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+type createSupplierRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Document string `json:"document"`
+	Email    string `json:"email" binding:"omitempty,email"`
+	Phone    string `json:"phone"`
+	Notes    string `json:"notes"`
+}
+
+func (server *Server) createSupplier(ctx *gin.Context) {
+	var req createSupplierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateSupplierParams{
+		Name:     req.Name,
+		Document: req.Document,
+		Email:    req.Email,
+		Phone:    req.Phone,
+		Notes:    req.Notes,
+	}
+
+	supplier, err := server.store.CreateSupplier(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, supplier.ID, nil, supplier)
+	ctx.JSON(http.StatusOK, supplier)
+}
+
+type getSupplierRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getSupplier(ctx *gin.Context) {
+	var req getSupplierRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	supplier, err := server.store.GetSupplier(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, supplier)
+}
+
+type listSupplierRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type listSupplierResponse struct {
+	Total     int64         `json:"total"`
+	Suppliers []db.Supplier `json:"suppliers"`
+}
+
+func (server *Server) listSupplier(ctx *gin.Context) {
+	var req listSupplierRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	total, err := server.store.CountSuppliers(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	suppliers, err := server.store.ListSuppliers(ctx, db.ListSuppliersParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listSupplierResponse{Total: total, Suppliers: suppliers})
+}
+
+type updateSupplierRequest struct {
+	Name     string  `json:"name"`
+	Document *string `json:"document"`
+	Email    *string `json:"email" binding:"omitempty,email"`
+	Phone    *string `json:"phone"`
+	Notes    *string `json:"notes"`
+}
+
+func (server *Server) updateSupplier(ctx *gin.Context) {
+	var uri getSupplierRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateSupplierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	existing, err := server.store.GetSupplier(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	previous := existing
+
+	// Update only the fields that are provided in the request
+	if req.Name != "" {
+		existing.Name = req.Name
+	}
+	if req.Document != nil {
+		existing.Document = *req.Document
+	}
+	if req.Email != nil {
+		existing.Email = *req.Email
+	}
+	if req.Phone != nil {
+		existing.Phone = *req.Phone
+	}
+	if req.Notes != nil {
+		existing.Notes = *req.Notes
+	}
+
+	supplier, err := server.store.UpdateSupplier(ctx, db.UpdateSupplierParams{
+		ID:       existing.ID,
+		Name:     existing.Name,
+		Document: existing.Document,
+		Email:    existing.Email,
+		Phone:    existing.Phone,
+		Notes:    existing.Notes,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, supplier.ID, previous, supplier)
+	ctx.JSON(http.StatusOK, supplier)
+}
+
+func (server *Server) deleteSupplier(ctx *gin.Context) {
+	var req getSupplierRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// products keep the supplier id, so a supplier still in use can't be deleted
+	products, err := server.store.CountProductsBySupplier(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if products > 0 {
+		err := fmt.Errorf("supplier %d is used by %d products", req.ID, products)
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+
+	orders, err := server.store.CountPurchaseOrdersBySupplier(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if orders > 0 {
+		err := fmt.Errorf("supplier %d has %d purchase orders", req.ID, orders)
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+
+	supplier, err := server.store.GetSupplier(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.DeleteSupplier(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	recordAudit(ctx, supplier.ID, supplier, nil)
+	ctx.JSON(http.StatusOK, "Supplier deleted successfully")
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomSupplier() db.Supplier {
+	return db.Supplier{
+		ID:    util.RandomInt(1, 1000),
+		Name:  util.RandomFullName(),
+		Email: util.RandomEmail(),
+		Phone: "11999999999",
+	}
+}
+
+func TestCreateSupplierAPI(t *testing.T) {
+	supplier := randomSupplier()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": supplier.Name, "email": supplier.Email, "phone": supplier.Phone},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateSupplierParams{
+					Name:  supplier.Name,
+					Email: supplier.Email,
+					Phone: supplier.Phone,
+				}
+				store.EXPECT().CreateSupplier(gomock.Any(), gomock.Eq(arg)).Times(1).Return(supplier, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Supplier
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, supplier, got)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{"name": supplier.Name, "email": "not-an-email"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSupplier(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			requestBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/suppliers", bytes.NewReader(requestBody))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteSupplierAPI(t *testing.T) {
+	supplier := randomSupplier()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountProductsBySupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(int64(0), nil)
+				store.EXPECT().CountPurchaseOrdersBySupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetSupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(supplier, nil)
+				store.EXPECT().DeleteSupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(nil)
+				store.EXPECT().CreateAuditLog(gomock.Any(), auditLogFor("supplier", "delete", supplier.ID)).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InUse",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountProductsBySupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(int64(3), nil)
+				store.EXPECT().DeleteSupplier(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "HasPurchaseOrders",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountProductsBySupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(int64(0), nil)
+				store.EXPECT().CountPurchaseOrdersBySupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(int64(2), nil)
+				store.EXPECT().DeleteSupplier(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/suppliers/%d", supplier.ID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, "username", time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
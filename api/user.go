@@ -88,6 +88,8 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
+	recordAudit(ctx, user.ID, nil, user)
+
 	rsp := newUserResponse(user)
 
 	ctx.JSON(http.StatusOK, rsp)
@@ -217,6 +219,7 @@ func (server *Server) updateUser(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	previousUser := existingUser
 
 	if req.FullName != "" {
 		existingUser.FullName = req.FullName
@@ -260,6 +263,8 @@ func (server *Server) updateUser(ctx *gin.Context) {
 		return
 	}
 
+	recordAudit(ctx, user.ID, previousUser, user)
+
 	ctx.JSON(http.StatusOK, user)
 }
 
@@ -290,7 +295,7 @@ func (server *Server) deleteUser(ctx *gin.Context) {
 	}
 
 	// Fetch the existing user data from db
-	_, err = server.store.GetUser(ctx, userID)
+	user, err := server.store.GetUser(ctx, userID)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -306,21 +311,26 @@ func (server *Server) deleteUser(ctx *gin.Context) {
 	if len(products) > 0 {
 		// If products are associated, check if a new user ID is provided in the request body
 		if req.UserID != 0 {
-			// Update the products' ownership to the new user ID
-			for _, p := range products {
-				arg := db.UpdateProductParams{
-					ID:          p.ID,
-					Name:        p.Name,
-					Description: p.Description,
-					UserID:      req.UserID,
-				}
-				_, err := server.store.UpdateProduct(ctx, arg)
-				if err != nil {
-					fmt.Printf("error in updating product: %v\n", err)
-					ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			newUser, err := server.store.GetUser(ctx, req.UserID)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("new user %d not found", req.UserID)))
 					return
 				}
-				// You can do something with the updatedProduct if needed
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+
+			// Update the products' ownership to the new user, only the owner columns change
+			_, err = server.store.ReassignProductsUser(ctx, db.ReassignProductsUserParams{
+				NewUserID:   newUser.ID,
+				NewUsername: newUser.Username,
+				UserID:      userID,
+			})
+			if err != nil {
+				fmt.Printf("error in updating product: %v\n", err)
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
 			}
 		} else {
 			// If no new user ID is provided, return an error indicating products are associated
@@ -336,6 +346,8 @@ func (server *Server) deleteUser(ctx *gin.Context) {
 		return
 	}
 
+	recordAudit(ctx, user.ID, user, nil)
+
 	ctx.JSON(http.StatusOK, "User deleted successfully")
 }
 
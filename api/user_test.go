@@ -66,15 +66,18 @@ func TestCreateUserAPI(t *testing.T) {
 				"password":  password,
 				"full_name": user.FullName,
 				"email":     user.Email,
+				"role":      user.Role,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{Username: user.Username, Role: "Administrator"}, nil)
 				arg := db.CreateUserParams{
 					Username: user.Username,
 					FullName: user.FullName,
 					Email:    user.Email,
+					Role:     user.Role,
 				}
 				store.EXPECT().
 					CreateUser(gomock.Any(), EqCreateUserParams(arg, password)).
@@ -93,11 +96,13 @@ func TestCreateUserAPI(t *testing.T) {
 				"password":  password,
 				"full_name": user.FullName,
 				"email":     user.Email,
+				"role":      user.Role,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{Username: user.Username, Role: "Administrator"}, nil)
 				store.EXPECT().
 					CreateUser(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -115,11 +120,13 @@ func TestCreateUserAPI(t *testing.T) {
 				"password":  "123",
 				"full_name": user.FullName,
 				"email":     user.Email,
+				"role":      user.Role,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByUsername(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{Username: user.Username, Role: "Administrator"}, nil)
 				store.EXPECT().
 					CreateUser(gomock.Any(), gomock.Any()).
 					Times(0)
@@ -158,6 +165,99 @@ func TestCreateUserAPI(t *testing.T) {
 	}
 }
 
+func TestDeleteUserAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = "Administrator"
+	user, _ := randomUser(t)
+	user.ID = 7
+	newUser, _ := randomUser(t)
+	newUser.ID = 8
+	products := []db.Product{{ID: 1, Name: "Ração", UserID: user.ID, Price: 150}}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "ReassignsProducts",
+			body: gin.H{"user_id": newUser.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().ListProductsByUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(products, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(newUser.ID)).Times(1).Return(newUser, nil)
+				// only the owner changes, the other columns of the products are kept
+				store.EXPECT().UpdateProduct(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ReassignProductsUser(gomock.Any(), gomock.Eq(db.ReassignProductsUserParams{
+					NewUserID:   newUser.ID,
+					NewUsername: newUser.Username,
+					UserID:      user.ID,
+				})).Times(1).Return(int64(1), nil)
+				store.EXPECT().DeleteUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NewUserNotFound",
+			body: gin.H{"user_id": newUser.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().ListProductsByUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(products, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(newUser.ID)).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().ReassignProductsUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "ProductsWithoutNewUser",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().ListProductsByUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(products, nil)
+				store.EXPECT().ReassignProductsUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByUsername(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/users/%d", user.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 func randomUser(t *testing.T) (user db.User, password string) {
 	password = util.RandomString(6)
 	hashedPassword, err := util.HashPassword(password)
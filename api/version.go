@@ -0,0 +1,72 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	db "super-pet-delivery/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errVersionRequired = errors.New("send the version of the record in the If-Match header or in the version field")
+	errInvalidETag     = errors.New("invalid If-Match, it must be the ETag of the record")
+	errVersionMismatch = errors.New("the If-Match header and the version field are different")
+)
+
+// setETag sends the version of the record as its ETag, the updates send it back in If-Match
+func setETag(ctx *gin.Context, version int64) {
+	ctx.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// parseETag reads the version from an ETag made by setETag, weak or not
+func parseETag(etag string) (int64, error) {
+	value, err := strconv.Unquote(strings.TrimPrefix(strings.TrimSpace(etag), "W/"))
+	if err != nil {
+		return 0, errInvalidETag
+	}
+
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version < 1 {
+		return 0, errInvalidETag
+	}
+	return version, nil
+}
+
+// expectedVersion is the version of the record the client edited, from the If-Match header or the version
+// field of the body. The updates require it so two admins editing the same record don't overwrite each other.
+// The error response is already written when it returns false.
+func expectedVersion(ctx *gin.Context, bodyVersion int64) (int64, bool) {
+	version := bodyVersion
+
+	if ifMatch := ctx.GetHeader("If-Match"); ifMatch != "" {
+		headerVersion, err := parseETag(ifMatch)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return 0, false
+		}
+		if bodyVersion != 0 && bodyVersion != headerVersion {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errVersionMismatch))
+			return 0, false
+		}
+		version = headerVersion
+	}
+
+	if version == 0 {
+		ctx.JSON(http.StatusPreconditionRequired, errorResponse(errVersionRequired))
+		return 0, false
+	}
+	return version, true
+}
+
+// checkVersion answers 409 Conflict when the record changed since the client read it.
+// The error response is already written when it returns false.
+func checkVersion(ctx *gin.Context, expected int64, current int64) bool {
+	if expected != current {
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrVersionConflict))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseETag(t *testing.T) {
+	version, err := parseETag(`"12"`)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), version)
+
+	version, err = parseETag(` W/"3" `)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), version)
+
+	for _, etag := range []string{"12", `"abc"`, `"0"`, `"-1"`, "*", `"1", "2"`} {
+		_, err = parseETag(etag)
+		require.ErrorIs(t, err, errInvalidETag, etag)
+	}
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"context"
+	"log"
+	db "super-pet-delivery/db/sqlc"
+	"time"
+)
+
+// Purger deletes the audit log entries older than the retention period
+type Purger struct {
+	store     db.Store
+	retention time.Duration
+}
+
+// NewPurger creates a new audit log purger, a retention of zero keeps the audit log forever
+func NewPurger(store db.Store, retention time.Duration) *Purger {
+	return &Purger{store: store, retention: retention}
+}
+
+// Purge deletes the entries created before now minus the retention and returns how many were deleted
+func (purger *Purger) Purge(ctx context.Context, now time.Time) (int64, error) {
+	if purger.retention <= 0 {
+		return 0, nil
+	}
+
+	return purger.store.DeleteAuditLogsBefore(ctx, now.Add(-purger.retention))
+}
+
+// Start purges the audit log every interval until the context is cancelled
+func (purger *Purger) Start(ctx context.Context, interval time.Duration) {
+	if purger.retention <= 0 {
+		return
+	}
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := purger.Purge(ctx, time.Now()); err != nil {
+			log.Println("cannot purge the audit log:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
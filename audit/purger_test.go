@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	mockdb "super-pet-delivery/db/mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurge(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		retention  time.Duration
+		buildStubs func(store *mockdb.MockStore)
+		expected   int64
+	}{
+		{
+			name:      "OldEntries",
+			retention: 90 * 24 * time.Hour,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteAuditLogsBefore(gomock.Any(), gomock.Eq(time.Date(2023, 3, 3, 12, 0, 0, 0, time.UTC))).
+					Times(1).
+					Return(int64(7), nil)
+			},
+			expected: 7,
+		},
+		{
+			name:      "KeptForever",
+			retention: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteAuditLogsBefore(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: 0,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			deleted, err := NewPurger(store, tc.retention).Purge(context.Background(), now)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, deleted)
+		})
+	}
+}
@@ -0,0 +1,2 @@
+ALTER TABLE "notification_outbox" DROP CONSTRAINT IF EXISTS "notification_outbox_sale_id_fkey";
+DROP TABLE IF EXISTS "notification_outbox";
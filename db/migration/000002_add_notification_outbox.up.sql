@@ -0,0 +1,19 @@
+CREATE TABLE "notification_outbox" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "channel" varchar NOT NULL,
+  "event" varchar NOT NULL,
+  "sale_id" bigint NOT NULL,
+  "recipient" varchar NOT NULL,
+  "subject" varchar NOT NULL,
+  "body" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+  "sent_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z',
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo')
+);
+
+ALTER TABLE "notification_outbox" ADD FOREIGN KEY ("sale_id") REFERENCES "sale" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "notification_outbox" ("status", "next_attempt_at");
@@ -0,0 +1,2 @@
+ALTER TABLE "client_notes" DROP CONSTRAINT IF EXISTS "client_notes_client_id_fkey";
+DROP TABLE IF EXISTS "client_notes";
@@ -0,0 +1,13 @@
+CREATE TABLE "client_notes" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "client_id" bigint NOT NULL,
+  "author" varchar NOT NULL,
+  "body" varchar NOT NULL,
+  "pinned" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo'),
+  "changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+ALTER TABLE "client_notes" ADD FOREIGN KEY ("client_id") REFERENCES "client" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "client_notes" ("client_id");
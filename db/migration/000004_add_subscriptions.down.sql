@@ -0,0 +1,11 @@
+ALTER TABLE "subscription_runs" DROP CONSTRAINT IF EXISTS "subscription_runs_subscription_id_fkey";
+ALTER TABLE "subscription_runs" DROP CONSTRAINT IF EXISTS "subscription_runs_sale_id_fkey";
+DROP TABLE IF EXISTS "subscription_runs";
+
+ALTER TABLE "subscription_items" DROP CONSTRAINT IF EXISTS "subscription_items_subscription_id_fkey";
+DROP TABLE IF EXISTS "subscription_items";
+
+ALTER TABLE "subscriptions" DROP CONSTRAINT IF EXISTS "subscriptions_client_id_fkey";
+DROP TABLE IF EXISTS "subscriptions";
+
+ALTER TABLE "sale" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,36 @@
+ALTER TABLE "sale" ADD COLUMN "status" varchar NOT NULL DEFAULT 'confirmed';
+
+CREATE TABLE "subscriptions" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "client_id" bigint NOT NULL,
+  "interval_days" int NOT NULL,
+  "next_run_date" date NOT NULL,
+  "paused" boolean NOT NULL DEFAULT false,
+  "observation" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo'),
+  "changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+ALTER TABLE "subscriptions" ADD FOREIGN KEY ("client_id") REFERENCES "client" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "subscription_items" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "subscription_id" bigint NOT NULL,
+  "product" varchar NOT NULL,
+  "quantity" int NOT NULL DEFAULT 1,
+  "price" float NOT NULL
+);
+
+ALTER TABLE "subscription_items" ADD FOREIGN KEY ("subscription_id") REFERENCES "subscriptions" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "subscription_runs" (
+  "subscription_id" bigint NOT NULL,
+  "run_date" date NOT NULL,
+  "sale_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo'),
+  CONSTRAINT unique_subscription_run UNIQUE (subscription_id, run_date)
+);
+
+ALTER TABLE "subscription_runs" ADD FOREIGN KEY ("subscription_id") REFERENCES "subscriptions" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "subscription_runs" ADD FOREIGN KEY ("sale_id") REFERENCES "sale" ("id") ON DELETE CASCADE;
@@ -0,0 +1,2 @@
+ALTER TABLE "loyalty_ledger" DROP CONSTRAINT IF EXISTS "loyalty_ledger_client_id_fkey";
+DROP TABLE IF EXISTS "loyalty_ledger";
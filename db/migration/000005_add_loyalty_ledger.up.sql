@@ -0,0 +1,16 @@
+CREATE TABLE "loyalty_ledger" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "client_id" bigint NOT NULL,
+  "sale_id" bigint NOT NULL,
+  "points" bigint NOT NULL,
+  "kind" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo')
+);
+
+-- sale_id has no foreign key on purpose: reversal entries must outlive the deleted sale
+ALTER TABLE "loyalty_ledger" ADD FOREIGN KEY ("client_id") REFERENCES "client" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "loyalty_ledger" ("client_id");
+
+CREATE INDEX ON "loyalty_ledger" ("sale_id");
@@ -0,0 +1,5 @@
+ALTER TABLE "sale_discounts" DROP CONSTRAINT IF EXISTS "sale_discounts_sale_id_fkey";
+ALTER TABLE "sale_discounts" DROP CONSTRAINT IF EXISTS "sale_discounts_coupon_id_fkey";
+DROP TABLE IF EXISTS "sale_discounts";
+
+DROP TABLE IF EXISTS "coupons";
@@ -0,0 +1,34 @@
+CREATE TABLE "coupons" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "code" varchar UNIQUE NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "discount_type" varchar NOT NULL,
+  "discount_value" float NOT NULL,
+  "min_order_value" float NOT NULL DEFAULT 0,
+  "starts_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo'),
+  "ends_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z',
+  "max_uses" int NOT NULL DEFAULT 0,
+  "max_uses_per_client" int NOT NULL DEFAULT 0,
+  "category_ids" bigint[] NOT NULL DEFAULT '{}',
+  "product_ids" bigint[] NOT NULL DEFAULT '{}',
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo'),
+  "changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+CREATE TABLE "sale_discounts" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "sale_id" bigint NOT NULL,
+  "coupon_id" bigint NOT NULL,
+  "client_id" bigint NOT NULL,
+  "code" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "amount" float NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo')
+);
+
+ALTER TABLE "sale_discounts" ADD FOREIGN KEY ("sale_id") REFERENCES "sale" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "sale_discounts" ADD FOREIGN KEY ("coupon_id") REFERENCES "coupons" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "sale_discounts" ("coupon_id", "client_id");
@@ -0,0 +1,4 @@
+ALTER TABLE "scheduled_prices" DROP CONSTRAINT IF EXISTS "scheduled_prices_product_id_fkey";
+DROP TABLE IF EXISTS "scheduled_prices";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "promotion_ends_at";
@@ -0,0 +1,18 @@
+ALTER TABLE "products" ADD COLUMN "promotion_ends_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z';
+
+CREATE TABLE "scheduled_prices" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "product_id" bigint NOT NULL,
+  "price" float NOT NULL,
+  "starts_at" timestamptz NOT NULL,
+  "ends_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z',
+  "status" varchar NOT NULL DEFAULT 'scheduled',
+  "previous_price" float NOT NULL DEFAULT 0,
+  "previous_old_price" float NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo'),
+  "changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+ALTER TABLE "scheduled_prices" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "scheduled_prices" ("status", "starts_at");
@@ -0,0 +1,2 @@
+ALTER TABLE "product_price_history" DROP CONSTRAINT IF EXISTS "product_price_history_product_id_fkey";
+DROP TABLE IF EXISTS "product_price_history";
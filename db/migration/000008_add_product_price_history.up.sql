@@ -0,0 +1,17 @@
+CREATE TABLE "product_price_history" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "product_id" bigint NOT NULL,
+  "price" float NOT NULL,
+  "old_price" float NOT NULL,
+  "changed_by" varchar NOT NULL,
+  "source" varchar NOT NULL DEFAULT 'manual',
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo')
+);
+
+ALTER TABLE "product_price_history" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "product_price_history" ("product_id", "created_at");
+
+-- the current prices are the starting point of the history
+INSERT INTO "product_price_history" ("product_id", "price", "old_price", "changed_by", "source", "created_at")
+SELECT "id", "price", "old_price", "username", 'initial', "created_at" FROM "products";
@@ -0,0 +1,7 @@
+ALTER TABLE "sale_items" DROP CONSTRAINT IF EXISTS "sale_items_sale_id_fkey";
+DROP TABLE IF EXISTS "sale_items";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "cost_price";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "supplier_id";
+
+DROP TABLE IF EXISTS "suppliers";
@@ -0,0 +1,31 @@
+CREATE TABLE "suppliers" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "document" varchar NOT NULL DEFAULT '',
+  "email" varchar NOT NULL DEFAULT '',
+  "phone" varchar NOT NULL DEFAULT '',
+  "notes" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo'),
+  "changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+-- 0 means the product has no supplier
+ALTER TABLE "products" ADD COLUMN "supplier_id" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "products" ADD COLUMN "cost_price" float NOT NULL DEFAULT 0;
+
+-- catalog products sold in a sale with the price and cost they had at the moment of the sale.
+-- product_id has no foreign key so deleting a product keeps the sales history
+CREATE TABLE "sale_items" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "sale_id" bigint NOT NULL,
+  "product_id" bigint NOT NULL,
+  "product_name" varchar NOT NULL,
+  "quantity" int NOT NULL DEFAULT 1,
+  "unit_price" float NOT NULL,
+  "unit_cost" float NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo')
+);
+
+ALTER TABLE "sale_items" ADD FOREIGN KEY ("sale_id") REFERENCES "sale" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "sale_items" ("product_id");
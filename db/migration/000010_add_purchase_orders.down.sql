@@ -0,0 +1,7 @@
+ALTER TABLE "products" DROP COLUMN IF EXISTS "last_cost";
+
+ALTER TABLE "purchase_order_lines" DROP CONSTRAINT IF EXISTS "purchase_order_lines_purchase_order_id_fkey";
+DROP TABLE IF EXISTS "purchase_order_lines";
+
+ALTER TABLE "purchase_orders" DROP CONSTRAINT IF EXISTS "purchase_orders_supplier_id_fkey";
+DROP TABLE IF EXISTS "purchase_orders";
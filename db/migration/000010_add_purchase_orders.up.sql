@@ -0,0 +1,32 @@
+CREATE TABLE "purchase_orders" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "supplier_id" bigint NOT NULL,
+  "supplier_name" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'draft',
+  "notes" varchar NOT NULL DEFAULT '',
+  "expected_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z',
+  "sent_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z',
+  "received_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z',
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo'),
+  "changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+ALTER TABLE "purchase_orders" ADD FOREIGN KEY ("supplier_id") REFERENCES "suppliers" ("id");
+
+CREATE INDEX ON "purchase_orders" ("status");
+
+-- product_id has no foreign key so deleting a product keeps the purchase history
+CREATE TABLE "purchase_order_lines" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "purchase_order_id" bigint NOT NULL,
+  "product_id" bigint NOT NULL,
+  "product_name" varchar NOT NULL,
+  "quantity" int NOT NULL,
+  "received_quantity" int NOT NULL DEFAULT 0,
+  "unit_cost" float NOT NULL
+);
+
+ALTER TABLE "purchase_order_lines" ADD FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_orders" ("id") ON DELETE CASCADE;
+
+-- what we paid the last time the product was received
+ALTER TABLE "products" ADD COLUMN "last_cost" float NOT NULL DEFAULT 0;
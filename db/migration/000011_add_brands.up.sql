@@ -0,0 +1,15 @@
+-- logo_id is the id of the logo in images, 0 means the brand has no logo
+CREATE TABLE "brands" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "slug" varchar UNIQUE NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "logo_id" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo'),
+  "changed_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z'
+);
+
+-- 0 means the product has no brand
+ALTER TABLE "products" ADD COLUMN "brand_id" bigint NOT NULL DEFAULT 0;
+
+CREATE INDEX ON "products" ("brand_id");
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "categories_parent_id_display_order_idx";
+ALTER TABLE "categories" DROP CONSTRAINT IF EXISTS "categories_slug_key";
+
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "display_order";
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "slug";
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "parent_id";
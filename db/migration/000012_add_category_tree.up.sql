@@ -0,0 +1,16 @@
+-- 0 means a top level category
+ALTER TABLE "categories" ADD COLUMN "parent_id" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "categories" ADD COLUMN "slug" varchar NOT NULL DEFAULT '';
+ALTER TABLE "categories" ADD COLUMN "display_order" int NOT NULL DEFAULT 0;
+
+-- slugs of the existing categories, new ones are generated by the api like product urls
+UPDATE "categories" SET "slug" = lower(replace(translate(trim(name),
+  'áàâãäéèêëíìîïóòôõöúùûüçñÁÀÂÃÄÉÈÊËÍÌÎÏÓÒÔÕÖÚÙÛÜÇÑ',
+  'aaaaaeeeeiiiiooooouuuucnAAAAAEEEEIIIIOOOOOUUUUCN'), ' ', '-'));
+
+UPDATE "categories" c SET "slug" = c.slug || '-' || c.id
+WHERE c.slug = '' OR EXISTS (SELECT 1 FROM "categories" o WHERE o.slug = c.slug AND o.id < c.id);
+
+ALTER TABLE "categories" ADD CONSTRAINT "categories_slug_key" UNIQUE ("slug");
+
+CREATE INDEX ON "categories" ("parent_id", "display_order");
@@ -0,0 +1,5 @@
+-- the links merged from products.categories are kept
+DROP INDEX IF EXISTS "product_categories_category_id_idx";
+
+ALTER TABLE "product_categories" DROP CONSTRAINT IF EXISTS "product_categories_product_id_fkey";
+ALTER TABLE "product_categories" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
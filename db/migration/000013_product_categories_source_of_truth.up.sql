@@ -0,0 +1,22 @@
+-- product_categories is the source of truth for the categories of a product.
+-- products.categories is kept as a read only copy for the product responses and is
+-- rewritten from product_categories every time the links of a product change.
+
+-- links only present in the array, for categories that still exist
+INSERT INTO "product_categories" ("product_id", "category_id")
+SELECT p.id, c.id
+FROM "products" p
+JOIN "categories" c ON c.id = ANY(p.categories)
+ON CONFLICT DO NOTHING;
+
+UPDATE "products" p SET "categories" = ARRAY(
+  SELECT pc.category_id FROM "product_categories" pc
+  WHERE pc.product_id = p.id
+  ORDER BY pc.category_id
+);
+
+-- deleting a product removes its links
+ALTER TABLE "product_categories" DROP CONSTRAINT IF EXISTS "product_categories_product_id_fkey";
+ALTER TABLE "product_categories" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "product_categories" ("category_id");
@@ -0,0 +1,5 @@
+-- the links and images created from products.images are kept
+DROP INDEX IF EXISTS "product_images_image_id_idx";
+
+ALTER TABLE "product_images" DROP CONSTRAINT IF EXISTS "product_images_product_id_fkey";
+ALTER TABLE "product_images" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
@@ -0,0 +1,36 @@
+-- product_images is the source of truth for the images of a product and their order.
+-- products.images is kept as a read only copy of the ordered image paths and is
+-- rewritten from product_images every time the links of a product change.
+
+-- paths only present in the array get an image record so no product loses an image
+INSERT INTO "images" ("name", "description", "alt", "image_path")
+SELECT DISTINCT regexp_replace(path, '^.*/', ''), '', '', path
+FROM "products" p, unnest(p.images) AS path
+WHERE path <> ''
+  AND NOT EXISTS (SELECT 1 FROM "images" i WHERE i.image_path = path);
+
+-- links only present in the array go after the existing ones, keeping the array order
+INSERT INTO "product_images" ("product_id", "image_id", "order")
+SELECT a.product_id, a.image_id,
+  COALESCE((SELECT max(pi."order") FROM "product_images" pi WHERE pi.product_id = a.product_id), -1) + a.position
+FROM (
+  SELECT DISTINCT ON (p.id, i.image_path) p.id AS product_id, i.id AS image_id, path.position
+  FROM "products" p
+  CROSS JOIN LATERAL unnest(p.images) WITH ORDINALITY AS path(image_path, position)
+  JOIN "images" i ON i.image_path = path.image_path
+  ORDER BY p.id, i.image_path, i.id, path.position
+) a
+ON CONFLICT DO NOTHING;
+
+UPDATE "products" p SET "images" = ARRAY(
+  SELECT i.image_path FROM "product_images" pi
+  JOIN "images" i ON i.id = pi.image_id
+  WHERE pi.product_id = p.id
+  ORDER BY pi."order", pi.image_id
+);
+
+-- deleting a product removes its links
+ALTER TABLE "product_images" DROP CONSTRAINT IF EXISTS "product_images_product_id_fkey";
+ALTER TABLE "product_images" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "product_images" ("image_id");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "products_status_deleted_at_idx";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "published_at";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,11 @@
+-- the products created so far were public, they start as published
+ALTER TABLE "products" ADD COLUMN "status" varchar NOT NULL DEFAULT 'published';
+ALTER TABLE "products" ADD COLUMN "published_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z';
+ALTER TABLE "products" ADD COLUMN "deleted_at" timestamptz NOT NULL DEFAULT '0001-01-01 00:00:00Z';
+
+UPDATE "products" SET "published_at" = "created_at";
+
+-- new products are drafts until they are published
+ALTER TABLE "products" ALTER COLUMN "status" SET DEFAULT 'draft';
+
+CREATE INDEX ON "products" ("status", "deleted_at");
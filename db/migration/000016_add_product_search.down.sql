@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "products_name_trgm_idx";
+DROP INDEX IF EXISTS "products_search_vector_idx";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "search_vector";
+
+DROP FUNCTION IF EXISTS immutable_unaccent(text);
+DROP TEXT SEARCH CONFIGURATION IF EXISTS portuguese_unaccent;
+
+-- the extensions are kept, other database objects may use them
@@ -0,0 +1,24 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- portuguese stemming that ignores accents, "racao" and "rações" both find "ração"
+CREATE TEXT SEARCH CONFIGURATION portuguese_unaccent (COPY = portuguese);
+ALTER TEXT SEARCH CONFIGURATION portuguese_unaccent
+  ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+
+-- unaccent isn't immutable because its dictionary can change, indexes need an immutable wrapper
+CREATE FUNCTION immutable_unaccent(text) RETURNS text AS $$
+  SELECT public.unaccent('public.unaccent', $1)
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+-- the name and the sku weigh more than the description in the ranking
+ALTER TABLE "products" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector('portuguese_unaccent', coalesce("name", '')), 'A') ||
+  setweight(to_tsvector('portuguese_unaccent', coalesce("sku", '')), 'A') ||
+  setweight(to_tsvector('portuguese_unaccent', coalesce("description", '')), 'B')
+) STORED;
+
+CREATE INDEX "products_search_vector_idx" ON "products" USING GIN ("search_vector");
+
+-- typo tolerance on the name, the similar names are found with the full text matches and ranked after them
+CREATE INDEX "products_name_trgm_idx" ON "products" USING GIN (immutable_unaccent(lower("name")) gin_trgm_ops);
@@ -0,0 +1,3 @@
+ALTER TABLE "sale" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "client" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "version";
@@ -0,0 +1,4 @@
+-- the version goes up on every update, an update made with an older version is a conflict
+ALTER TABLE "sale" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "client" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "products" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
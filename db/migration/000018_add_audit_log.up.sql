@@ -0,0 +1,18 @@
+-- who changed what in the admin, changes holds the fields that changed as {"field": {"before": ..., "after": ...}}
+CREATE TABLE "audit_log" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "actor" varchar NOT NULL,
+  "action" varchar NOT NULL,
+  "entity" varchar NOT NULL,
+  "entity_id" bigint NOT NULL DEFAULT 0,
+  "changes" jsonb NOT NULL DEFAULT '{}',
+  "ip" varchar NOT NULL DEFAULT '',
+  "user_agent" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo')
+);
+
+CREATE INDEX ON "audit_log" ("entity", "entity_id");
+
+CREATE INDEX ON "audit_log" ("actor");
+
+CREATE INDEX ON "audit_log" ("created_at");
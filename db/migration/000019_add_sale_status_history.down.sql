@@ -0,0 +1,2 @@
+ALTER TABLE "sale_status_history" DROP CONSTRAINT IF EXISTS "sale_status_history_sale_id_fkey";
+DROP TABLE IF EXISTS "sale_status_history";
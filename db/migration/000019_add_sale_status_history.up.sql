@@ -0,0 +1,15 @@
+CREATE TABLE "sale_status_history" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "sale_id" bigint NOT NULL,
+  "status" varchar NOT NULL,
+  "changed_by" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now() AT TIME ZONE 'America/Sao_Paulo')
+);
+
+ALTER TABLE "sale_status_history" ADD FOREIGN KEY ("sale_id") REFERENCES "sale" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "sale_status_history" ("sale_id");
+
+-- the existing sales start their history with the status they have now
+INSERT INTO "sale_status_history" ("sale_id", "status", "created_at")
+SELECT "id", "status", "created_at" FROM "sale";
@@ -0,0 +1 @@
+ALTER TABLE "purchase_order_lines" DROP COLUMN IF EXISTS "received_unit_cost";
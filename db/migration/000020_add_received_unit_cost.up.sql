@@ -0,0 +1,2 @@
+-- what the supplier charged for the units received, unit_cost keeps the ordered cost
+ALTER TABLE "purchase_order_lines" ADD COLUMN "received_unit_cost" float NOT NULL DEFAULT 0;
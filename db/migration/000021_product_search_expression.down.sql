@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS "products_search_vector_idx";
+DROP FUNCTION IF EXISTS product_search_vector(text, text, text);
+
+ALTER TABLE "products" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector('portuguese_unaccent', coalesce("name", '')), 'A') ||
+  setweight(to_tsvector('portuguese_unaccent', coalesce("sku", '')), 'A') ||
+  setweight(to_tsvector('portuguese_unaccent', coalesce("description", '')), 'B')
+) STORED;
+
+CREATE INDEX "products_search_vector_idx" ON "products" USING GIN ("search_vector");
@@ -0,0 +1,13 @@
+-- the search vector is computed from the columns by the index instead of being stored,
+-- so it is never read back with the product
+DROP INDEX IF EXISTS "products_search_vector_idx";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "search_vector";
+
+-- the name and the sku weigh more than the description in the ranking
+CREATE FUNCTION product_search_vector(name text, sku text, description text) RETURNS tsvector AS $$
+  SELECT setweight(to_tsvector('public.portuguese_unaccent', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('public.portuguese_unaccent', coalesce(sku, '')), 'A') ||
+    setweight(to_tsvector('public.portuguese_unaccent', coalesce(description, '')), 'B')
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+CREATE INDEX "products_search_vector_idx" ON "products" USING GIN (product_search_vector("name", "sku", "description"));
@@ -0,0 +1,2 @@
+ALTER TABLE "sale_discounts" DROP CONSTRAINT IF EXISTS "sale_discounts_coupon_id_fkey";
+ALTER TABLE "sale_discounts" ADD FOREIGN KEY ("coupon_id") REFERENCES "coupons" ("id") ON DELETE CASCADE;
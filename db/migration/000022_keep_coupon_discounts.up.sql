@@ -0,0 +1,3 @@
+-- the discounts are the record of what each sale was discounted, a coupon with uses can't be deleted
+ALTER TABLE "sale_discounts" DROP CONSTRAINT IF EXISTS "sale_discounts_coupon_id_fkey";
+ALTER TABLE "sale_discounts" ADD FOREIGN KEY ("coupon_id") REFERENCES "coupons" ("id") ON DELETE RESTRICT;
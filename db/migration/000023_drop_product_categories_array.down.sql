@@ -0,0 +1,7 @@
+ALTER TABLE "products" ADD COLUMN "categories" bigint[] NOT NULL DEFAULT '{}';
+
+UPDATE "products" p SET "categories" = ARRAY(
+  SELECT pc.category_id FROM "product_categories" pc
+  WHERE pc.product_id = p.id
+  ORDER BY pc.category_id
+);
@@ -0,0 +1,10 @@
+-- the categories of a product are read only from product_categories
+
+-- links only present in the array, for categories that still exist
+INSERT INTO "product_categories" ("product_id", "category_id")
+SELECT p.id, c.id
+FROM "products" p
+JOIN "categories" c ON c.id = ANY(p.categories)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE "products" DROP COLUMN "categories";
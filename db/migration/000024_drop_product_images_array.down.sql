@@ -0,0 +1,8 @@
+ALTER TABLE "products" ADD COLUMN "images" varchar[] NOT NULL DEFAULT '{}';
+
+UPDATE "products" p SET "images" = ARRAY(
+  SELECT i.image_path FROM "product_images" pi
+  JOIN "images" i ON i.id = pi.image_id
+  WHERE pi.product_id = p.id
+  ORDER BY pi."order", pi.image_id
+);
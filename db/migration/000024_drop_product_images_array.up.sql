@@ -0,0 +1,3 @@
+-- the images of a product are read only from product_images, products.images has been
+-- rewritten from it on every change since 000014 so nothing is lost
+ALTER TABLE "products" DROP COLUMN "images";
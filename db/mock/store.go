@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: super-pet-delivery/db/sqlc (interfaces: Store)
+// Source: super-pet-delivery/db/sqlc (interfaces: SortableStore)
 
 // Package mockdb is a generated GoMock package.
 package mockdb
@@ -8,12 +8,13 @@ import (
 	context "context"
 	reflect "reflect"
 	db "super-pet-delivery/db/sqlc"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
-// MockStore is a mock of Store interface.
+// MockStore is a mock of SortableStore interface.
 type MockStore struct {
 	ctrl     *gomock.Controller
 	recorder *MockStoreMockRecorder
@@ -36,6 +37,50 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AssociateCategoriesTx mocks base method.
+func (m *MockStore) AssociateCategoriesTx(arg0 context.Context, arg1 db.ProductCategoriesTxParams) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateCategoriesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssociateCategoriesTx indicates an expected call of AssociateCategoriesTx.
+func (mr *MockStoreMockRecorder) AssociateCategoriesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateCategoriesTx", reflect.TypeOf((*MockStore)(nil).AssociateCategoriesTx), arg0, arg1)
+}
+
+// AssociateExistingCategories mocks base method.
+func (m *MockStore) AssociateExistingCategories(arg0 context.Context, arg1 db.AssociateExistingCategoriesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateExistingCategories", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssociateExistingCategories indicates an expected call of AssociateExistingCategories.
+func (mr *MockStoreMockRecorder) AssociateExistingCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateExistingCategories", reflect.TypeOf((*MockStore)(nil).AssociateExistingCategories), arg0, arg1)
+}
+
+// AssociateImagesTx mocks base method.
+func (m *MockStore) AssociateImagesTx(arg0 context.Context, arg1 db.ProductImagesTxParams) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssociateImagesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssociateImagesTx indicates an expected call of AssociateImagesTx.
+func (mr *MockStoreMockRecorder) AssociateImagesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateImagesTx", reflect.TypeOf((*MockStore)(nil).AssociateImagesTx), arg0, arg1)
+}
+
 // AssociateProductWithCategory mocks base method.
 func (m *MockStore) AssociateProductWithCategory(arg0 context.Context, arg1 db.AssociateProductWithCategoryParams) (db.ProductCategory, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +111,36 @@ func (mr *MockStoreMockRecorder) AssociateProductWithImage(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateProductWithImage", reflect.TypeOf((*MockStore)(nil).AssociateProductWithImage), arg0, arg1)
 }
 
+// ClaimDueNotifications mocks base method.
+func (m *MockStore) ClaimDueNotifications(arg0 context.Context, arg1 db.ClaimDueNotificationsParams) ([]db.NotificationOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueNotifications", arg0, arg1)
+	ret0, _ := ret[0].([]db.NotificationOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueNotifications indicates an expected call of ClaimDueNotifications.
+func (mr *MockStoreMockRecorder) ClaimDueNotifications(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueNotifications", reflect.TypeOf((*MockStore)(nil).ClaimDueNotifications), arg0, arg1)
+}
+
+// CountAuditLogs mocks base method.
+func (m *MockStore) CountAuditLogs(arg0 context.Context, arg1 db.CountAuditLogsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAuditLogs indicates an expected call of CountAuditLogs.
+func (mr *MockStoreMockRecorder) CountAuditLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAuditLogs", reflect.TypeOf((*MockStore)(nil).CountAuditLogs), arg0, arg1)
+}
+
 // CountCategory mocks base method.
 func (m *MockStore) CountCategory(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +171,51 @@ func (mr *MockStoreMockRecorder) CountClients(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClients", reflect.TypeOf((*MockStore)(nil).CountClients), arg0)
 }
 
+// CountCouponUses mocks base method.
+func (m *MockStore) CountCouponUses(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCouponUses", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCouponUses indicates an expected call of CountCouponUses.
+func (mr *MockStoreMockRecorder) CountCouponUses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCouponUses", reflect.TypeOf((*MockStore)(nil).CountCouponUses), arg0, arg1)
+}
+
+// CountCouponUsesByClient mocks base method.
+func (m *MockStore) CountCouponUsesByClient(arg0 context.Context, arg1 db.CountCouponUsesByClientParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCouponUsesByClient", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCouponUsesByClient indicates an expected call of CountCouponUsesByClient.
+func (mr *MockStoreMockRecorder) CountCouponUsesByClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCouponUsesByClient", reflect.TypeOf((*MockStore)(nil).CountCouponUsesByClient), arg0, arg1)
+}
+
+// CountCoupons mocks base method.
+func (m *MockStore) CountCoupons(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCoupons", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCoupons indicates an expected call of CountCoupons.
+func (mr *MockStoreMockRecorder) CountCoupons(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCoupons", reflect.TypeOf((*MockStore)(nil).CountCoupons), arg0)
+}
+
 // CountImages mocks base method.
 func (m *MockStore) CountImages(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -111,6 +231,21 @@ func (mr *MockStoreMockRecorder) CountImages(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountImages", reflect.TypeOf((*MockStore)(nil).CountImages), arg0)
 }
 
+// CountLoyaltyEntriesBySale mocks base method.
+func (m *MockStore) CountLoyaltyEntriesBySale(arg0 context.Context, arg1 db.CountLoyaltyEntriesBySaleParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLoyaltyEntriesBySale", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLoyaltyEntriesBySale indicates an expected call of CountLoyaltyEntriesBySale.
+func (mr *MockStoreMockRecorder) CountLoyaltyEntriesBySale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLoyaltyEntriesBySale", reflect.TypeOf((*MockStore)(nil).CountLoyaltyEntriesBySale), arg0, arg1)
+}
+
 // CountProducts mocks base method.
 func (m *MockStore) CountProducts(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -126,6 +261,81 @@ func (mr *MockStoreMockRecorder) CountProducts(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProducts", reflect.TypeOf((*MockStore)(nil).CountProducts), arg0)
 }
 
+// CountProductsByBrand mocks base method.
+func (m *MockStore) CountProductsByBrand(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductsByBrand", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductsByBrand indicates an expected call of CountProductsByBrand.
+func (mr *MockStoreMockRecorder) CountProductsByBrand(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsByBrand", reflect.TypeOf((*MockStore)(nil).CountProductsByBrand), arg0, arg1)
+}
+
+// CountProductsBySupplier mocks base method.
+func (m *MockStore) CountProductsBySupplier(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductsBySupplier", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductsBySupplier indicates an expected call of CountProductsBySupplier.
+func (mr *MockStoreMockRecorder) CountProductsBySupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsBySupplier", reflect.TypeOf((*MockStore)(nil).CountProductsBySupplier), arg0, arg1)
+}
+
+// CountPublishedProducts mocks base method.
+func (m *MockStore) CountPublishedProducts(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPublishedProducts", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPublishedProducts indicates an expected call of CountPublishedProducts.
+func (mr *MockStoreMockRecorder) CountPublishedProducts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPublishedProducts", reflect.TypeOf((*MockStore)(nil).CountPublishedProducts), arg0)
+}
+
+// CountPurchaseOrders mocks base method.
+func (m *MockStore) CountPurchaseOrders(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPurchaseOrders", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPurchaseOrders indicates an expected call of CountPurchaseOrders.
+func (mr *MockStoreMockRecorder) CountPurchaseOrders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPurchaseOrders", reflect.TypeOf((*MockStore)(nil).CountPurchaseOrders), arg0, arg1)
+}
+
+// CountPurchaseOrdersBySupplier mocks base method.
+func (m *MockStore) CountPurchaseOrdersBySupplier(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPurchaseOrdersBySupplier", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPurchaseOrdersBySupplier indicates an expected call of CountPurchaseOrdersBySupplier.
+func (mr *MockStoreMockRecorder) CountPurchaseOrdersBySupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPurchaseOrdersBySupplier", reflect.TypeOf((*MockStore)(nil).CountPurchaseOrdersBySupplier), arg0, arg1)
+}
+
 // CountSales mocks base method.
 func (m *MockStore) CountSales(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +351,66 @@ func (mr *MockStoreMockRecorder) CountSales(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSales", reflect.TypeOf((*MockStore)(nil).CountSales), arg0)
 }
 
+// CountSubscriptions mocks base method.
+func (m *MockStore) CountSubscriptions(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSubscriptions", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSubscriptions indicates an expected call of CountSubscriptions.
+func (mr *MockStoreMockRecorder) CountSubscriptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSubscriptions", reflect.TypeOf((*MockStore)(nil).CountSubscriptions), arg0)
+}
+
+// CountSuppliers mocks base method.
+func (m *MockStore) CountSuppliers(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSuppliers", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSuppliers indicates an expected call of CountSuppliers.
+func (mr *MockStoreMockRecorder) CountSuppliers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSuppliers", reflect.TypeOf((*MockStore)(nil).CountSuppliers), arg0)
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockStore) CreateAuditLog(arg0 context.Context, arg1 db.CreateAuditLogParams) (db.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", arg0, arg1)
+	ret0, _ := ret[0].(db.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockStoreMockRecorder) CreateAuditLog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockStore)(nil).CreateAuditLog), arg0, arg1)
+}
+
+// CreateBrand mocks base method.
+func (m *MockStore) CreateBrand(arg0 context.Context, arg1 db.CreateBrandParams) (db.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBrand", arg0, arg1)
+	ret0, _ := ret[0].(db.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBrand indicates an expected call of CreateBrand.
+func (mr *MockStoreMockRecorder) CreateBrand(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBrand", reflect.TypeOf((*MockStore)(nil).CreateBrand), arg0, arg1)
+}
+
 // CreateCategory mocks base method.
 func (m *MockStore) CreateCategory(arg0 context.Context, arg1 db.CreateCategoryParams) (db.Category, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +441,36 @@ func (mr *MockStoreMockRecorder) CreateClient(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClient", reflect.TypeOf((*MockStore)(nil).CreateClient), arg0, arg1)
 }
 
+// CreateClientNote mocks base method.
+func (m *MockStore) CreateClientNote(arg0 context.Context, arg1 db.CreateClientNoteParams) (db.ClientNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClientNote", arg0, arg1)
+	ret0, _ := ret[0].(db.ClientNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateClientNote indicates an expected call of CreateClientNote.
+func (mr *MockStoreMockRecorder) CreateClientNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClientNote", reflect.TypeOf((*MockStore)(nil).CreateClientNote), arg0, arg1)
+}
+
+// CreateCoupon mocks base method.
+func (m *MockStore) CreateCoupon(arg0 context.Context, arg1 db.CreateCouponParams) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCoupon", arg0, arg1)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCoupon indicates an expected call of CreateCoupon.
+func (mr *MockStoreMockRecorder) CreateCoupon(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCoupon", reflect.TypeOf((*MockStore)(nil).CreateCoupon), arg0, arg1)
+}
+
 // CreateImage mocks base method.
 func (m *MockStore) CreateImage(arg0 context.Context, arg1 db.CreateImageParams) (db.Image, error) {
 	m.ctrl.T.Helper()
@@ -186,6 +486,36 @@ func (mr *MockStoreMockRecorder) CreateImage(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImage", reflect.TypeOf((*MockStore)(nil).CreateImage), arg0, arg1)
 }
 
+// CreateLoyaltyEntry mocks base method.
+func (m *MockStore) CreateLoyaltyEntry(arg0 context.Context, arg1 db.CreateLoyaltyEntryParams) (db.LoyaltyLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoyaltyEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.LoyaltyLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoyaltyEntry indicates an expected call of CreateLoyaltyEntry.
+func (mr *MockStoreMockRecorder) CreateLoyaltyEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoyaltyEntry", reflect.TypeOf((*MockStore)(nil).CreateLoyaltyEntry), arg0, arg1)
+}
+
+// CreateNotification mocks base method.
+func (m *MockStore) CreateNotification(arg0 context.Context, arg1 db.CreateNotificationParams) (db.NotificationOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", arg0, arg1)
+	ret0, _ := ret[0].(db.NotificationOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockStoreMockRecorder) CreateNotification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockStore)(nil).CreateNotification), arg0, arg1)
+}
+
 // CreateProduct mocks base method.
 func (m *MockStore) CreateProduct(arg0 context.Context, arg1 db.CreateProductParams) (db.Product, error) {
 	m.ctrl.T.Helper()
@@ -201,610 +531,2509 @@ func (mr *MockStoreMockRecorder) CreateProduct(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), arg0, arg1)
 }
 
-// CreateSale mocks base method.
-func (m *MockStore) CreateSale(arg0 context.Context, arg1 db.CreateSaleParams) (db.Sale, error) {
+// CreateProductPriceHistory mocks base method.
+func (m *MockStore) CreateProductPriceHistory(arg0 context.Context, arg1 db.CreateProductPriceHistoryParams) (db.ProductPriceHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSale", arg0, arg1)
-	ret0, _ := ret[0].(db.Sale)
+	ret := m.ctrl.Call(m, "CreateProductPriceHistory", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductPriceHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSale indicates an expected call of CreateSale.
-func (mr *MockStoreMockRecorder) CreateSale(arg0, arg1 interface{}) *gomock.Call {
+// CreateProductPriceHistory indicates an expected call of CreateProductPriceHistory.
+func (mr *MockStoreMockRecorder) CreateProductPriceHistory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSale", reflect.TypeOf((*MockStore)(nil).CreateSale), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductPriceHistory", reflect.TypeOf((*MockStore)(nil).CreateProductPriceHistory), arg0, arg1)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+// CreateProductTx mocks base method.
+func (m *MockStore) CreateProductTx(arg0 context.Context, arg1 db.CreateProductTxParams) (db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "CreateProductTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+// CreateProductTx indicates an expected call of CreateProductTx.
+func (mr *MockStoreMockRecorder) CreateProductTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductTx", reflect.TypeOf((*MockStore)(nil).CreateProductTx), arg0, arg1)
 }
 
-// CreateSliderImage mocks base method.
-func (m *MockStore) CreateSliderImage(arg0 context.Context, arg1 db.CreateSliderImageParams) (db.SliderImageWidget, error) {
+// CreatePurchaseOrder mocks base method.
+func (m *MockStore) CreatePurchaseOrder(arg0 context.Context, arg1 db.CreatePurchaseOrderParams) (db.PurchaseOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSliderImage", arg0, arg1)
-	ret0, _ := ret[0].(db.SliderImageWidget)
+	ret := m.ctrl.Call(m, "CreatePurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSliderImage indicates an expected call of CreateSliderImage.
-func (mr *MockStoreMockRecorder) CreateSliderImage(arg0, arg1 interface{}) *gomock.Call {
+// CreatePurchaseOrder indicates an expected call of CreatePurchaseOrder.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrder(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSliderImage", reflect.TypeOf((*MockStore)(nil).CreateSliderImage), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrder", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrder), arg0, arg1)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// CreatePurchaseOrderLine mocks base method.
+func (m *MockStore) CreatePurchaseOrderLine(arg0 context.Context, arg1 db.CreatePurchaseOrderLineParams) (db.PurchaseOrderLine, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "CreatePurchaseOrderLine", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderLine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// CreatePurchaseOrderLine indicates an expected call of CreatePurchaseOrderLine.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrderLine(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrderLine", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrderLine), arg0, arg1)
 }
 
-// DeleteByImageId mocks base method.
-func (m *MockStore) DeleteByImageId(arg0 context.Context, arg1 int64) error {
+// CreatePurchaseOrderTx mocks base method.
+func (m *MockStore) CreatePurchaseOrderTx(arg0 context.Context, arg1 db.CreatePurchaseOrderTxParams) (db.PurchaseOrderTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByImageId", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreatePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteByImageId indicates an expected call of DeleteByImageId.
-func (mr *MockStoreMockRecorder) DeleteByImageId(arg0, arg1 interface{}) *gomock.Call {
+// CreatePurchaseOrderTx indicates an expected call of CreatePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByImageId", reflect.TypeOf((*MockStore)(nil).DeleteByImageId), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrderTx), arg0, arg1)
 }
 
-// DeleteCategory mocks base method.
-func (m *MockStore) DeleteCategory(arg0 context.Context, arg1 int64) error {
+// CreateSale mocks base method.
+func (m *MockStore) CreateSale(arg0 context.Context, arg1 db.CreateSaleParams) (db.Sale, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateSale", arg0, arg1)
+	ret0, _ := ret[0].(db.Sale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.Call {
+// CreateSale indicates an expected call of CreateSale.
+func (mr *MockStoreMockRecorder) CreateSale(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSale", reflect.TypeOf((*MockStore)(nil).CreateSale), arg0, arg1)
 }
 
-// DeleteClient mocks base method.
-func (m *MockStore) DeleteClient(arg0 context.Context, arg1 int64) error {
+// CreateSaleDiscount mocks base method.
+func (m *MockStore) CreateSaleDiscount(arg0 context.Context, arg1 db.CreateSaleDiscountParams) (db.SaleDiscount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteClient", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateSaleDiscount", arg0, arg1)
+	ret0, _ := ret[0].(db.SaleDiscount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteClient indicates an expected call of DeleteClient.
-func (mr *MockStoreMockRecorder) DeleteClient(arg0, arg1 interface{}) *gomock.Call {
+// CreateSaleDiscount indicates an expected call of CreateSaleDiscount.
+func (mr *MockStoreMockRecorder) CreateSaleDiscount(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockStore)(nil).DeleteClient), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSaleDiscount", reflect.TypeOf((*MockStore)(nil).CreateSaleDiscount), arg0, arg1)
+}
+
+// CreateSaleItem mocks base method.
+func (m *MockStore) CreateSaleItem(arg0 context.Context, arg1 db.CreateSaleItemParams) (db.SaleItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSaleItem", arg0, arg1)
+	ret0, _ := ret[0].(db.SaleItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSaleItem indicates an expected call of CreateSaleItem.
+func (mr *MockStoreMockRecorder) CreateSaleItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSaleItem", reflect.TypeOf((*MockStore)(nil).CreateSaleItem), arg0, arg1)
+}
+
+// CreateSaleStatusChange mocks base method.
+func (m *MockStore) CreateSaleStatusChange(arg0 context.Context, arg1 db.CreateSaleStatusChangeParams) (db.SaleStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSaleStatusChange", arg0, arg1)
+	ret0, _ := ret[0].(db.SaleStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSaleStatusChange indicates an expected call of CreateSaleStatusChange.
+func (mr *MockStoreMockRecorder) CreateSaleStatusChange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSaleStatusChange", reflect.TypeOf((*MockStore)(nil).CreateSaleStatusChange), arg0, arg1)
+}
+
+// CreateSaleTx mocks base method.
+func (m *MockStore) CreateSaleTx(arg0 context.Context, arg1 db.CreateSaleTxParams) (db.CreateSaleTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSaleTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateSaleTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSaleTx indicates an expected call of CreateSaleTx.
+func (mr *MockStoreMockRecorder) CreateSaleTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSaleTx", reflect.TypeOf((*MockStore)(nil).CreateSaleTx), arg0, arg1)
+}
+
+// CreateScheduledPrice mocks base method.
+func (m *MockStore) CreateScheduledPrice(arg0 context.Context, arg1 db.CreateScheduledPriceParams) (db.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledPrice", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledPrice indicates an expected call of CreateScheduledPrice.
+func (mr *MockStoreMockRecorder) CreateScheduledPrice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledPrice", reflect.TypeOf((*MockStore)(nil).CreateScheduledPrice), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+}
+
+// CreateSliderImage mocks base method.
+func (m *MockStore) CreateSliderImage(arg0 context.Context, arg1 db.CreateSliderImageParams) (db.SliderImageWidget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSliderImage", arg0, arg1)
+	ret0, _ := ret[0].(db.SliderImageWidget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSliderImage indicates an expected call of CreateSliderImage.
+func (mr *MockStoreMockRecorder) CreateSliderImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSliderImage", reflect.TypeOf((*MockStore)(nil).CreateSliderImage), arg0, arg1)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockStore) CreateSubscription(arg0 context.Context, arg1 db.CreateSubscriptionParams) (db.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", arg0, arg1)
+	ret0, _ := ret[0].(db.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockStoreMockRecorder) CreateSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockStore)(nil).CreateSubscription), arg0, arg1)
+}
+
+// CreateSubscriptionItem mocks base method.
+func (m *MockStore) CreateSubscriptionItem(arg0 context.Context, arg1 db.CreateSubscriptionItemParams) (db.SubscriptionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscriptionItem", arg0, arg1)
+	ret0, _ := ret[0].(db.SubscriptionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscriptionItem indicates an expected call of CreateSubscriptionItem.
+func (mr *MockStoreMockRecorder) CreateSubscriptionItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscriptionItem", reflect.TypeOf((*MockStore)(nil).CreateSubscriptionItem), arg0, arg1)
+}
+
+// CreateSubscriptionRun mocks base method.
+func (m *MockStore) CreateSubscriptionRun(arg0 context.Context, arg1 db.CreateSubscriptionRunParams) (db.SubscriptionRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscriptionRun", arg0, arg1)
+	ret0, _ := ret[0].(db.SubscriptionRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscriptionRun indicates an expected call of CreateSubscriptionRun.
+func (mr *MockStoreMockRecorder) CreateSubscriptionRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscriptionRun", reflect.TypeOf((*MockStore)(nil).CreateSubscriptionRun), arg0, arg1)
+}
+
+// CreateSubscriptionTx mocks base method.
+func (m *MockStore) CreateSubscriptionTx(arg0 context.Context, arg1 db.CreateSubscriptionTxParams) (db.SubscriptionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscriptionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SubscriptionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscriptionTx indicates an expected call of CreateSubscriptionTx.
+func (mr *MockStoreMockRecorder) CreateSubscriptionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscriptionTx", reflect.TypeOf((*MockStore)(nil).CreateSubscriptionTx), arg0, arg1)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockStore) CreateSupplier(arg0 context.Context, arg1 db.CreateSupplierParams) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockStoreMockRecorder) CreateSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockStore)(nil).CreateSupplier), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// DeactivateCoupon mocks base method.
+func (m *MockStore) DeactivateCoupon(arg0 context.Context, arg1 int64) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateCoupon", arg0, arg1)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateCoupon indicates an expected call of DeactivateCoupon.
+func (mr *MockStoreMockRecorder) DeactivateCoupon(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateCoupon", reflect.TypeOf((*MockStore)(nil).DeactivateCoupon), arg0, arg1)
+}
+
+// DeleteAuditLogsBefore mocks base method.
+func (m *MockStore) DeleteAuditLogsBefore(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuditLogsBefore", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAuditLogsBefore indicates an expected call of DeleteAuditLogsBefore.
+func (mr *MockStoreMockRecorder) DeleteAuditLogsBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuditLogsBefore", reflect.TypeOf((*MockStore)(nil).DeleteAuditLogsBefore), arg0, arg1)
+}
+
+// DeleteBrand mocks base method.
+func (m *MockStore) DeleteBrand(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBrand", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBrand indicates an expected call of DeleteBrand.
+func (mr *MockStoreMockRecorder) DeleteBrand(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBrand", reflect.TypeOf((*MockStore)(nil).DeleteBrand), arg0, arg1)
+}
+
+// DeleteByImageId mocks base method.
+func (m *MockStore) DeleteByImageId(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByImageId", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByImageId indicates an expected call of DeleteByImageId.
+func (mr *MockStoreMockRecorder) DeleteByImageId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByImageId", reflect.TypeOf((*MockStore)(nil).DeleteByImageId), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockStore) DeleteCategory(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
+}
+
+// DeleteCategoryTx mocks base method.
+func (m *MockStore) DeleteCategoryTx(arg0 context.Context, arg1 int64) (db.DeleteCategoryTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategoryTx", arg0, arg1)
+	ret0, _ := ret[0].(db.DeleteCategoryTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategoryTx indicates an expected call of DeleteCategoryTx.
+func (mr *MockStoreMockRecorder) DeleteCategoryTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategoryTx", reflect.TypeOf((*MockStore)(nil).DeleteCategoryTx), arg0, arg1)
+}
+
+// DeleteClient mocks base method.
+func (m *MockStore) DeleteClient(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClient", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClient indicates an expected call of DeleteClient.
+func (mr *MockStoreMockRecorder) DeleteClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClient", reflect.TypeOf((*MockStore)(nil).DeleteClient), arg0, arg1)
+}
+
+// DeleteClientNote mocks base method.
+func (m *MockStore) DeleteClientNote(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClientNote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClientNote indicates an expected call of DeleteClientNote.
+func (mr *MockStoreMockRecorder) DeleteClientNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClientNote", reflect.TypeOf((*MockStore)(nil).DeleteClientNote), arg0, arg1)
+}
+
+// DeleteClientTx mocks base method.
+func (m *MockStore) DeleteClientTx(arg0 context.Context, arg1 db.DeleteClientTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClientTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClientTx indicates an expected call of DeleteClientTx.
+func (mr *MockStoreMockRecorder) DeleteClientTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClientTx", reflect.TypeOf((*MockStore)(nil).DeleteClientTx), arg0, arg1)
 }
 
 // DeleteImage mocks base method.
 func (m *MockStore) DeleteImage(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteImage", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteImage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockStoreMockRecorder) DeleteImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockStore)(nil).DeleteImage), arg0, arg1)
+}
+
+// DeleteImageLinks mocks base method.
+func (m *MockStore) DeleteImageLinks(arg0 context.Context, arg1 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImageLinks", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteImageLinks indicates an expected call of DeleteImageLinks.
+func (mr *MockStoreMockRecorder) DeleteImageLinks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImageLinks", reflect.TypeOf((*MockStore)(nil).DeleteImageLinks), arg0, arg1)
+}
+
+// DeleteImageTx mocks base method.
+func (m *MockStore) DeleteImageTx(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImageTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImageTx indicates an expected call of DeleteImageTx.
+func (mr *MockStoreMockRecorder) DeleteImageTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImageTx", reflect.TypeOf((*MockStore)(nil).DeleteImageTx), arg0, arg1)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockStore) DeleteProduct(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockStoreMockRecorder) DeleteProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockStore)(nil).DeleteProduct), arg0, arg1)
+}
+
+// DeleteProductCategories mocks base method.
+func (m *MockStore) DeleteProductCategories(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductCategories", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductCategories indicates an expected call of DeleteProductCategories.
+func (mr *MockStoreMockRecorder) DeleteProductCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductCategories", reflect.TypeOf((*MockStore)(nil).DeleteProductCategories), arg0, arg1)
+}
+
+// DeleteProductImages mocks base method.
+func (m *MockStore) DeleteProductImages(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductImages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductImages indicates an expected call of DeleteProductImages.
+func (mr *MockStoreMockRecorder) DeleteProductImages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductImages", reflect.TypeOf((*MockStore)(nil).DeleteProductImages), arg0, arg1)
+}
+
+// DeletePurchaseOrder mocks base method.
+func (m *MockStore) DeletePurchaseOrder(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePurchaseOrder indicates an expected call of DeletePurchaseOrder.
+func (mr *MockStoreMockRecorder) DeletePurchaseOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePurchaseOrder", reflect.TypeOf((*MockStore)(nil).DeletePurchaseOrder), arg0, arg1)
+}
+
+// DeletePurchaseOrderLines mocks base method.
+func (m *MockStore) DeletePurchaseOrderLines(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePurchaseOrderLines", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePurchaseOrderLines indicates an expected call of DeletePurchaseOrderLines.
+func (mr *MockStoreMockRecorder) DeletePurchaseOrderLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePurchaseOrderLines", reflect.TypeOf((*MockStore)(nil).DeletePurchaseOrderLines), arg0, arg1)
+}
+
+// DeleteSale mocks base method.
+func (m *MockStore) DeleteSale(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSale", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSale indicates an expected call of DeleteSale.
+func (mr *MockStoreMockRecorder) DeleteSale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSale", reflect.TypeOf((*MockStore)(nil).DeleteSale), arg0, arg1)
+}
+
+// DeleteSaleTx mocks base method.
+func (m *MockStore) DeleteSaleTx(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSaleTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSaleTx indicates an expected call of DeleteSaleTx.
+func (mr *MockStoreMockRecorder) DeleteSaleTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSaleTx", reflect.TypeOf((*MockStore)(nil).DeleteSaleTx), arg0, arg1)
+}
+
+// DeleteSales mocks base method.
+func (m *MockStore) DeleteSales(arg0 context.Context, arg1 []int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSales", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSales indicates an expected call of DeleteSales.
+func (mr *MockStoreMockRecorder) DeleteSales(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSales", reflect.TypeOf((*MockStore)(nil).DeleteSales), arg0, arg1)
+}
+
+// DeleteSalesTx mocks base method.
+func (m *MockStore) DeleteSalesTx(arg0 context.Context, arg1 []int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSalesTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSalesTx indicates an expected call of DeleteSalesTx.
+func (mr *MockStoreMockRecorder) DeleteSalesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSalesTx", reflect.TypeOf((*MockStore)(nil).DeleteSalesTx), arg0, arg1)
+}
+
+// DeleteSliderImage mocks base method.
+func (m *MockStore) DeleteSliderImage(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSliderImage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSliderImage indicates an expected call of DeleteSliderImage.
+func (mr *MockStoreMockRecorder) DeleteSliderImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSliderImage", reflect.TypeOf((*MockStore)(nil).DeleteSliderImage), arg0, arg1)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockStore) DeleteSubscription(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockStoreMockRecorder) DeleteSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStore)(nil).DeleteSubscription), arg0, arg1)
+}
+
+// DeleteSubscriptionItems mocks base method.
+func (m *MockStore) DeleteSubscriptionItems(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscriptionItems", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscriptionItems indicates an expected call of DeleteSubscriptionItems.
+func (mr *MockStoreMockRecorder) DeleteSubscriptionItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriptionItems", reflect.TypeOf((*MockStore)(nil).DeleteSubscriptionItems), arg0, arg1)
+}
+
+// DeleteSupplier mocks base method.
+func (m *MockStore) DeleteSupplier(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSupplier", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSupplier indicates an expected call of DeleteSupplier.
+func (mr *MockStoreMockRecorder) DeleteSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupplier", reflect.TypeOf((*MockStore)(nil).DeleteSupplier), arg0, arg1)
+}
+
+// DeleteUser mocks base method.
+func (m *MockStore) DeleteUser(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
+}
+
+// DisassociateCategoriesTx mocks base method.
+func (m *MockStore) DisassociateCategoriesTx(arg0 context.Context, arg1 db.ProductCategoriesTxParams) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateCategoriesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisassociateCategoriesTx indicates an expected call of DisassociateCategoriesTx.
+func (mr *MockStoreMockRecorder) DisassociateCategoriesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateCategoriesTx", reflect.TypeOf((*MockStore)(nil).DisassociateCategoriesTx), arg0, arg1)
+}
+
+// DisassociateImagesTx mocks base method.
+func (m *MockStore) DisassociateImagesTx(arg0 context.Context, arg1 int64, arg2 []int64) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateImagesTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisassociateImagesTx indicates an expected call of DisassociateImagesTx.
+func (mr *MockStoreMockRecorder) DisassociateImagesTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateImagesTx", reflect.TypeOf((*MockStore)(nil).DisassociateImagesTx), arg0, arg1, arg2)
+}
+
+// DisassociateProductFromCategory mocks base method.
+func (m *MockStore) DisassociateProductFromCategory(arg0 context.Context, arg1 db.DisassociateProductFromCategoryParams) (db.ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateProductFromCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisassociateProductFromCategory indicates an expected call of DisassociateProductFromCategory.
+func (mr *MockStoreMockRecorder) DisassociateProductFromCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateProductFromCategory", reflect.TypeOf((*MockStore)(nil).DisassociateProductFromCategory), arg0, arg1)
+}
+
+// DisassociateProductFromImage mocks base method.
+func (m *MockStore) DisassociateProductFromImage(arg0 context.Context, arg1 db.DisassociateProductFromImageParams) (db.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisassociateProductFromImage", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisassociateProductFromImage indicates an expected call of DisassociateProductFromImage.
+func (mr *MockStoreMockRecorder) DisassociateProductFromImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateProductFromImage", reflect.TypeOf((*MockStore)(nil).DisassociateProductFromImage), arg0, arg1)
+}
+
+// EditAssociation mocks base method.
+func (m *MockStore) EditAssociation(arg0 context.Context, arg1 db.EditAssociationParams) (db.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditAssociation", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditAssociation indicates an expected call of EditAssociation.
+func (mr *MockStoreMockRecorder) EditAssociation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditAssociation", reflect.TypeOf((*MockStore)(nil).EditAssociation), arg0, arg1)
+}
+
+// EditImageOrderTx mocks base method.
+func (m *MockStore) EditImageOrderTx(arg0 context.Context, arg1 db.ProductImagesTxParams) (db.EditImageOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditImageOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.EditImageOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditImageOrderTx indicates an expected call of EditImageOrderTx.
+func (mr *MockStoreMockRecorder) EditImageOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditImageOrderTx", reflect.TypeOf((*MockStore)(nil).EditImageOrderTx), arg0, arg1)
+}
+
+// ExecTx mocks base method.
+func (m *MockStore) ExecTx(arg0 context.Context, arg1 func(*db.Queries) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecTx indicates an expected call of ExecTx.
+func (mr *MockStoreMockRecorder) ExecTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTx", reflect.TypeOf((*MockStore)(nil).ExecTx), arg0, arg1)
+}
+
+// FilterProducts mocks base method.
+func (m *MockStore) FilterProducts(arg0 context.Context, arg1 []int64, arg2 []int64, arg3 int, arg4 int, arg5 string, arg6 string, arg7 string, arg8 bool) ([]db.Product, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterProducts", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FilterProducts indicates an expected call of FilterProducts.
+func (mr *MockStoreMockRecorder) FilterProducts(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterProducts", reflect.TypeOf((*MockStore)(nil).FilterProducts), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// FilterSales mocks base method.
+func (m *MockStore) FilterSales(arg0 context.Context, arg1 db.SaleFilter, arg2 int, arg3 int, arg4 string, arg5 string, arg6 string) ([]db.Sale, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterSales", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].([]db.Sale)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FilterSales indicates an expected call of FilterSales.
+func (mr *MockStoreMockRecorder) FilterSales(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterSales", reflect.TypeOf((*MockStore)(nil).FilterSales), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// FindClients mocks base method.
+func (m *MockStore) FindClients(arg0 context.Context, arg1 string, arg2 int) ([]db.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindClients", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindClients indicates an expected call of FindClients.
+func (mr *MockStoreMockRecorder) FindClients(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClients", reflect.TypeOf((*MockStore)(nil).FindClients), arg0, arg1, arg2)
+}
+
+// FindImages mocks base method.
+func (m *MockStore) FindImages(arg0 context.Context, arg1 string, arg2 int) ([]db.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindImages", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindImages indicates an expected call of FindImages.
+func (mr *MockStoreMockRecorder) FindImages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImages", reflect.TypeOf((*MockStore)(nil).FindImages), arg0, arg1, arg2)
+}
+
+// FindProducts mocks base method.
+func (m *MockStore) FindProducts(arg0 context.Context, arg1 string, arg2 int, arg3 bool) ([]db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProducts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProducts indicates an expected call of FindProducts.
+func (mr *MockStoreMockRecorder) FindProducts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProducts", reflect.TypeOf((*MockStore)(nil).FindProducts), arg0, arg1, arg2, arg3)
+}
+
+// FindSales mocks base method.
+func (m *MockStore) FindSales(arg0 context.Context, arg1 string, arg2 int) ([]db.Sale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSales", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.Sale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSales indicates an expected call of FindSales.
+func (mr *MockStoreMockRecorder) FindSales(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSales", reflect.TypeOf((*MockStore)(nil).FindSales), arg0, arg1, arg2)
+}
+
+// FinishScheduledPriceTx mocks base method.
+func (m *MockStore) FinishScheduledPriceTx(arg0 context.Context, arg1 db.FinishScheduledPriceTxParams) (db.ScheduledPriceTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishScheduledPriceTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduledPriceTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishScheduledPriceTx indicates an expected call of FinishScheduledPriceTx.
+func (mr *MockStoreMockRecorder) FinishScheduledPriceTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishScheduledPriceTx", reflect.TypeOf((*MockStore)(nil).FinishScheduledPriceTx), arg0, arg1)
+}
+
+// GenerateSubscriptionSaleTx mocks base method.
+func (m *MockStore) GenerateSubscriptionSaleTx(arg0 context.Context, arg1 db.GenerateSubscriptionSaleTxParams) (db.GenerateSubscriptionSaleTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSubscriptionSaleTx", arg0, arg1)
+	ret0, _ := ret[0].(db.GenerateSubscriptionSaleTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSubscriptionSaleTx indicates an expected call of GenerateSubscriptionSaleTx.
+func (mr *MockStoreMockRecorder) GenerateSubscriptionSaleTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSubscriptionSaleTx", reflect.TypeOf((*MockStore)(nil).GenerateSubscriptionSaleTx), arg0, arg1)
+}
+
+// GetAllSaleIDs mocks base method.
+func (m *MockStore) GetAllSaleIDs(arg0 context.Context) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSaleIDs", arg0)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSaleIDs indicates an expected call of GetAllSaleIDs.
+func (mr *MockStoreMockRecorder) GetAllSaleIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSaleIDs", reflect.TypeOf((*MockStore)(nil).GetAllSaleIDs), arg0)
+}
+
+// GetBrand mocks base method.
+func (m *MockStore) GetBrand(arg0 context.Context, arg1 int64) (db.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrand", arg0, arg1)
+	ret0, _ := ret[0].(db.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrand indicates an expected call of GetBrand.
+func (mr *MockStoreMockRecorder) GetBrand(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrand", reflect.TypeOf((*MockStore)(nil).GetBrand), arg0, arg1)
+}
+
+// GetBrandBySlug mocks base method.
+func (m *MockStore) GetBrandBySlug(arg0 context.Context, arg1 string) (db.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrandBySlug", arg0, arg1)
+	ret0, _ := ret[0].(db.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrandBySlug indicates an expected call of GetBrandBySlug.
+func (mr *MockStoreMockRecorder) GetBrandBySlug(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrandBySlug", reflect.TypeOf((*MockStore)(nil).GetBrandBySlug), arg0, arg1)
+}
+
+// GetCategory mocks base method.
+func (m *MockStore) GetCategory(arg0 context.Context, arg1 int64) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+}
+
+// GetCategoryBySlug mocks base method.
+func (m *MockStore) GetCategoryBySlug(arg0 context.Context, arg1 string) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryBySlug", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryBySlug indicates an expected call of GetCategoryBySlug.
+func (mr *MockStoreMockRecorder) GetCategoryBySlug(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryBySlug", reflect.TypeOf((*MockStore)(nil).GetCategoryBySlug), arg0, arg1)
+}
+
+// GetClient mocks base method.
+func (m *MockStore) GetClient(arg0 context.Context, arg1 int64) (db.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClient", arg0, arg1)
+	ret0, _ := ret[0].(db.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClient indicates an expected call of GetClient.
+func (mr *MockStoreMockRecorder) GetClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClient", reflect.TypeOf((*MockStore)(nil).GetClient), arg0, arg1)
+}
+
+// GetClientForUpdate mocks base method.
+func (m *MockStore) GetClientForUpdate(arg0 context.Context, arg1 int64) (db.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientForUpdate indicates an expected call of GetClientForUpdate.
+func (mr *MockStoreMockRecorder) GetClientForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientForUpdate", reflect.TypeOf((*MockStore)(nil).GetClientForUpdate), arg0, arg1)
+}
+
+// GetClientNote mocks base method.
+func (m *MockStore) GetClientNote(arg0 context.Context, arg1 int64) (db.ClientNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientNote", arg0, arg1)
+	ret0, _ := ret[0].(db.ClientNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientNote indicates an expected call of GetClientNote.
+func (mr *MockStoreMockRecorder) GetClientNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientNote", reflect.TypeOf((*MockStore)(nil).GetClientNote), arg0, arg1)
+}
+
+// GetCoupon mocks base method.
+func (m *MockStore) GetCoupon(arg0 context.Context, arg1 int64) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoupon", arg0, arg1)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoupon indicates an expected call of GetCoupon.
+func (mr *MockStoreMockRecorder) GetCoupon(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoupon", reflect.TypeOf((*MockStore)(nil).GetCoupon), arg0, arg1)
+}
+
+// GetCouponByCode mocks base method.
+func (m *MockStore) GetCouponByCode(arg0 context.Context, arg1 string) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCouponByCode", arg0, arg1)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCouponByCode indicates an expected call of GetCouponByCode.
+func (mr *MockStoreMockRecorder) GetCouponByCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCouponByCode", reflect.TypeOf((*MockStore)(nil).GetCouponByCode), arg0, arg1)
+}
+
+// GetCouponForUpdate mocks base method.
+func (m *MockStore) GetCouponForUpdate(arg0 context.Context, arg1 int64) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCouponForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCouponForUpdate indicates an expected call of GetCouponForUpdate.
+func (mr *MockStoreMockRecorder) GetCouponForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCouponForUpdate", reflect.TypeOf((*MockStore)(nil).GetCouponForUpdate), arg0, arg1)
+}
+
+// GetImage mocks base method.
+func (m *MockStore) GetImage(arg0 context.Context, arg1 int64) (db.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImage", arg0, arg1)
+	ret0, _ := ret[0].(db.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImage indicates an expected call of GetImage.
+func (mr *MockStoreMockRecorder) GetImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImage", reflect.TypeOf((*MockStore)(nil).GetImage), arg0, arg1)
+}
+
+// GetLoyaltyBalance mocks base method.
+func (m *MockStore) GetLoyaltyBalance(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoyaltyBalance", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoyaltyBalance indicates an expected call of GetLoyaltyBalance.
+func (mr *MockStoreMockRecorder) GetLoyaltyBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoyaltyBalance", reflect.TypeOf((*MockStore)(nil).GetLoyaltyBalance), arg0, arg1)
+}
+
+// GetMarginByCategory mocks base method.
+func (m *MockStore) GetMarginByCategory(arg0 context.Context, arg1 db.GetMarginByCategoryParams) ([]db.GetMarginByCategoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarginByCategory", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetMarginByCategoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMarginByCategory indicates an expected call of GetMarginByCategory.
+func (mr *MockStoreMockRecorder) GetMarginByCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarginByCategory", reflect.TypeOf((*MockStore)(nil).GetMarginByCategory), arg0, arg1)
+}
+
+// GetMarginByPeriod mocks base method.
+func (m *MockStore) GetMarginByPeriod(arg0 context.Context, arg1 db.GetMarginByPeriodParams) ([]db.GetMarginByPeriodRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarginByPeriod", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetMarginByPeriodRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMarginByPeriod indicates an expected call of GetMarginByPeriod.
+func (mr *MockStoreMockRecorder) GetMarginByPeriod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarginByPeriod", reflect.TypeOf((*MockStore)(nil).GetMarginByPeriod), arg0, arg1)
+}
+
+// GetMarginByProduct mocks base method.
+func (m *MockStore) GetMarginByProduct(arg0 context.Context, arg1 db.GetMarginByProductParams) ([]db.GetMarginByProductRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarginByProduct", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetMarginByProductRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMarginByProduct indicates an expected call of GetMarginByProduct.
+func (mr *MockStoreMockRecorder) GetMarginByProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarginByProduct", reflect.TypeOf((*MockStore)(nil).GetMarginByProduct), arg0, arg1)
+}
+
+// GetProduct mocks base method.
+func (m *MockStore) GetProduct(arg0 context.Context, arg1 int64) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockStoreMockRecorder) GetProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), arg0, arg1)
+}
+
+// GetProductByURL mocks base method.
+func (m *MockStore) GetProductByURL(arg0 context.Context, arg1 string) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByURL", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByURL indicates an expected call of GetProductByURL.
+func (mr *MockStoreMockRecorder) GetProductByURL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByURL", reflect.TypeOf((*MockStore)(nil).GetProductByURL), arg0, arg1)
+}
+
+// GetProductForUpdate mocks base method.
+func (m *MockStore) GetProductForUpdate(arg0 context.Context, arg1 int64) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductForUpdate indicates an expected call of GetProductForUpdate.
+func (mr *MockStoreMockRecorder) GetProductForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductForUpdate", reflect.TypeOf((*MockStore)(nil).GetProductForUpdate), arg0, arg1)
+}
+
+// GetProductPriceAt mocks base method.
+func (m *MockStore) GetProductPriceAt(arg0 context.Context, arg1 db.GetProductPriceAtParams) (db.ProductPriceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductPriceAt", arg0, arg1)
+	ret0, _ := ret[0].(db.ProductPriceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductPriceAt indicates an expected call of GetProductPriceAt.
+func (mr *MockStoreMockRecorder) GetProductPriceAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductPriceAt", reflect.TypeOf((*MockStore)(nil).GetProductPriceAt), arg0, arg1)
+}
+
+// GetPurchaseOrder mocks base method.
+func (m *MockStore) GetPurchaseOrder(arg0 context.Context, arg1 int64) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrder indicates an expected call of GetPurchaseOrder.
+func (mr *MockStoreMockRecorder) GetPurchaseOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrder", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrder), arg0, arg1)
+}
+
+// GetPurchaseOrderForUpdate mocks base method.
+func (m *MockStore) GetPurchaseOrderForUpdate(arg0 context.Context, arg1 int64) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrderForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrderForUpdate indicates an expected call of GetPurchaseOrderForUpdate.
+func (mr *MockStoreMockRecorder) GetPurchaseOrderForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrderForUpdate", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrderForUpdate), arg0, arg1)
+}
+
+// GetSale mocks base method.
+func (m *MockStore) GetSale(arg0 context.Context, arg1 int64) (db.Sale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSale", arg0, arg1)
+	ret0, _ := ret[0].(db.Sale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSale indicates an expected call of GetSale.
+func (mr *MockStoreMockRecorder) GetSale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSale", reflect.TypeOf((*MockStore)(nil).GetSale), arg0, arg1)
+}
+
+// GetSaleForUpdate mocks base method.
+func (m *MockStore) GetSaleForUpdate(arg0 context.Context, arg1 int64) (db.Sale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSaleForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Sale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSaleForUpdate indicates an expected call of GetSaleForUpdate.
+func (mr *MockStoreMockRecorder) GetSaleForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSaleForUpdate", reflect.TypeOf((*MockStore)(nil).GetSaleForUpdate), arg0, arg1)
+}
+
+// GetSalesByClientID mocks base method.
+func (m *MockStore) GetSalesByClientID(arg0 context.Context, arg1 int64) ([]db.Sale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSalesByClientID", arg0, arg1)
+	ret0, _ := ret[0].([]db.Sale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSalesByClientID indicates an expected call of GetSalesByClientID.
+func (mr *MockStoreMockRecorder) GetSalesByClientID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesByClientID", reflect.TypeOf((*MockStore)(nil).GetSalesByClientID), arg0, arg1)
+}
+
+// GetSalesByDate mocks base method.
+func (m *MockStore) GetSalesByDate(arg0 context.Context, arg1 db.GetSalesByDateParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSalesByDate", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSalesByDate indicates an expected call of GetSalesByDate.
+func (mr *MockStoreMockRecorder) GetSalesByDate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesByDate", reflect.TypeOf((*MockStore)(nil).GetSalesByDate), arg0, arg1)
+}
+
+// GetScheduledPrice mocks base method.
+func (m *MockStore) GetScheduledPrice(arg0 context.Context, arg1 int64) (db.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledPrice", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledPrice indicates an expected call of GetScheduledPrice.
+func (mr *MockStoreMockRecorder) GetScheduledPrice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledPrice", reflect.TypeOf((*MockStore)(nil).GetScheduledPrice), arg0, arg1)
+}
+
+// GetScheduledPriceForUpdate mocks base method.
+func (m *MockStore) GetScheduledPriceForUpdate(arg0 context.Context, arg1 int64) (db.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledPriceForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledPriceForUpdate indicates an expected call of GetScheduledPriceForUpdate.
+func (mr *MockStoreMockRecorder) GetScheduledPriceForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledPriceForUpdate", reflect.TypeOf((*MockStore)(nil).GetScheduledPriceForUpdate), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetSubscription mocks base method.
+func (m *MockStore) GetSubscription(arg0 context.Context, arg1 int64) (db.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", arg0, arg1)
+	ret0, _ := ret[0].(db.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockStoreMockRecorder) GetSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockStore)(nil).GetSubscription), arg0, arg1)
+}
+
+// GetSubscriptionForUpdate mocks base method.
+func (m *MockStore) GetSubscriptionForUpdate(arg0 context.Context, arg1 int64) (db.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionForUpdate indicates an expected call of GetSubscriptionForUpdate.
+func (mr *MockStoreMockRecorder) GetSubscriptionForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionForUpdate", reflect.TypeOf((*MockStore)(nil).GetSubscriptionForUpdate), arg0, arg1)
+}
+
+// GetSupplier mocks base method.
+func (m *MockStore) GetSupplier(arg0 context.Context, arg1 int64) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplier indicates an expected call of GetSupplier.
+func (mr *MockStoreMockRecorder) GetSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockStore)(nil).GetSupplier), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 int64) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockStore) GetUserByUsername(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockStoreMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), arg0, arg1)
+}
+
+// ListActiveSubscriptions mocks base method.
+func (m *MockStore) ListActiveSubscriptions(arg0 context.Context) ([]db.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveSubscriptions", arg0)
+	ret0, _ := ret[0].([]db.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveSubscriptions indicates an expected call of ListActiveSubscriptions.
+func (mr *MockStoreMockRecorder) ListActiveSubscriptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveSubscriptions", reflect.TypeOf((*MockStore)(nil).ListActiveSubscriptions), arg0)
+}
+
+// ListAllCategories mocks base method.
+func (m *MockStore) ListAllCategories(arg0 context.Context) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllCategories", arg0)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllCategories indicates an expected call of ListAllCategories.
+func (mr *MockStoreMockRecorder) ListAllCategories(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllCategories", reflect.TypeOf((*MockStore)(nil).ListAllCategories), arg0)
+}
+
+// ListAuditLogs mocks base method.
+func (m *MockStore) ListAuditLogs(arg0 context.Context, arg1 db.ListAuditLogsParams) ([]db.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].([]db.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockStoreMockRecorder) ListAuditLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockStore)(nil).ListAuditLogs), arg0, arg1)
+}
+
+// ListBrands mocks base method.
+func (m *MockStore) ListBrands(arg0 context.Context) ([]db.ListBrandsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrands", arg0)
+	ret0, _ := ret[0].([]db.ListBrandsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrands indicates an expected call of ListBrands.
+func (mr *MockStoreMockRecorder) ListBrands(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrands", reflect.TypeOf((*MockStore)(nil).ListBrands), arg0)
+}
+
+// ListCategories mocks base method.
+func (m *MockStore) ListCategories(arg0 context.Context, arg1 db.ListCategoriesParams) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockStoreMockRecorder) ListCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0, arg1)
+}
+
+// ListCategoriesByProduct mocks base method.
+func (m *MockStore) ListCategoriesByProduct(arg0 context.Context, arg1 int64) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoriesByProduct", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoriesByProduct indicates an expected call of ListCategoriesByProduct.
+func (mr *MockStoreMockRecorder) ListCategoriesByProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoriesByProduct", reflect.TypeOf((*MockStore)(nil).ListCategoriesByProduct), arg0, arg1)
+}
+
+// ListCategoriesBySale mocks base method.
+func (m *MockStore) ListCategoriesBySale(arg0 context.Context, arg1 int64) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoriesBySale", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoriesBySale indicates an expected call of ListCategoriesBySale.
+func (mr *MockStoreMockRecorder) ListCategoriesBySale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoriesBySale", reflect.TypeOf((*MockStore)(nil).ListCategoriesBySale), arg0, arg1)
+}
+
+// ListClientNotes mocks base method.
+func (m *MockStore) ListClientNotes(arg0 context.Context, arg1 int64) ([]db.ClientNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClientNotes", arg0, arg1)
+	ret0, _ := ret[0].([]db.ClientNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClientNotes indicates an expected call of ListClientNotes.
+func (mr *MockStoreMockRecorder) ListClientNotes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientNotes", reflect.TypeOf((*MockStore)(nil).ListClientNotes), arg0, arg1)
+}
+
+// ListClients mocks base method.
+func (m *MockStore) ListClients(arg0 context.Context, arg1 db.ListClientsParams) ([]db.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClients", arg0, arg1)
+	ret0, _ := ret[0].([]db.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClients indicates an expected call of ListClients.
+func (mr *MockStoreMockRecorder) ListClients(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockStore)(nil).ListClients), arg0, arg1)
+}
+
+// ListClientsPage mocks base method.
+func (m *MockStore) ListClientsPage(arg0 context.Context, arg1 db.PageParams) ([]db.Client, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClientsPage", arg0, arg1)
+	ret0, _ := ret[0].([]db.Client)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListClientsPage indicates an expected call of ListClientsPage.
+func (mr *MockStoreMockRecorder) ListClientsPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientsPage", reflect.TypeOf((*MockStore)(nil).ListClientsPage), arg0, arg1)
+}
+
+// ListClientsSorted mocks base method.
+func (m *MockStore) ListClientsSorted(arg0 context.Context, arg1 db.ListClientsParams, arg2 string, arg3 string) ([]db.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClientsSorted", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]db.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClientsSorted indicates an expected call of ListClientsSorted.
+func (mr *MockStoreMockRecorder) ListClientsSorted(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClientsSorted", reflect.TypeOf((*MockStore)(nil).ListClientsSorted), arg0, arg1, arg2, arg3)
+}
+
+// ListCoupons mocks base method.
+func (m *MockStore) ListCoupons(arg0 context.Context, arg1 db.ListCouponsParams) ([]db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCoupons", arg0, arg1)
+	ret0, _ := ret[0].([]db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCoupons indicates an expected call of ListCoupons.
+func (mr *MockStoreMockRecorder) ListCoupons(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCoupons", reflect.TypeOf((*MockStore)(nil).ListCoupons), arg0, arg1)
+}
+
+// ListDueScheduledPrices mocks base method.
+func (m *MockStore) ListDueScheduledPrices(arg0 context.Context, arg1 time.Time) ([]db.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueScheduledPrices", arg0, arg1)
+	ret0, _ := ret[0].([]db.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueScheduledPrices indicates an expected call of ListDueScheduledPrices.
+func (mr *MockStoreMockRecorder) ListDueScheduledPrices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueScheduledPrices", reflect.TypeOf((*MockStore)(nil).ListDueScheduledPrices), arg0, arg1)
+}
+
+// ListDueSubscriptions mocks base method.
+func (m *MockStore) ListDueSubscriptions(arg0 context.Context, arg1 time.Time) ([]db.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueSubscriptions", arg0, arg1)
+	ret0, _ := ret[0].([]db.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueSubscriptions indicates an expected call of ListDueSubscriptions.
+func (mr *MockStoreMockRecorder) ListDueSubscriptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueSubscriptions", reflect.TypeOf((*MockStore)(nil).ListDueSubscriptions), arg0, arg1)
+}
+
+// ListExpiredScheduledPrices mocks base method.
+func (m *MockStore) ListExpiredScheduledPrices(arg0 context.Context, arg1 time.Time) ([]db.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredScheduledPrices", arg0, arg1)
+	ret0, _ := ret[0].([]db.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredScheduledPrices indicates an expected call of ListExpiredScheduledPrices.
+func (mr *MockStoreMockRecorder) ListExpiredScheduledPrices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredScheduledPrices", reflect.TypeOf((*MockStore)(nil).ListExpiredScheduledPrices), arg0, arg1)
+}
+
+// ListImages mocks base method.
+func (m *MockStore) ListImages(arg0 context.Context, arg1 db.ListImagesParams) ([]db.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImages", arg0, arg1)
+	ret0, _ := ret[0].([]db.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImages indicates an expected call of ListImages.
+func (mr *MockStoreMockRecorder) ListImages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockStore)(nil).ListImages), arg0, arg1)
+}
+
+// ListImagesByProduct mocks base method.
+func (m *MockStore) ListImagesByProduct(arg0 context.Context, arg1 int64) ([]db.ListImagesByProductRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImagesByProduct", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListImagesByProductRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImagesByProduct indicates an expected call of ListImagesByProduct.
+func (mr *MockStoreMockRecorder) ListImagesByProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImagesByProduct", reflect.TypeOf((*MockStore)(nil).ListImagesByProduct), arg0, arg1)
+}
+
+// ListImagesByProducts mocks base method.
+func (m *MockStore) ListImagesByProducts(arg0 context.Context, arg1 []int64) ([]db.ListImagesByProductsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImagesByProducts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListImagesByProductsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImagesByProducts indicates an expected call of ListImagesByProducts.
+func (mr *MockStoreMockRecorder) ListImagesByProducts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImagesByProducts", reflect.TypeOf((*MockStore)(nil).ListImagesByProducts), arg0, arg1)
+}
+
+// ListImagesPage mocks base method.
+func (m *MockStore) ListImagesPage(arg0 context.Context, arg1 db.PageParams) ([]db.Image, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImagesPage", arg0, arg1)
+	ret0, _ := ret[0].([]db.Image)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListImagesPage indicates an expected call of ListImagesPage.
+func (mr *MockStoreMockRecorder) ListImagesPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImagesPage", reflect.TypeOf((*MockStore)(nil).ListImagesPage), arg0, arg1)
+}
+
+// ListImagesSorted mocks base method.
+func (m *MockStore) ListImagesSorted(arg0 context.Context, arg1 db.ListImagesParams, arg2 string, arg3 string) ([]db.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImagesSorted", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]db.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImagesSorted indicates an expected call of ListImagesSorted.
+func (mr *MockStoreMockRecorder) ListImagesSorted(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImagesSorted", reflect.TypeOf((*MockStore)(nil).ListImagesSorted), arg0, arg1, arg2, arg3)
+}
+
+// ListLoyaltyEntries mocks base method.
+func (m *MockStore) ListLoyaltyEntries(arg0 context.Context, arg1 int64) ([]db.LoyaltyLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoyaltyEntries", arg0, arg1)
+	ret0, _ := ret[0].([]db.LoyaltyLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoyaltyEntries indicates an expected call of ListLoyaltyEntries.
+func (mr *MockStoreMockRecorder) ListLoyaltyEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoyaltyEntries", reflect.TypeOf((*MockStore)(nil).ListLoyaltyEntries), arg0, arg1)
+}
+
+// ListNotificationsBySale mocks base method.
+func (m *MockStore) ListNotificationsBySale(arg0 context.Context, arg1 int64) ([]db.NotificationOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationsBySale", arg0, arg1)
+	ret0, _ := ret[0].([]db.NotificationOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationsBySale indicates an expected call of ListNotificationsBySale.
+func (mr *MockStoreMockRecorder) ListNotificationsBySale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationsBySale", reflect.TypeOf((*MockStore)(nil).ListNotificationsBySale), arg0, arg1)
+}
+
+// ListPinnedClientNotes mocks base method.
+func (m *MockStore) ListPinnedClientNotes(arg0 context.Context, arg1 int64) ([]db.ClientNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPinnedClientNotes", arg0, arg1)
+	ret0, _ := ret[0].([]db.ClientNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPinnedClientNotes indicates an expected call of ListPinnedClientNotes.
+func (mr *MockStoreMockRecorder) ListPinnedClientNotes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPinnedClientNotes", reflect.TypeOf((*MockStore)(nil).ListPinnedClientNotes), arg0, arg1)
+}
+
+// ListProductCategoriesByProducts mocks base method.
+func (m *MockStore) ListProductCategoriesByProducts(arg0 context.Context, arg1 []int64) ([]db.ProductCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductCategoriesByProducts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ProductCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductCategoriesByProducts indicates an expected call of ListProductCategoriesByProducts.
+func (mr *MockStoreMockRecorder) ListProductCategoriesByProducts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductCategoriesByProducts", reflect.TypeOf((*MockStore)(nil).ListProductCategoriesByProducts), arg0, arg1)
+}
+
+// ListProductPriceHistory mocks base method.
+func (m *MockStore) ListProductPriceHistory(arg0 context.Context, arg1 int64) ([]db.ProductPriceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductPriceHistory", arg0, arg1)
+	ret0, _ := ret[0].([]db.ProductPriceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductPriceHistory indicates an expected call of ListProductPriceHistory.
+func (mr *MockStoreMockRecorder) ListProductPriceHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductPriceHistory", reflect.TypeOf((*MockStore)(nil).ListProductPriceHistory), arg0, arg1)
+}
+
+// ListProductPricesAt mocks base method.
+func (m *MockStore) ListProductPricesAt(arg0 context.Context, arg1 time.Time) ([]db.ProductPriceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductPricesAt", arg0, arg1)
+	ret0, _ := ret[0].([]db.ProductPriceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductPricesAt indicates an expected call of ListProductPricesAt.
+func (mr *MockStoreMockRecorder) ListProductPricesAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductPricesAt", reflect.TypeOf((*MockStore)(nil).ListProductPricesAt), arg0, arg1)
+}
+
+// ListProducts mocks base method.
+func (m *MockStore) ListProducts(arg0 context.Context, arg1 db.ListProductsParams) ([]db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockStoreMockRecorder) ListProducts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockStore)(nil).ListProducts), arg0, arg1)
+}
+
+// ListProductsByCategory mocks base method.
+func (m *MockStore) ListProductsByCategory(arg0 context.Context, arg1 int64) ([]db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductsByCategory", arg0, arg1)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductsByCategory indicates an expected call of ListProductsByCategory.
+func (mr *MockStoreMockRecorder) ListProductsByCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByCategory", reflect.TypeOf((*MockStore)(nil).ListProductsByCategory), arg0, arg1)
+}
+
+// ListProductsByUser mocks base method.
+func (m *MockStore) ListProductsByUser(arg0 context.Context, arg1 int64) ([]db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductsByUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteImage indicates an expected call of DeleteImage.
-func (mr *MockStoreMockRecorder) DeleteImage(arg0, arg1 interface{}) *gomock.Call {
+// ListProductsByUser indicates an expected call of ListProductsByUser.
+func (mr *MockStoreMockRecorder) ListProductsByUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockStore)(nil).DeleteImage), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByUser", reflect.TypeOf((*MockStore)(nil).ListProductsByUser), arg0, arg1)
 }
 
-// DeleteProduct mocks base method.
-func (m *MockStore) DeleteProduct(arg0 context.Context, arg1 int64) error {
+// ListProductsPage mocks base method.
+func (m *MockStore) ListProductsPage(arg0 context.Context, arg1 db.ProductPageParams) ([]db.Product, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProduct", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListProductsPage", arg0, arg1)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// DeleteProduct indicates an expected call of DeleteProduct.
-func (mr *MockStoreMockRecorder) DeleteProduct(arg0, arg1 interface{}) *gomock.Call {
+// ListProductsPage indicates an expected call of ListProductsPage.
+func (mr *MockStoreMockRecorder) ListProductsPage(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockStore)(nil).DeleteProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsPage", reflect.TypeOf((*MockStore)(nil).ListProductsPage), arg0, arg1)
 }
 
-// DeleteSale mocks base method.
-func (m *MockStore) DeleteSale(arg0 context.Context, arg1 int64) error {
+// ListProductsSorted mocks base method.
+func (m *MockStore) ListProductsSorted(arg0 context.Context, arg1 db.ListProductsParams, arg2 string, arg3 string, arg4 bool) ([]db.Product, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSale", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListProductsSorted", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// DeleteSale indicates an expected call of DeleteSale.
-func (mr *MockStoreMockRecorder) DeleteSale(arg0, arg1 interface{}) *gomock.Call {
+// ListProductsSorted indicates an expected call of ListProductsSorted.
+func (mr *MockStoreMockRecorder) ListProductsSorted(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSale", reflect.TypeOf((*MockStore)(nil).DeleteSale), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsSorted", reflect.TypeOf((*MockStore)(nil).ListProductsSorted), arg0, arg1, arg2, arg3, arg4)
 }
 
-// DeleteSales mocks base method.
-func (m *MockStore) DeleteSales(arg0 context.Context, arg1 []int32) error {
+// ListPublishedProducts mocks base method.
+func (m *MockStore) ListPublishedProducts(arg0 context.Context, arg1 db.ListPublishedProductsParams) ([]db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSales", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListPublishedProducts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteSales indicates an expected call of DeleteSales.
-func (mr *MockStoreMockRecorder) DeleteSales(arg0, arg1 interface{}) *gomock.Call {
+// ListPublishedProducts indicates an expected call of ListPublishedProducts.
+func (mr *MockStoreMockRecorder) ListPublishedProducts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSales", reflect.TypeOf((*MockStore)(nil).DeleteSales), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublishedProducts", reflect.TypeOf((*MockStore)(nil).ListPublishedProducts), arg0, arg1)
 }
 
-// DeleteSliderImage mocks base method.
-func (m *MockStore) DeleteSliderImage(arg0 context.Context, arg1 int64) error {
+// ListPurchaseOrderLines mocks base method.
+func (m *MockStore) ListPurchaseOrderLines(arg0 context.Context, arg1 int64) ([]db.PurchaseOrderLine, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSliderImage", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListPurchaseOrderLines", arg0, arg1)
+	ret0, _ := ret[0].([]db.PurchaseOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteSliderImage indicates an expected call of DeleteSliderImage.
-func (mr *MockStoreMockRecorder) DeleteSliderImage(arg0, arg1 interface{}) *gomock.Call {
+// ListPurchaseOrderLines indicates an expected call of ListPurchaseOrderLines.
+func (mr *MockStoreMockRecorder) ListPurchaseOrderLines(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSliderImage", reflect.TypeOf((*MockStore)(nil).DeleteSliderImage), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrderLines", reflect.TypeOf((*MockStore)(nil).ListPurchaseOrderLines), arg0, arg1)
 }
 
-// DeleteUser mocks base method.
-func (m *MockStore) DeleteUser(arg0 context.Context, arg1 int64) error {
+// ListPurchaseOrders mocks base method.
+func (m *MockStore) ListPurchaseOrders(arg0 context.Context, arg1 db.ListPurchaseOrdersParams) ([]db.PurchaseOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListPurchaseOrders", arg0, arg1)
+	ret0, _ := ret[0].([]db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+// ListPurchaseOrders indicates an expected call of ListPurchaseOrders.
+func (mr *MockStoreMockRecorder) ListPurchaseOrders(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrders", reflect.TypeOf((*MockStore)(nil).ListPurchaseOrders), arg0, arg1)
 }
 
-// DisassociateProductFromCategory mocks base method.
-func (m *MockStore) DisassociateProductFromCategory(arg0 context.Context, arg1 db.DisassociateProductFromCategoryParams) (db.ProductCategory, error) {
+// ListSaleClientIDs mocks base method.
+func (m *MockStore) ListSaleClientIDs(arg0 context.Context) ([]int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DisassociateProductFromCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.ProductCategory)
+	ret := m.ctrl.Call(m, "ListSaleClientIDs", arg0)
+	ret0, _ := ret[0].([]int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DisassociateProductFromCategory indicates an expected call of DisassociateProductFromCategory.
-func (mr *MockStoreMockRecorder) DisassociateProductFromCategory(arg0, arg1 interface{}) *gomock.Call {
+// ListSaleClientIDs indicates an expected call of ListSaleClientIDs.
+func (mr *MockStoreMockRecorder) ListSaleClientIDs(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateProductFromCategory", reflect.TypeOf((*MockStore)(nil).DisassociateProductFromCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSaleClientIDs", reflect.TypeOf((*MockStore)(nil).ListSaleClientIDs), arg0)
 }
 
-// DisassociateProductFromImage mocks base method.
-func (m *MockStore) DisassociateProductFromImage(arg0 context.Context, arg1 db.DisassociateProductFromImageParams) (db.ProductImage, error) {
+// ListSaleDiscounts mocks base method.
+func (m *MockStore) ListSaleDiscounts(arg0 context.Context, arg1 int64) ([]db.SaleDiscount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DisassociateProductFromImage", arg0, arg1)
-	ret0, _ := ret[0].(db.ProductImage)
+	ret := m.ctrl.Call(m, "ListSaleDiscounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.SaleDiscount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DisassociateProductFromImage indicates an expected call of DisassociateProductFromImage.
-func (mr *MockStoreMockRecorder) DisassociateProductFromImage(arg0, arg1 interface{}) *gomock.Call {
+// ListSaleDiscounts indicates an expected call of ListSaleDiscounts.
+func (mr *MockStoreMockRecorder) ListSaleDiscounts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisassociateProductFromImage", reflect.TypeOf((*MockStore)(nil).DisassociateProductFromImage), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSaleDiscounts", reflect.TypeOf((*MockStore)(nil).ListSaleDiscounts), arg0, arg1)
 }
 
-// EditAssociation mocks base method.
-func (m *MockStore) EditAssociation(arg0 context.Context, arg1 db.EditAssociationParams) (db.ProductImage, error) {
+// ListSaleDiscountsByClient mocks base method.
+func (m *MockStore) ListSaleDiscountsByClient(arg0 context.Context, arg1 int64) ([]db.SaleDiscount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditAssociation", arg0, arg1)
-	ret0, _ := ret[0].(db.ProductImage)
+	ret := m.ctrl.Call(m, "ListSaleDiscountsByClient", arg0, arg1)
+	ret0, _ := ret[0].([]db.SaleDiscount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// EditAssociation indicates an expected call of EditAssociation.
-func (mr *MockStoreMockRecorder) EditAssociation(arg0, arg1 interface{}) *gomock.Call {
+// ListSaleDiscountsByClient indicates an expected call of ListSaleDiscountsByClient.
+func (mr *MockStoreMockRecorder) ListSaleDiscountsByClient(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditAssociation", reflect.TypeOf((*MockStore)(nil).EditAssociation), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSaleDiscountsByClient", reflect.TypeOf((*MockStore)(nil).ListSaleDiscountsByClient), arg0, arg1)
 }
 
-// GetAllSaleIDs mocks base method.
-func (m *MockStore) GetAllSaleIDs(arg0 context.Context) ([]int64, error) {
+// ListSaleItems mocks base method.
+func (m *MockStore) ListSaleItems(arg0 context.Context, arg1 int64) ([]db.SaleItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllSaleIDs", arg0)
-	ret0, _ := ret[0].([]int64)
+	ret := m.ctrl.Call(m, "ListSaleItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.SaleItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllSaleIDs indicates an expected call of GetAllSaleIDs.
-func (mr *MockStoreMockRecorder) GetAllSaleIDs(arg0 interface{}) *gomock.Call {
+// ListSaleItems indicates an expected call of ListSaleItems.
+func (mr *MockStoreMockRecorder) ListSaleItems(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSaleIDs", reflect.TypeOf((*MockStore)(nil).GetAllSaleIDs), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSaleItems", reflect.TypeOf((*MockStore)(nil).ListSaleItems), arg0, arg1)
 }
 
-// GetCategory mocks base method.
-func (m *MockStore) GetCategory(arg0 context.Context, arg1 int64) (db.Category, error) {
+// ListSaleStatusHistory mocks base method.
+func (m *MockStore) ListSaleStatusHistory(arg0 context.Context, arg1 int64) ([]db.SaleStatusHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.Category)
+	ret := m.ctrl.Call(m, "ListSaleStatusHistory", arg0, arg1)
+	ret0, _ := ret[0].([]db.SaleStatusHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategory indicates an expected call of GetCategory.
-func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 interface{}) *gomock.Call {
+// ListSaleStatusHistory indicates an expected call of ListSaleStatusHistory.
+func (mr *MockStoreMockRecorder) ListSaleStatusHistory(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSaleStatusHistory", reflect.TypeOf((*MockStore)(nil).ListSaleStatusHistory), arg0, arg1)
 }
 
-// GetClient mocks base method.
-func (m *MockStore) GetClient(arg0 context.Context, arg1 int64) (db.Client, error) {
+// ListSaleStatusHistoryByClient mocks base method.
+func (m *MockStore) ListSaleStatusHistoryByClient(arg0 context.Context, arg1 int64) ([]db.SaleStatusHistory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClient", arg0, arg1)
-	ret0, _ := ret[0].(db.Client)
+	ret := m.ctrl.Call(m, "ListSaleStatusHistoryByClient", arg0, arg1)
+	ret0, _ := ret[0].([]db.SaleStatusHistory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClient indicates an expected call of GetClient.
-func (mr *MockStoreMockRecorder) GetClient(arg0, arg1 interface{}) *gomock.Call {
+// ListSaleStatusHistoryByClient indicates an expected call of ListSaleStatusHistoryByClient.
+func (mr *MockStoreMockRecorder) ListSaleStatusHistoryByClient(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClient", reflect.TypeOf((*MockStore)(nil).GetClient), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSaleStatusHistoryByClient", reflect.TypeOf((*MockStore)(nil).ListSaleStatusHistoryByClient), arg0, arg1)
 }
 
-// GetImage mocks base method.
-func (m *MockStore) GetImage(arg0 context.Context, arg1 int64) (db.Image, error) {
+// ListSales mocks base method.
+func (m *MockStore) ListSales(arg0 context.Context, arg1 db.ListSalesParams) ([]db.Sale, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetImage", arg0, arg1)
-	ret0, _ := ret[0].(db.Image)
+	ret := m.ctrl.Call(m, "ListSales", arg0, arg1)
+	ret0, _ := ret[0].([]db.Sale)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetImage indicates an expected call of GetImage.
-func (mr *MockStoreMockRecorder) GetImage(arg0, arg1 interface{}) *gomock.Call {
+// ListSales indicates an expected call of ListSales.
+func (mr *MockStoreMockRecorder) ListSales(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImage", reflect.TypeOf((*MockStore)(nil).GetImage), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSales", reflect.TypeOf((*MockStore)(nil).ListSales), arg0, arg1)
 }
 
-// GetProduct mocks base method.
-func (m *MockStore) GetProduct(arg0 context.Context, arg1 int64) (db.Product, error) {
+// ListSalesPage mocks base method.
+func (m *MockStore) ListSalesPage(arg0 context.Context, arg1 db.SalePageParams) ([]db.Sale, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
-	ret0, _ := ret[0].(db.Product)
+	ret := m.ctrl.Call(m, "ListSalesPage", arg0, arg1)
+	ret0, _ := ret[0].([]db.Sale)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSalesPage indicates an expected call of ListSalesPage.
+func (mr *MockStoreMockRecorder) ListSalesPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSalesPage", reflect.TypeOf((*MockStore)(nil).ListSalesPage), arg0, arg1)
+}
+
+// ListSalesSorted mocks base method.
+func (m *MockStore) ListSalesSorted(arg0 context.Context, arg1 db.ListSalesParams, arg2 string, arg3 string) ([]db.Sale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSalesSorted", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]db.Sale)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProduct indicates an expected call of GetProduct.
-func (mr *MockStoreMockRecorder) GetProduct(arg0, arg1 interface{}) *gomock.Call {
+// ListSalesSorted indicates an expected call of ListSalesSorted.
+func (mr *MockStoreMockRecorder) ListSalesSorted(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSalesSorted", reflect.TypeOf((*MockStore)(nil).ListSalesSorted), arg0, arg1, arg2, arg3)
 }
 
-// GetProductByURL mocks base method.
-func (m *MockStore) GetProductByURL(arg0 context.Context, arg1 string) (db.Product, error) {
+// ListScheduledPricesByProduct mocks base method.
+func (m *MockStore) ListScheduledPricesByProduct(arg0 context.Context, arg1 int64) ([]db.ScheduledPrice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductByURL", arg0, arg1)
-	ret0, _ := ret[0].(db.Product)
+	ret := m.ctrl.Call(m, "ListScheduledPricesByProduct", arg0, arg1)
+	ret0, _ := ret[0].([]db.ScheduledPrice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductByURL indicates an expected call of GetProductByURL.
-func (mr *MockStoreMockRecorder) GetProductByURL(arg0, arg1 interface{}) *gomock.Call {
+// ListScheduledPricesByProduct indicates an expected call of ListScheduledPricesByProduct.
+func (mr *MockStoreMockRecorder) ListScheduledPricesByProduct(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByURL", reflect.TypeOf((*MockStore)(nil).GetProductByURL), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledPricesByProduct", reflect.TypeOf((*MockStore)(nil).ListScheduledPricesByProduct), arg0, arg1)
 }
 
-// GetSale mocks base method.
-func (m *MockStore) GetSale(arg0 context.Context, arg1 int64) (db.Sale, error) {
+// ListSessionsByUsername mocks base method.
+func (m *MockStore) ListSessionsByUsername(arg0 context.Context, arg1 string) ([]db.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSale", arg0, arg1)
-	ret0, _ := ret[0].(db.Sale)
+	ret := m.ctrl.Call(m, "ListSessionsByUsername", arg0, arg1)
+	ret0, _ := ret[0].([]db.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSale indicates an expected call of GetSale.
-func (mr *MockStoreMockRecorder) GetSale(arg0, arg1 interface{}) *gomock.Call {
+// ListSessionsByUsername indicates an expected call of ListSessionsByUsername.
+func (mr *MockStoreMockRecorder) ListSessionsByUsername(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSale", reflect.TypeOf((*MockStore)(nil).GetSale), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionsByUsername", reflect.TypeOf((*MockStore)(nil).ListSessionsByUsername), arg0, arg1)
 }
 
-// GetSalesByClientID mocks base method.
-func (m *MockStore) GetSalesByClientID(arg0 context.Context, arg1 int64) ([]db.Sale, error) {
+// ListSliderImages mocks base method.
+func (m *MockStore) ListSliderImages(arg0 context.Context, arg1 db.ListSliderImagesParams) ([]db.SliderImageWidget, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSalesByClientID", arg0, arg1)
-	ret0, _ := ret[0].([]db.Sale)
+	ret := m.ctrl.Call(m, "ListSliderImages", arg0, arg1)
+	ret0, _ := ret[0].([]db.SliderImageWidget)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSalesByClientID indicates an expected call of GetSalesByClientID.
-func (mr *MockStoreMockRecorder) GetSalesByClientID(arg0, arg1 interface{}) *gomock.Call {
+// ListSliderImages indicates an expected call of ListSliderImages.
+func (mr *MockStoreMockRecorder) ListSliderImages(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesByClientID", reflect.TypeOf((*MockStore)(nil).GetSalesByClientID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSliderImages", reflect.TypeOf((*MockStore)(nil).ListSliderImages), arg0, arg1)
 }
 
-// GetSalesByDate mocks base method.
-func (m *MockStore) GetSalesByDate(arg0 context.Context, arg1 db.GetSalesByDateParams) ([]int64, error) {
+// ListSubscriptionItems mocks base method.
+func (m *MockStore) ListSubscriptionItems(arg0 context.Context, arg1 int64) ([]db.SubscriptionItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSalesByDate", arg0, arg1)
-	ret0, _ := ret[0].([]int64)
+	ret := m.ctrl.Call(m, "ListSubscriptionItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.SubscriptionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptionItems indicates an expected call of ListSubscriptionItems.
+func (mr *MockStoreMockRecorder) ListSubscriptionItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionItems", reflect.TypeOf((*MockStore)(nil).ListSubscriptionItems), arg0, arg1)
+}
+
+// ListSubscriptionRuns mocks base method.
+func (m *MockStore) ListSubscriptionRuns(arg0 context.Context, arg1 int64) ([]db.SubscriptionRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptionRuns", arg0, arg1)
+	ret0, _ := ret[0].([]db.SubscriptionRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptionRuns indicates an expected call of ListSubscriptionRuns.
+func (mr *MockStoreMockRecorder) ListSubscriptionRuns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionRuns", reflect.TypeOf((*MockStore)(nil).ListSubscriptionRuns), arg0, arg1)
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockStore) ListSubscriptions(arg0 context.Context, arg1 db.ListSubscriptionsParams) ([]db.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", arg0, arg1)
+	ret0, _ := ret[0].([]db.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockStoreMockRecorder) ListSubscriptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockStore)(nil).ListSubscriptions), arg0, arg1)
+}
+
+// ListSubscriptionsByClient mocks base method.
+func (m *MockStore) ListSubscriptionsByClient(arg0 context.Context, arg1 int64) ([]db.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptionsByClient", arg0, arg1)
+	ret0, _ := ret[0].([]db.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptionsByClient indicates an expected call of ListSubscriptionsByClient.
+func (mr *MockStoreMockRecorder) ListSubscriptionsByClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionsByClient", reflect.TypeOf((*MockStore)(nil).ListSubscriptionsByClient), arg0, arg1)
+}
+
+// ListSuppliers mocks base method.
+func (m *MockStore) ListSuppliers(arg0 context.Context, arg1 db.ListSuppliersParams) ([]db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSuppliers", arg0, arg1)
+	ret0, _ := ret[0].([]db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSuppliers indicates an expected call of ListSuppliers.
+func (mr *MockStoreMockRecorder) ListSuppliers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuppliers", reflect.TypeOf((*MockStore)(nil).ListSuppliers), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
+}
+
+// MarkNotificationFailed mocks base method.
+func (m *MockStore) MarkNotificationFailed(arg0 context.Context, arg1 db.MarkNotificationFailedParams) (db.NotificationOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationFailed", arg0, arg1)
+	ret0, _ := ret[0].(db.NotificationOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationFailed indicates an expected call of MarkNotificationFailed.
+func (mr *MockStoreMockRecorder) MarkNotificationFailed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationFailed", reflect.TypeOf((*MockStore)(nil).MarkNotificationFailed), arg0, arg1)
+}
+
+// MarkNotificationSent mocks base method.
+func (m *MockStore) MarkNotificationSent(arg0 context.Context, arg1 int64) (db.NotificationOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationSent", arg0, arg1)
+	ret0, _ := ret[0].(db.NotificationOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationSent indicates an expected call of MarkNotificationSent.
+func (mr *MockStoreMockRecorder) MarkNotificationSent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationSent", reflect.TypeOf((*MockStore)(nil).MarkNotificationSent), arg0, arg1)
+}
+
+// MoveChildCategories mocks base method.
+func (m *MockStore) MoveChildCategories(arg0 context.Context, arg1 db.MoveChildCategoriesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveChildCategories", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveChildCategories indicates an expected call of MoveChildCategories.
+func (mr *MockStoreMockRecorder) MoveChildCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveChildCategories", reflect.TypeOf((*MockStore)(nil).MoveChildCategories), arg0, arg1)
+}
+
+// ProductFacets mocks base method.
+func (m *MockStore) ProductFacets(arg0 context.Context, arg1 []int64, arg2 []int64, arg3 string, arg4 bool) (db.ProductFacets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductFacets", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(db.ProductFacets)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSalesByDate indicates an expected call of GetSalesByDate.
-func (mr *MockStoreMockRecorder) GetSalesByDate(arg0, arg1 interface{}) *gomock.Call {
+// ProductFacets indicates an expected call of ProductFacets.
+func (mr *MockStoreMockRecorder) ProductFacets(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesByDate", reflect.TypeOf((*MockStore)(nil).GetSalesByDate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductFacets", reflect.TypeOf((*MockStore)(nil).ProductFacets), arg0, arg1, arg2, arg3, arg4)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+// ReassignClientNotes mocks base method.
+func (m *MockStore) ReassignClientNotes(arg0 context.Context, arg1 db.ReassignClientNotesParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ReassignClientNotes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+// ReassignClientNotes indicates an expected call of ReassignClientNotes.
+func (mr *MockStoreMockRecorder) ReassignClientNotes(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignClientNotes", reflect.TypeOf((*MockStore)(nil).ReassignClientNotes), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 int64) (db.User, error) {
+// ReassignLoyaltyEntries mocks base method.
+func (m *MockStore) ReassignLoyaltyEntries(arg0 context.Context, arg1 db.ReassignLoyaltyEntriesParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ReassignLoyaltyEntries", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// ReassignLoyaltyEntries indicates an expected call of ReassignLoyaltyEntries.
+func (mr *MockStoreMockRecorder) ReassignLoyaltyEntries(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignLoyaltyEntries", reflect.TypeOf((*MockStore)(nil).ReassignLoyaltyEntries), arg0, arg1)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+// ReassignProductsUser mocks base method.
+func (m *MockStore) ReassignProductsUser(arg0 context.Context, arg1 db.ReassignProductsUserParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ReassignProductsUser", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+// ReassignProductsUser indicates an expected call of ReassignProductsUser.
+func (mr *MockStoreMockRecorder) ReassignProductsUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignProductsUser", reflect.TypeOf((*MockStore)(nil).ReassignProductsUser), arg0, arg1)
 }
 
-// GetUserByUsername mocks base method.
-func (m *MockStore) GetUserByUsername(arg0 context.Context, arg1 string) (db.User, error) {
+// ReceivePurchaseOrderLine mocks base method.
+func (m *MockStore) ReceivePurchaseOrderLine(arg0 context.Context, arg1 db.ReceivePurchaseOrderLineParams) (db.PurchaseOrderLine, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ReceivePurchaseOrderLine", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderLine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByUsername indicates an expected call of GetUserByUsername.
-func (mr *MockStoreMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+// ReceivePurchaseOrderLine indicates an expected call of ReceivePurchaseOrderLine.
+func (mr *MockStoreMockRecorder) ReceivePurchaseOrderLine(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceivePurchaseOrderLine", reflect.TypeOf((*MockStore)(nil).ReceivePurchaseOrderLine), arg0, arg1)
 }
 
-// ListCategories mocks base method.
-func (m *MockStore) ListCategories(arg0 context.Context, arg1 db.ListCategoriesParams) ([]db.Category, error) {
+// ReceivePurchaseOrderTx mocks base method.
+func (m *MockStore) ReceivePurchaseOrderTx(arg0 context.Context, arg1 db.ReceivePurchaseOrderTxParams) (db.PurchaseOrderTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCategories", arg0, arg1)
-	ret0, _ := ret[0].([]db.Category)
+	ret := m.ctrl.Call(m, "ReceivePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCategories indicates an expected call of ListCategories.
-func (mr *MockStoreMockRecorder) ListCategories(arg0, arg1 interface{}) *gomock.Call {
+// ReceivePurchaseOrderTx indicates an expected call of ReceivePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) ReceivePurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceivePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).ReceivePurchaseOrderTx), arg0, arg1)
 }
 
-// ListCategoriesByProduct mocks base method.
-func (m *MockStore) ListCategoriesByProduct(arg0 context.Context, arg1 int64) ([]db.Category, error) {
+// RestoreProduct mocks base method.
+func (m *MockStore) RestoreProduct(arg0 context.Context, arg1 int64) (db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCategoriesByProduct", arg0, arg1)
-	ret0, _ := ret[0].([]db.Category)
+	ret := m.ctrl.Call(m, "RestoreProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCategoriesByProduct indicates an expected call of ListCategoriesByProduct.
-func (mr *MockStoreMockRecorder) ListCategoriesByProduct(arg0, arg1 interface{}) *gomock.Call {
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockStoreMockRecorder) RestoreProduct(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoriesByProduct", reflect.TypeOf((*MockStore)(nil).ListCategoriesByProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockStore)(nil).RestoreProduct), arg0, arg1)
 }
 
-// ListClients mocks base method.
-func (m *MockStore) ListClients(arg0 context.Context, arg1 db.ListClientsParams) ([]db.Client, error) {
+// SearchClients mocks base method.
+func (m *MockStore) SearchClients(arg0 context.Context, arg1 string, arg2 int, arg3 int, arg4 string, arg5 string) ([]db.Client, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListClients", arg0, arg1)
+	ret := m.ctrl.Call(m, "SearchClients", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].([]db.Client)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// ListClients indicates an expected call of ListClients.
-func (mr *MockStoreMockRecorder) ListClients(arg0, arg1 interface{}) *gomock.Call {
+// SearchClients indicates an expected call of SearchClients.
+func (mr *MockStoreMockRecorder) SearchClients(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClients", reflect.TypeOf((*MockStore)(nil).ListClients), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchClients", reflect.TypeOf((*MockStore)(nil).SearchClients), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
-// ListImages mocks base method.
-func (m *MockStore) ListImages(arg0 context.Context, arg1 db.ListImagesParams) ([]db.Image, error) {
+// SearchImages mocks base method.
+func (m *MockStore) SearchImages(arg0 context.Context, arg1 string, arg2 int, arg3 int, arg4 string, arg5 string) ([]db.Image, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListImages", arg0, arg1)
+	ret := m.ctrl.Call(m, "SearchImages", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].([]db.Image)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// ListImages indicates an expected call of ListImages.
-func (mr *MockStoreMockRecorder) ListImages(arg0, arg1 interface{}) *gomock.Call {
+// SearchImages indicates an expected call of SearchImages.
+func (mr *MockStoreMockRecorder) SearchImages(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockStore)(nil).ListImages), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchImages", reflect.TypeOf((*MockStore)(nil).SearchImages), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
-// ListImagesByProduct mocks base method.
-func (m *MockStore) ListImagesByProduct(arg0 context.Context, arg1 int64) ([]db.ListImagesByProductRow, error) {
+// SearchProducts mocks base method.
+func (m *MockStore) SearchProducts(arg0 context.Context, arg1 string, arg2 int, arg3 int, arg4 string, arg5 string, arg6 bool) ([]db.Product, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListImagesByProduct", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListImagesByProductRow)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SearchProducts", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// ListImagesByProduct indicates an expected call of ListImagesByProduct.
-func (mr *MockStoreMockRecorder) ListImagesByProduct(arg0, arg1 interface{}) *gomock.Call {
+// SearchProducts indicates an expected call of SearchProducts.
+func (mr *MockStoreMockRecorder) SearchProducts(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImagesByProduct", reflect.TypeOf((*MockStore)(nil).ListImagesByProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockStore)(nil).SearchProducts), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
-// ListProducts mocks base method.
-func (m *MockStore) ListProducts(arg0 context.Context, arg1 db.ListProductsParams) ([]db.Product, error) {
+// SearchSales mocks base method.
+func (m *MockStore) SearchSales(arg0 context.Context, arg1 string, arg2 int, arg3 int, arg4 string, arg5 string) ([]db.Sale, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProducts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Product)
+	ret := m.ctrl.Call(m, "SearchSales", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]db.Sale)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchSales indicates an expected call of SearchSales.
+func (mr *MockStoreMockRecorder) SearchSales(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSales", reflect.TypeOf((*MockStore)(nil).SearchSales), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// SendPurchaseOrderTx mocks base method.
+func (m *MockStore) SendPurchaseOrderTx(arg0 context.Context, arg1 int64) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProducts indicates an expected call of ListProducts.
-func (mr *MockStoreMockRecorder) ListProducts(arg0, arg1 interface{}) *gomock.Call {
+// SendPurchaseOrderTx indicates an expected call of SendPurchaseOrderTx.
+func (mr *MockStoreMockRecorder) SendPurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockStore)(nil).ListProducts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).SendPurchaseOrderTx), arg0, arg1)
 }
 
-// ListProductsByCategory mocks base method.
-func (m *MockStore) ListProductsByCategory(arg0 context.Context, arg1 int64) ([]db.Product, error) {
+// SetProductCategoriesTx mocks base method.
+func (m *MockStore) SetProductCategoriesTx(arg0 context.Context, arg1 db.ProductCategoriesTxParams) (db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProductsByCategory", arg0, arg1)
-	ret0, _ := ret[0].([]db.Product)
+	ret := m.ctrl.Call(m, "SetProductCategoriesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProductsByCategory indicates an expected call of ListProductsByCategory.
-func (mr *MockStoreMockRecorder) ListProductsByCategory(arg0, arg1 interface{}) *gomock.Call {
+// SetProductCategoriesTx indicates an expected call of SetProductCategoriesTx.
+func (mr *MockStoreMockRecorder) SetProductCategoriesTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByCategory", reflect.TypeOf((*MockStore)(nil).ListProductsByCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductCategoriesTx", reflect.TypeOf((*MockStore)(nil).SetProductCategoriesTx), arg0, arg1)
 }
 
-// ListProductsByUser mocks base method.
-func (m *MockStore) ListProductsByUser(arg0 context.Context, arg1 int64) ([]db.Product, error) {
+// SoftDeleteProduct mocks base method.
+func (m *MockStore) SoftDeleteProduct(arg0 context.Context, arg1 int64) (db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProductsByUser", arg0, arg1)
-	ret0, _ := ret[0].([]db.Product)
+	ret := m.ctrl.Call(m, "SoftDeleteProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProductsByUser indicates an expected call of ListProductsByUser.
-func (mr *MockStoreMockRecorder) ListProductsByUser(arg0, arg1 interface{}) *gomock.Call {
+// SoftDeleteProduct indicates an expected call of SoftDeleteProduct.
+func (mr *MockStoreMockRecorder) SoftDeleteProduct(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByUser", reflect.TypeOf((*MockStore)(nil).ListProductsByUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteProduct", reflect.TypeOf((*MockStore)(nil).SoftDeleteProduct), arg0, arg1)
 }
 
-// ListSales mocks base method.
-func (m *MockStore) ListSales(arg0 context.Context, arg1 db.ListSalesParams) ([]db.Sale, error) {
+// StartScheduledPriceTx mocks base method.
+func (m *MockStore) StartScheduledPriceTx(arg0 context.Context, arg1 int64) (db.ScheduledPriceTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSales", arg0, arg1)
-	ret0, _ := ret[0].([]db.Sale)
+	ret := m.ctrl.Call(m, "StartScheduledPriceTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduledPriceTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListSales indicates an expected call of ListSales.
-func (mr *MockStoreMockRecorder) ListSales(arg0, arg1 interface{}) *gomock.Call {
+// StartScheduledPriceTx indicates an expected call of StartScheduledPriceTx.
+func (mr *MockStoreMockRecorder) StartScheduledPriceTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSales", reflect.TypeOf((*MockStore)(nil).ListSales), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartScheduledPriceTx", reflect.TypeOf((*MockStore)(nil).StartScheduledPriceTx), arg0, arg1)
 }
 
-// ListSessionsByUsername mocks base method.
-func (m *MockStore) ListSessionsByUsername(arg0 context.Context, arg1 string) ([]db.Session, error) {
+// SumLoyaltyPointsBySale mocks base method.
+func (m *MockStore) SumLoyaltyPointsBySale(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSessionsByUsername", arg0, arg1)
-	ret0, _ := ret[0].([]db.Session)
+	ret := m.ctrl.Call(m, "SumLoyaltyPointsBySale", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListSessionsByUsername indicates an expected call of ListSessionsByUsername.
-func (mr *MockStoreMockRecorder) ListSessionsByUsername(arg0, arg1 interface{}) *gomock.Call {
+// SumLoyaltyPointsBySale indicates an expected call of SumLoyaltyPointsBySale.
+func (mr *MockStoreMockRecorder) SumLoyaltyPointsBySale(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionsByUsername", reflect.TypeOf((*MockStore)(nil).ListSessionsByUsername), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumLoyaltyPointsBySale", reflect.TypeOf((*MockStore)(nil).SumLoyaltyPointsBySale), arg0, arg1)
 }
 
-// ListSliderImages mocks base method.
-func (m *MockStore) ListSliderImages(arg0 context.Context, arg1 db.ListSliderImagesParams) ([]db.SliderImageWidget, error) {
+// TouchProduct mocks base method.
+func (m *MockStore) TouchProduct(arg0 context.Context, arg1 int64) (db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSliderImages", arg0, arg1)
-	ret0, _ := ret[0].([]db.SliderImageWidget)
+	ret := m.ctrl.Call(m, "TouchProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListSliderImages indicates an expected call of ListSliderImages.
-func (mr *MockStoreMockRecorder) ListSliderImages(arg0, arg1 interface{}) *gomock.Call {
+// TouchProduct indicates an expected call of TouchProduct.
+func (mr *MockStoreMockRecorder) TouchProduct(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSliderImages", reflect.TypeOf((*MockStore)(nil).ListSliderImages), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchProduct", reflect.TypeOf((*MockStore)(nil).TouchProduct), arg0, arg1)
 }
 
-// ListUsers mocks base method.
-func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
+// UpdateBrand mocks base method.
+func (m *MockStore) UpdateBrand(arg0 context.Context, arg1 db.UpdateBrandParams) (db.Brand, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
-	ret0, _ := ret[0].([]db.User)
+	ret := m.ctrl.Call(m, "UpdateBrand", arg0, arg1)
+	ret0, _ := ret[0].(db.Brand)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
+// UpdateBrand indicates an expected call of UpdateBrand.
+func (mr *MockStoreMockRecorder) UpdateBrand(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBrand", reflect.TypeOf((*MockStore)(nil).UpdateBrand), arg0, arg1)
 }
 
 // UpdateCategory mocks base method.
@@ -837,6 +3066,36 @@ func (mr *MockStoreMockRecorder) UpdateClient(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClient", reflect.TypeOf((*MockStore)(nil).UpdateClient), arg0, arg1)
 }
 
+// UpdateClientNote mocks base method.
+func (m *MockStore) UpdateClientNote(arg0 context.Context, arg1 db.UpdateClientNoteParams) (db.ClientNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClientNote", arg0, arg1)
+	ret0, _ := ret[0].(db.ClientNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateClientNote indicates an expected call of UpdateClientNote.
+func (mr *MockStoreMockRecorder) UpdateClientNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClientNote", reflect.TypeOf((*MockStore)(nil).UpdateClientNote), arg0, arg1)
+}
+
+// UpdateCoupon mocks base method.
+func (m *MockStore) UpdateCoupon(arg0 context.Context, arg1 db.UpdateCouponParams) (db.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCoupon", arg0, arg1)
+	ret0, _ := ret[0].(db.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCoupon indicates an expected call of UpdateCoupon.
+func (mr *MockStoreMockRecorder) UpdateCoupon(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCoupon", reflect.TypeOf((*MockStore)(nil).UpdateCoupon), arg0, arg1)
+}
+
 // UpdateImage mocks base method.
 func (m *MockStore) UpdateImage(arg0 context.Context, arg1 db.UpdateImageParams) (db.Image, error) {
 	m.ctrl.T.Helper()
@@ -867,6 +3126,95 @@ func (mr *MockStoreMockRecorder) UpdateProduct(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockStore)(nil).UpdateProduct), arg0, arg1)
 }
 
+// UpdateProductLastCost mocks base method.
+func (m *MockStore) UpdateProductLastCost(arg0 context.Context, arg1 db.UpdateProductLastCostParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductLastCost", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProductLastCost indicates an expected call of UpdateProductLastCost.
+func (mr *MockStoreMockRecorder) UpdateProductLastCost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductLastCost", reflect.TypeOf((*MockStore)(nil).UpdateProductLastCost), arg0, arg1)
+}
+
+// UpdateProductPricing mocks base method.
+func (m *MockStore) UpdateProductPricing(arg0 context.Context, arg1 db.UpdateProductPricingParams) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductPricing", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductPricing indicates an expected call of UpdateProductPricing.
+func (mr *MockStoreMockRecorder) UpdateProductPricing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductPricing", reflect.TypeOf((*MockStore)(nil).UpdateProductPricing), arg0, arg1)
+}
+
+// UpdateProductTx mocks base method.
+func (m *MockStore) UpdateProductTx(arg0 context.Context, arg1 db.UpdateProductTxParams) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProductTx indicates an expected call of UpdateProductTx.
+func (mr *MockStoreMockRecorder) UpdateProductTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductTx", reflect.TypeOf((*MockStore)(nil).UpdateProductTx), arg0, arg1)
+}
+
+// UpdatePurchaseOrder mocks base method.
+func (m *MockStore) UpdatePurchaseOrder(arg0 context.Context, arg1 db.UpdatePurchaseOrderParams) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePurchaseOrder indicates an expected call of UpdatePurchaseOrder.
+func (mr *MockStoreMockRecorder) UpdatePurchaseOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrder", reflect.TypeOf((*MockStore)(nil).UpdatePurchaseOrder), arg0, arg1)
+}
+
+// UpdatePurchaseOrderStatus mocks base method.
+func (m *MockStore) UpdatePurchaseOrderStatus(arg0 context.Context, arg1 db.UpdatePurchaseOrderStatusParams) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrderStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePurchaseOrderStatus indicates an expected call of UpdatePurchaseOrderStatus.
+func (mr *MockStoreMockRecorder) UpdatePurchaseOrderStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrderStatus", reflect.TypeOf((*MockStore)(nil).UpdatePurchaseOrderStatus), arg0, arg1)
+}
+
+// UpdatePurchaseOrderTx mocks base method.
+func (m *MockStore) UpdatePurchaseOrderTx(arg0 context.Context, arg1 db.UpdatePurchaseOrderTxParams) (db.PurchaseOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePurchaseOrderTx indicates an expected call of UpdatePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) UpdatePurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).UpdatePurchaseOrderTx), arg0, arg1)
+}
+
 // UpdateSale mocks base method.
 func (m *MockStore) UpdateSale(arg0 context.Context, arg1 db.UpdateSaleParams) (db.Sale, error) {
 	m.ctrl.T.Helper()
@@ -882,6 +3230,36 @@ func (mr *MockStoreMockRecorder) UpdateSale(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSale", reflect.TypeOf((*MockStore)(nil).UpdateSale), arg0, arg1)
 }
 
+// UpdateSaleTx mocks base method.
+func (m *MockStore) UpdateSaleTx(arg0 context.Context, arg1 db.UpdateSaleTxParams) (db.Sale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSaleTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Sale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSaleTx indicates an expected call of UpdateSaleTx.
+func (mr *MockStoreMockRecorder) UpdateSaleTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSaleTx", reflect.TypeOf((*MockStore)(nil).UpdateSaleTx), arg0, arg1)
+}
+
+// UpdateScheduledPriceStatus mocks base method.
+func (m *MockStore) UpdateScheduledPriceStatus(arg0 context.Context, arg1 db.UpdateScheduledPriceStatusParams) (db.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledPriceStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledPriceStatus indicates an expected call of UpdateScheduledPriceStatus.
+func (mr *MockStoreMockRecorder) UpdateScheduledPriceStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledPriceStatus", reflect.TypeOf((*MockStore)(nil).UpdateScheduledPriceStatus), arg0, arg1)
+}
+
 // UpdateSession mocks base method.
 func (m *MockStore) UpdateSession(arg0 context.Context, arg1 db.UpdateSessionParams) (db.Session, error) {
 	m.ctrl.T.Helper()
@@ -942,6 +3320,65 @@ func (mr *MockStoreMockRecorder) UpdateSliderImageByImageId(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSliderImageByImageId", reflect.TypeOf((*MockStore)(nil).UpdateSliderImageByImageId), arg0, arg1)
 }
 
+// UpdateSubscription mocks base method.
+func (m *MockStore) UpdateSubscription(arg0 context.Context, arg1 db.UpdateSubscriptionParams) (db.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", arg0, arg1)
+	ret0, _ := ret[0].(db.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockStoreMockRecorder) UpdateSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockStore)(nil).UpdateSubscription), arg0, arg1)
+}
+
+// UpdateSubscriptionNextRun mocks base method.
+func (m *MockStore) UpdateSubscriptionNextRun(arg0 context.Context, arg1 db.UpdateSubscriptionNextRunParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionNextRun", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscriptionNextRun indicates an expected call of UpdateSubscriptionNextRun.
+func (mr *MockStoreMockRecorder) UpdateSubscriptionNextRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionNextRun", reflect.TypeOf((*MockStore)(nil).UpdateSubscriptionNextRun), arg0, arg1)
+}
+
+// UpdateSubscriptionTx mocks base method.
+func (m *MockStore) UpdateSubscriptionTx(arg0 context.Context, arg1 db.UpdateSubscriptionTxParams) (db.SubscriptionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SubscriptionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscriptionTx indicates an expected call of UpdateSubscriptionTx.
+func (mr *MockStoreMockRecorder) UpdateSubscriptionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionTx", reflect.TypeOf((*MockStore)(nil).UpdateSubscriptionTx), arg0, arg1)
+}
+
+// UpdateSupplier mocks base method.
+func (m *MockStore) UpdateSupplier(arg0 context.Context, arg1 db.UpdateSupplierParams) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSupplier indicates an expected call of UpdateSupplier.
+func (mr *MockStoreMockRecorder) UpdateSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSupplier", reflect.TypeOf((*MockStore)(nil).UpdateSupplier), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,31 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_log (
+    actor,
+    action,
+    entity,
+    entity_id,
+    changes,
+    ip,
+    user_agent
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: ListAuditLogs :many
+SELECT * FROM audit_log
+WHERE (sqlc.arg(entity)::varchar = '' OR entity = sqlc.arg(entity))
+  AND (sqlc.arg(entity_id)::bigint = 0 OR entity_id = sqlc.arg(entity_id))
+  AND (sqlc.arg(actor)::varchar = '' OR actor = sqlc.arg(actor))
+ORDER BY id DESC
+LIMIT sqlc.arg(limit_count)
+OFFSET sqlc.arg(offset_count);
+
+-- name: CountAuditLogs :one
+SELECT COUNT(*) FROM audit_log
+WHERE (sqlc.arg(entity)::varchar = '' OR entity = sqlc.arg(entity))
+  AND (sqlc.arg(entity_id)::bigint = 0 OR entity_id = sqlc.arg(entity_id))
+  AND (sqlc.arg(actor)::varchar = '' OR actor = sqlc.arg(actor));
+
+-- name: DeleteAuditLogsBefore :execrows
+DELETE FROM audit_log
+WHERE created_at < $1;
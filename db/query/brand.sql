@@ -0,0 +1,42 @@
+-- name: CreateBrand :one
+INSERT INTO brands (
+    name,
+    slug,
+    description,
+    logo_id
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetBrand :one
+SELECT * FROM brands
+WHERE id = $1 LIMIT 1;
+
+-- name: GetBrandBySlug :one
+SELECT * FROM brands
+WHERE slug = $1 LIMIT 1;
+
+-- name: ListBrands :many
+SELECT b.*, COALESCE(i.image_path, '')::varchar AS logo_path, COALESCE(i.alt, '')::varchar AS logo_alt
+FROM brands b
+LEFT JOIN images i ON i.id = b.logo_id
+ORDER BY b.name;
+
+-- name: UpdateBrand :one
+UPDATE brands
+SET
+    name = COALESCE($2, name),
+    slug = COALESCE($3, slug),
+    description = COALESCE($4, description),
+    logo_id = COALESCE($5, logo_id),
+    changed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBrand :exec
+DELETE FROM brands
+WHERE id = $1;
+
+-- name: CountProductsByBrand :one
+SELECT COUNT(*) FROM products
+WHERE brand_id = $1;
@@ -1,15 +1,22 @@
 -- name: CreateCategory :one
 INSERT INTO categories (
     name,
-    description
+    description,
+    parent_id,
+    slug,
+    display_order
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetCategory :one
 SELECT * FROM categories 
 WHERE id = $1 LIMIT 1;
 
+-- name: GetCategoryBySlug :one
+SELECT * FROM categories
+WHERE slug = $1 LIMIT 1;
+
 -- name: CountCategory :one
 SELECT COUNT(*) FROM categories;
 
@@ -19,6 +26,10 @@ ORDER BY id
 LIMIT $1
 OFFSET $2;
 
+-- name: ListAllCategories :many
+SELECT * FROM categories
+ORDER BY parent_id, display_order, name;
+
 -- name: AssociateProductWithCategory :one
 INSERT INTO product_categories (product_id, category_id)
 VALUES ($1, $2)
@@ -36,8 +47,18 @@ JOIN product_categories pc ON c.id = pc.category_id
 WHERE pc.product_id = $1
 ORDER BY c.id;
 
+-- name: ListCategoriesBySale :many
+SELECT DISTINCT c.*
+FROM categories c
+JOIN product_categories pc ON c.id = pc.category_id
+JOIN sale_items si ON si.product_id = pc.product_id
+WHERE si.sale_id = $1
+ORDER BY c.id;
+
 -- name: ListProductsByCategory :many
-SELECT p.*
+SELECT p.id, p.name, p.description, p.user_id, p.username, p.price, p.old_price, p.sku, p.url, p.created_at,
+    p.changed_at, p.promotion_ends_at, p.supplier_id, p.cost_price, p.last_cost, p.brand_id, p.status, p.published_at,
+    p.deleted_at, p.version
 FROM products p
 JOIN product_categories pc ON p.id = pc.product_id
 WHERE pc.category_id = $1
@@ -48,10 +69,35 @@ ORDER BY p.id;
 UPDATE categories 
 SET 
     name = COALESCE($2, name),
-    description = COALESCE($3, description)
+    description = COALESCE($3, description),
+    parent_id = COALESCE($4, parent_id),
+    slug = COALESCE($5, slug),
+    display_order = COALESCE($6, display_order)
 WHERE id = $1
 RETURNING *;
 
 -- name: DeleteCategory :exec
 DELETE FROM categories 
 WHERE id = $1;
+
+-- name: MoveChildCategories :exec
+UPDATE categories
+SET parent_id = sqlc.arg(new_parent_id)
+WHERE parent_id = sqlc.arg(parent_id);
+
+-- name: DeleteProductCategories :exec
+DELETE FROM product_categories
+WHERE product_id = $1;
+
+-- name: AssociateExistingCategories :exec
+INSERT INTO product_categories (product_id, category_id)
+SELECT sqlc.arg(product_id), c.id
+FROM categories c
+WHERE c.id = ANY(sqlc.arg(category_ids)::bigint[])
+ON CONFLICT DO NOTHING;
+
+-- name: ListProductCategoriesByProducts :many
+SELECT product_id, category_id
+FROM product_categories
+WHERE product_id = ANY(sqlc.arg(product_ids)::bigint[])
+ORDER BY product_id, category_id;
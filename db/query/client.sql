@@ -18,6 +18,11 @@ INSERT INTO client (
 SELECT * FROM client
 WHERE id = $1 LIMIT 1;
 
+-- name: GetClientForUpdate :one
+SELECT * FROM client
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: GetSalesByClientID :many
 SELECT * FROM sale
 WHERE client_id = $1;
@@ -43,8 +48,10 @@ SET
     address_city = COALESCE($8, address_city),
     address_number = COALESCE($9, address_number),
     address_neighborhood = COALESCE($10, address_neighborhood),
-    address_reference = COALESCE($11, address_reference)
-WHERE id = $1
+    address_reference = COALESCE($11, address_reference),
+    version = version + 1,
+    changed_at = now()
+WHERE id = $1 AND version = $12
 RETURNING *;
 
 -- name: DeleteClient :exec
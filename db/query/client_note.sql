@@ -0,0 +1,41 @@
+-- name: CreateClientNote :one
+INSERT INTO client_notes (
+    client_id,
+    author,
+    body,
+    pinned
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetClientNote :one
+SELECT * FROM client_notes
+WHERE id = $1 LIMIT 1;
+
+-- name: ListClientNotes :many
+SELECT * FROM client_notes
+WHERE client_id = $1
+ORDER BY pinned DESC, created_at DESC;
+
+-- name: ListPinnedClientNotes :many
+SELECT * FROM client_notes
+WHERE client_id = $1 AND pinned = true
+ORDER BY created_at;
+
+-- name: UpdateClientNote :one
+UPDATE client_notes
+SET
+    body = COALESCE($2, body),
+    pinned = COALESCE($3, pinned),
+    changed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ReassignClientNotes :exec
+UPDATE client_notes
+SET client_id = sqlc.arg(new_client_id)
+WHERE client_id = sqlc.arg(client_id);
+
+-- name: DeleteClientNote :exec
+DELETE FROM client_notes
+WHERE id = $1;
@@ -0,0 +1,92 @@
+-- name: CreateCoupon :one
+INSERT INTO coupons (
+    code,
+    description,
+    discount_type,
+    discount_value,
+    min_order_value,
+    starts_at,
+    ends_at,
+    max_uses,
+    max_uses_per_client,
+    category_ids,
+    product_ids
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: GetCoupon :one
+SELECT * FROM coupons
+WHERE id = $1 LIMIT 1;
+
+-- name: GetCouponByCode :one
+SELECT * FROM coupons
+WHERE code = $1 LIMIT 1;
+
+-- name: GetCouponForUpdate :one
+SELECT * FROM coupons
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListCoupons :many
+SELECT * FROM coupons
+ORDER BY id DESC
+LIMIT $1
+OFFSET $2;
+
+-- name: CountCoupons :one
+SELECT COUNT(*) FROM coupons;
+
+-- name: UpdateCoupon :one
+UPDATE coupons
+SET
+    description = COALESCE($2, description),
+    discount_type = COALESCE($3, discount_type),
+    discount_value = COALESCE($4, discount_value),
+    min_order_value = COALESCE($5, min_order_value),
+    starts_at = COALESCE($6, starts_at),
+    ends_at = COALESCE($7, ends_at),
+    max_uses = COALESCE($8, max_uses),
+    max_uses_per_client = COALESCE($9, max_uses_per_client),
+    category_ids = COALESCE($10, category_ids),
+    product_ids = COALESCE($11, product_ids),
+    active = COALESCE($12, active),
+    changed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeactivateCoupon :one
+UPDATE coupons
+SET active = false, changed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CountCouponUses :one
+SELECT COUNT(*) FROM sale_discounts
+WHERE coupon_id = $1;
+
+-- name: CountCouponUsesByClient :one
+SELECT COUNT(*) FROM sale_discounts
+WHERE coupon_id = $1 AND client_id = $2;
+
+-- name: CreateSaleDiscount :one
+INSERT INTO sale_discounts (
+    sale_id,
+    coupon_id,
+    client_id,
+    code,
+    description,
+    amount
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListSaleDiscounts :many
+SELECT * FROM sale_discounts
+WHERE sale_id = $1
+ORDER BY id;
+
+-- name: ListSaleDiscountsByClient :many
+SELECT * FROM sale_discounts
+WHERE client_id = $1
+ORDER BY created_at, id;
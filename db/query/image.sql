@@ -36,7 +36,7 @@ SELECT c.*, pc."order"
 FROM images c
 JOIN product_images pc ON c.id = pc.image_id
 WHERE pc.product_id = $1
-ORDER BY pc."order";
+ORDER BY pc."order", pc.image_id;
 
 -- name: EditAssociation :one
 UPDATE product_images
@@ -57,3 +57,19 @@ RETURNING *;
 DELETE FROM images 
 WHERE id = $1;
 
+
+-- name: DeleteProductImages :exec
+DELETE FROM product_images
+WHERE product_id = $1;
+
+-- name: DeleteImageLinks :many
+DELETE FROM product_images
+WHERE image_id = $1
+RETURNING product_id;
+
+-- name: ListImagesByProducts :many
+SELECT pi.product_id, i.id, i.image_path, i.alt
+FROM product_images pi
+JOIN images i ON i.id = pi.image_id
+WHERE pi.product_id = ANY(sqlc.arg(product_ids)::bigint[])
+ORDER BY pi.product_id, pi."order", pi.image_id;
@@ -0,0 +1,32 @@
+-- name: CreateLoyaltyEntry :one
+INSERT INTO loyalty_ledger (
+    client_id,
+    sale_id,
+    points,
+    kind,
+    description
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetLoyaltyBalance :one
+SELECT COALESCE(SUM(points), 0)::bigint AS balance FROM loyalty_ledger
+WHERE client_id = $1;
+
+-- name: SumLoyaltyPointsBySale :one
+SELECT COALESCE(SUM(points), 0)::bigint AS points FROM loyalty_ledger
+WHERE sale_id = $1;
+
+-- name: CountLoyaltyEntriesBySale :one
+SELECT COUNT(*) FROM loyalty_ledger
+WHERE sale_id = $1 AND kind = $2;
+
+-- name: ListLoyaltyEntries :many
+SELECT * FROM loyalty_ledger
+WHERE client_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: ReassignLoyaltyEntries :exec
+UPDATE loyalty_ledger
+SET client_id = sqlc.arg(new_client_id)
+WHERE client_id = sqlc.arg(client_id);
@@ -0,0 +1,49 @@
+-- name: CreateNotification :one
+INSERT INTO notification_outbox (
+    channel,
+    event,
+    sale_id,
+    recipient,
+    subject,
+    body
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ClaimDueNotifications :many
+UPDATE notification_outbox
+SET next_attempt_at = sqlc.arg(locked_until)
+WHERE id IN (
+    SELECT id FROM notification_outbox
+    WHERE status = 'pending' AND next_attempt_at <= now()
+      AND channel = ANY(sqlc.arg(channels)::varchar[])
+    ORDER BY id
+    LIMIT sqlc.arg(limit_count)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ListNotificationsBySale :many
+SELECT * FROM notification_outbox
+WHERE sale_id = $1
+ORDER BY id;
+
+-- name: MarkNotificationSent :one
+UPDATE notification_outbox
+SET
+    status = 'sent',
+    attempts = attempts + 1,
+    last_error = '',
+    sent_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkNotificationFailed :one
+UPDATE notification_outbox
+SET
+    status = $2,
+    attempts = attempts + 1,
+    last_error = $3,
+    next_attempt_at = $4
+WHERE id = $1
+RETURNING *;
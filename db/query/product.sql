@@ -8,27 +8,47 @@ INSERT INTO products (
     old_price,
     sku,
     url,
-    categories,
-    images
+    supplier_id,
+    cost_price,
+    brand_id,
+    status,
+    published_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING *;
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+    CASE WHEN $12::varchar = 'published' THEN now() AT TIME ZONE 'America/Sao_Paulo' ELSE '0001-01-01 00:00:00Z' END
+) RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version;
 
 -- name: GetProduct :one
-SELECT * FROM products 
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
 WHERE id = $1 LIMIT 1;
 
+-- name: GetProductForUpdate :one
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: GetProductByURL :one
-SELECT * FROM products 
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
 WHERE url = $1 LIMIT 1;
 
 -- name: ListProductsByUser :many
-SELECT * FROM products
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
 WHERE user_id = $1
 ORDER BY id;
 
 -- name: ListProducts :many
-SELECT * FROM products 
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
 ORDER BY id DESC
 LIMIT $1
 OFFSET $2;
@@ -39,6 +59,19 @@ OFFSET $2;
 -- name: CountProducts :one
 SELECT COUNT(*) FROM products;
 
+-- name: ListPublishedProducts :many
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
+WHERE status = 'published' AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY id DESC
+LIMIT $1
+OFFSET $2;
+
+-- name: CountPublishedProducts :one
+SELECT COUNT(*) FROM products
+WHERE status = 'published' AND deleted_at = '0001-01-01 00:00:00Z';
+
 -- name: UpdateProduct :one
 UPDATE  products 
 SET 
@@ -50,11 +83,68 @@ SET
     old_price = COALESCE($7, old_price),
     sku = COALESCE($8, sku),
     url = COALESCE($9, url),
-    images = COALESCE($10, images),
-    categories = COALESCE($11, categories)
+    supplier_id = COALESCE($10, supplier_id),
+    cost_price = COALESCE($11, cost_price),
+    brand_id = COALESCE($12, brand_id),
+    status = COALESCE(NULLIF($13::varchar, ''), status),
+    -- published_at keeps the first time the product was published
+    published_at = CASE
+        WHEN $13::varchar = 'published' AND published_at = '0001-01-01 00:00:00Z' THEN now() AT TIME ZONE 'America/Sao_Paulo'
+        ELSE published_at
+    END,
+    version = version + 1,
+    changed_at = now()
+WHERE id = $1 AND version = $14
+RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version;
+
+-- name: ReassignProductsUser :execrows
+UPDATE products
+SET
+    user_id = sqlc.arg(new_user_id),
+    username = sqlc.arg(new_username),
+    version = version + 1,
+    changed_at = now()
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: UpdateProductPricing :one
+UPDATE products
+SET
+    price = $2,
+    old_price = $3,
+    promotion_ends_at = $4,
+    version = version + 1,
+    changed_at = now()
+WHERE id = $1
+RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version;
+
+-- name: TouchProduct :one
+UPDATE products
+SET version = version + 1, changed_at = now()
 WHERE id = $1
-RETURNING *;
+RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version;
 
 -- name: DeleteProduct :exec
 DELETE FROM products 
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: SoftDeleteProduct :one
+UPDATE products
+SET deleted_at = now() AT TIME ZONE 'America/Sao_Paulo', version = version + 1, changed_at = now()
+WHERE id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version;
+
+-- name: RestoreProduct :one
+UPDATE products
+SET deleted_at = '0001-01-01 00:00:00Z', version = version + 1, changed_at = now()
+WHERE id = $1 AND deleted_at <> '0001-01-01 00:00:00Z'
+RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version;
+
+-- name: UpdateProductLastCost :exec
+UPDATE products
+SET last_cost = $2, version = version + 1, changed_at = now()
+WHERE id = $1;
@@ -0,0 +1,27 @@
+-- name: CreateProductPriceHistory :one
+INSERT INTO product_price_history (
+    product_id,
+    price,
+    old_price,
+    changed_by,
+    source
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListProductPriceHistory :many
+SELECT * FROM product_price_history
+WHERE product_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: GetProductPriceAt :one
+SELECT * FROM product_price_history
+WHERE product_id = $1 AND created_at <= $2
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
+
+-- name: ListProductPricesAt :many
+SELECT DISTINCT ON (product_id) id, product_id, price, old_price, changed_by, source, created_at
+FROM product_price_history
+WHERE created_at <= $1
+ORDER BY product_id, created_at DESC, id DESC;
@@ -0,0 +1,84 @@
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (
+    supplier_id,
+    supplier_name,
+    notes,
+    expected_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetPurchaseOrder :one
+SELECT * FROM purchase_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPurchaseOrderForUpdate :one
+SELECT * FROM purchase_orders
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPurchaseOrders :many
+SELECT * FROM purchase_orders
+WHERE sqlc.arg(status)::varchar = '' OR status = sqlc.arg(status)
+ORDER BY id DESC
+LIMIT sqlc.arg(limit_count)
+OFFSET sqlc.arg(offset_count);
+
+-- name: CountPurchaseOrders :one
+SELECT COUNT(*) FROM purchase_orders
+WHERE sqlc.arg(status)::varchar = '' OR status = sqlc.arg(status);
+
+-- name: CountPurchaseOrdersBySupplier :one
+SELECT COUNT(*) FROM purchase_orders
+WHERE supplier_id = $1;
+
+-- name: UpdatePurchaseOrder :one
+UPDATE purchase_orders
+SET
+    notes = COALESCE($2, notes),
+    expected_at = COALESCE($3, expected_at),
+    changed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdatePurchaseOrderStatus :one
+UPDATE purchase_orders
+SET
+    status = $2,
+    sent_at = $3,
+    received_at = $4,
+    changed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeletePurchaseOrder :exec
+DELETE FROM purchase_orders
+WHERE id = $1;
+
+-- name: CreatePurchaseOrderLine :one
+INSERT INTO purchase_order_lines (
+    purchase_order_id,
+    product_id,
+    product_name,
+    quantity,
+    unit_cost
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListPurchaseOrderLines :many
+SELECT * FROM purchase_order_lines
+WHERE purchase_order_id = $1
+ORDER BY id;
+
+-- name: DeletePurchaseOrderLines :exec
+DELETE FROM purchase_order_lines
+WHERE purchase_order_id = $1;
+
+-- name: ReceivePurchaseOrderLine :one
+UPDATE purchase_order_lines
+SET
+    received_quantity = received_quantity + $2,
+    received_unit_cost = $3
+WHERE id = $1
+RETURNING *;
@@ -4,15 +4,21 @@ INSERT INTO sale (
     client_name,
     product,
     price,
-    observation
+    observation,
+    status
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetSale :one
 SELECT * FROM sale
 WHERE id = $1 LIMIT 1;
 
+-- name: GetSaleForUpdate :one
+SELECT * FROM sale
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: ListSales :many
 SELECT * FROM sale
 ORDER BY id DESC
@@ -38,8 +44,11 @@ SET
     client_name = COALESCE($3, client_name),
     product = COALESCE($4, product),
     price = COALESCE($5, price),
-    observation = COALESCE($6, observation)
-WHERE id = $1
+    observation = COALESCE($6, observation),
+    status = COALESCE($7, status),
+    version = version + 1,
+    changed_at = now()
+WHERE id = $1 AND version = $8
 RETURNING *;
 
 -- name: DeleteSale :exec
@@ -48,4 +57,8 @@ WHERE id = $1;
 
 -- name: DeleteSales :exec
 DELETE FROM sale
-WHERE id = ANY($1::int[]);
\ No newline at end of file
+WHERE id = ANY($1::int[]);
+
+-- name: ListSaleClientIDs :many
+SELECT DISTINCT client_id FROM sale
+ORDER BY client_id;
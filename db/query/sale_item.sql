@@ -0,0 +1,56 @@
+-- name: CreateSaleItem :one
+INSERT INTO sale_items (
+    sale_id,
+    product_id,
+    product_name,
+    quantity,
+    unit_price,
+    unit_cost
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListSaleItems :many
+SELECT * FROM sale_items
+WHERE sale_id = $1
+ORDER BY id;
+
+-- name: GetMarginByProduct :many
+SELECT
+    si.product_id,
+    MAX(si.product_name)::varchar AS product_name,
+    SUM(si.quantity)::bigint AS quantity,
+    SUM(si.quantity * si.unit_price)::float AS revenue,
+    SUM(si.quantity * si.unit_cost)::float AS cost
+FROM sale_items si
+JOIN sale s ON s.id = si.sale_id
+WHERE s.status = 'confirmed' AND s.created_at >= sqlc.arg(from_date) AND s.created_at < sqlc.arg(to_date)
+GROUP BY si.product_id
+ORDER BY revenue DESC;
+
+-- name: GetMarginByCategory :many
+SELECT
+    c.id AS category_id,
+    c.name AS category_name,
+    SUM(si.quantity)::bigint AS quantity,
+    SUM(si.quantity * si.unit_price)::float AS revenue,
+    SUM(si.quantity * si.unit_cost)::float AS cost
+FROM sale_items si
+JOIN sale s ON s.id = si.sale_id
+JOIN product_categories pc ON pc.product_id = si.product_id
+JOIN categories c ON c.id = pc.category_id
+WHERE s.status = 'confirmed' AND s.created_at >= sqlc.arg(from_date) AND s.created_at < sqlc.arg(to_date)
+GROUP BY c.id, c.name
+ORDER BY revenue DESC;
+
+-- name: GetMarginByPeriod :many
+SELECT
+    date_trunc(sqlc.arg(period)::text, s.created_at)::timestamptz AS period_start,
+    SUM(si.quantity)::bigint AS quantity,
+    SUM(si.quantity * si.unit_price)::float AS revenue,
+    SUM(si.quantity * si.unit_cost)::float AS cost
+FROM sale_items si
+JOIN sale s ON s.id = si.sale_id
+WHERE s.status = 'confirmed' AND s.created_at >= sqlc.arg(from_date) AND s.created_at < sqlc.arg(to_date)
+GROUP BY period_start
+ORDER BY period_start;
@@ -0,0 +1,20 @@
+-- name: CreateSaleStatusChange :one
+INSERT INTO sale_status_history (
+    sale_id,
+    status,
+    changed_by
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ListSaleStatusHistory :many
+SELECT * FROM sale_status_history
+WHERE sale_id = $1
+ORDER BY created_at, id;
+
+-- name: ListSaleStatusHistoryByClient :many
+SELECT h.id, h.sale_id, h.status, h.changed_by, h.created_at
+FROM sale_status_history h
+JOIN sale s ON s.id = h.sale_id
+WHERE s.client_id = $1
+ORDER BY h.created_at, h.id;
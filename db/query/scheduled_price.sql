@@ -0,0 +1,43 @@
+-- name: CreateScheduledPrice :one
+INSERT INTO scheduled_prices (
+    product_id,
+    price,
+    starts_at,
+    ends_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetScheduledPrice :one
+SELECT * FROM scheduled_prices
+WHERE id = $1 LIMIT 1;
+
+-- name: GetScheduledPriceForUpdate :one
+SELECT * FROM scheduled_prices
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListScheduledPricesByProduct :many
+SELECT * FROM scheduled_prices
+WHERE product_id = $1
+ORDER BY starts_at DESC, id DESC;
+
+-- name: ListDueScheduledPrices :many
+SELECT * FROM scheduled_prices
+WHERE status = 'scheduled' AND starts_at <= $1
+ORDER BY starts_at, id;
+
+-- name: ListExpiredScheduledPrices :many
+SELECT * FROM scheduled_prices
+WHERE status = 'active' AND ends_at <> '0001-01-01 00:00:00Z' AND ends_at <= $1
+ORDER BY ends_at, id;
+
+-- name: UpdateScheduledPriceStatus :one
+UPDATE scheduled_prices
+SET
+    status = $2,
+    previous_price = $3,
+    previous_old_price = $4,
+    changed_at = now()
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,95 @@
+-- name: CreateSubscription :one
+INSERT INTO subscriptions (
+    client_id,
+    interval_days,
+    next_run_date,
+    observation
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetSubscription :one
+SELECT * FROM subscriptions
+WHERE id = $1 LIMIT 1;
+
+-- name: GetSubscriptionForUpdate :one
+SELECT * FROM subscriptions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListSubscriptions :many
+SELECT * FROM subscriptions
+ORDER BY next_run_date, id
+LIMIT $1
+OFFSET $2;
+
+-- name: CountSubscriptions :one
+SELECT COUNT(*) FROM subscriptions;
+
+-- name: ListSubscriptionsByClient :many
+SELECT * FROM subscriptions
+WHERE client_id = $1
+ORDER BY id;
+
+-- name: ListDueSubscriptions :many
+SELECT * FROM subscriptions
+WHERE paused = false AND next_run_date <= $1
+ORDER BY id;
+
+-- name: ListActiveSubscriptions :many
+SELECT * FROM subscriptions
+WHERE paused = false
+ORDER BY next_run_date, id;
+
+-- name: UpdateSubscription :one
+UPDATE subscriptions
+SET
+    interval_days = COALESCE($2, interval_days),
+    next_run_date = COALESCE($3, next_run_date),
+    paused = COALESCE($4, paused),
+    observation = COALESCE($5, observation),
+    changed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateSubscriptionNextRun :exec
+UPDATE subscriptions
+SET next_run_date = $2
+WHERE id = $1;
+
+-- name: DeleteSubscription :exec
+DELETE FROM subscriptions
+WHERE id = $1;
+
+-- name: CreateSubscriptionItem :one
+INSERT INTO subscription_items (
+    subscription_id,
+    product,
+    quantity,
+    price
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListSubscriptionItems :many
+SELECT * FROM subscription_items
+WHERE subscription_id = $1
+ORDER BY id;
+
+-- name: DeleteSubscriptionItems :exec
+DELETE FROM subscription_items
+WHERE subscription_id = $1;
+
+-- name: CreateSubscriptionRun :one
+INSERT INTO subscription_runs (
+    subscription_id,
+    run_date,
+    sale_id
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ListSubscriptionRuns :many
+SELECT * FROM subscription_runs
+WHERE subscription_id = $1
+ORDER BY run_date DESC;
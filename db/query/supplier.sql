@@ -0,0 +1,43 @@
+-- name: CreateSupplier :one
+INSERT INTO suppliers (
+    name,
+    document,
+    email,
+    phone,
+    notes
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetSupplier :one
+SELECT * FROM suppliers
+WHERE id = $1 LIMIT 1;
+
+-- name: ListSuppliers :many
+SELECT * FROM suppliers
+ORDER BY name
+LIMIT $1
+OFFSET $2;
+
+-- name: CountSuppliers :one
+SELECT COUNT(*) FROM suppliers;
+
+-- name: UpdateSupplier :one
+UPDATE suppliers
+SET
+    name = COALESCE($2, name),
+    document = COALESCE($3, document),
+    email = COALESCE($4, email),
+    phone = COALESCE($5, phone),
+    notes = COALESCE($6, notes),
+    changed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSupplier :exec
+DELETE FROM suppliers
+WHERE id = $1;
+
+-- name: CountProductsBySupplier :one
+SELECT COUNT(*) FROM products
+WHERE supplier_id = $1;
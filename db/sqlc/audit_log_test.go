@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAuditLog(t *testing.T, actor string, entityID int64) AuditLog {
+	arg := CreateAuditLogParams{
+		Actor:     actor,
+		Action:    "update",
+		Entity:    "client",
+		EntityID:  entityID,
+		Changes:   json.RawMessage(`{"address_street": {"before": "Rua A", "after": "Rua B"}}`),
+		Ip:        "192.0.2.1",
+		UserAgent: "Mozilla/5.0",
+	}
+
+	entry, err := testQueries.CreateAuditLog(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, entry.ID)
+	require.Equal(t, arg.Actor, entry.Actor)
+	require.Equal(t, arg.EntityID, entry.EntityID)
+	require.JSONEq(t, string(arg.Changes), string(entry.Changes))
+	require.NotZero(t, entry.CreatedAt)
+
+	return entry
+}
+
+func TestListAuditLogs(t *testing.T) {
+	actor := util.RandomUsername()
+	entityID := util.RandomInt(1, 1000)
+	first := createRandomAuditLog(t, actor, entityID)
+	second := createRandomAuditLog(t, actor, entityID)
+	createRandomAuditLog(t, actor, entityID+1)
+
+	filter := CountAuditLogsParams{Entity: "client", EntityID: entityID, Actor: actor}
+	total, err := testQueries.CountAuditLogs(context.Background(), filter)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+
+	// the newest entries come first
+	entries, err := testQueries.ListAuditLogs(context.Background(), ListAuditLogsParams{
+		Entity:     filter.Entity,
+		EntityID:   filter.EntityID,
+		Actor:      filter.Actor,
+		LimitCount: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, second.ID, entries[0].ID)
+	require.Equal(t, first.ID, entries[1].ID)
+
+	// an empty filter keeps every entry of the actor
+	total, err = testQueries.CountAuditLogs(context.Background(), CountAuditLogsParams{Actor: actor})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+}
+
+func TestDeleteAuditLogsBefore(t *testing.T) {
+	entry := createRandomAuditLog(t, util.RandomUsername(), util.RandomInt(1, 1000))
+
+	// the entry is newer than the date, it is kept
+	_, err := testQueries.DeleteAuditLogsBefore(context.Background(), entry.CreatedAt.Add(-time.Second))
+	require.NoError(t, err)
+
+	total, err := testQueries.CountAuditLogs(context.Background(), CountAuditLogsParams{Actor: entry.Actor})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+
+	deleted, err := testQueries.DeleteAuditLogsBefore(context.Background(), entry.CreatedAt.Add(time.Second))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	total, err = testQueries.CountAuditLogs(context.Background(), CountAuditLogsParams{Actor: entry.Actor})
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"super-pet-delivery/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomBrand(t *testing.T) Brand {
+	arg := CreateBrandParams{
+		Name:        util.RandomFullName(),
+		Slug:        util.RandomString(12),
+		Description: util.RandomDescription(),
+	}
+
+	brand, err := testQueries.CreateBrand(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, brand.Name)
+	require.Equal(t, arg.Slug, brand.Slug)
+	require.Zero(t, brand.LogoID)
+	require.NotZero(t, brand.ID)
+
+	return brand
+}
+
+func TestGetBrandBySlug(t *testing.T) {
+	brand := createRandomBrand(t)
+
+	got, err := testQueries.GetBrandBySlug(context.Background(), brand.Slug)
+	require.NoError(t, err)
+	require.Equal(t, brand.ID, got.ID)
+}
+
+func TestFilterProductsByBrand(t *testing.T) {
+	brand := createRandomBrand(t)
+	product := createRandomProduct(t)
+	store := NewSortableStore(testDB)
+
+	_, err := testQueries.UpdateProduct(context.Background(), UpdateProductParams{
+		ID:          product.ID,
+		Version:     product.Version,
+		Name:        product.Name,
+		Description: product.Description,
+		UserID:      product.UserID,
+		Username:    product.Username,
+		Price:       product.Price,
+		OldPrice:    product.OldPrice,
+		Sku:         product.Sku,
+		Url:         product.Url,
+		BrandID:     brand.ID,
+	})
+	require.NoError(t, err)
+
+	products, total, err := store.FilterProducts(context.Background(), nil, []int64{brand.ID}, 1, 5, "", "", "", false)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Len(t, products, 1)
+	require.Equal(t, product.ID, products[0].ID)
+
+	count, err := testQueries.CountProductsByBrand(context.Background(), brand.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
@@ -11,6 +11,24 @@ import (
 	"github.com/lib/pq"
 )
 
+const associateExistingCategories = `-- name: AssociateExistingCategories :exec
+INSERT INTO product_categories (product_id, category_id)
+SELECT $1, c.id
+FROM categories c
+WHERE c.id = ANY($2::bigint[])
+ON CONFLICT DO NOTHING
+`
+
+type AssociateExistingCategoriesParams struct {
+	ProductID   int64   `json:"product_id"`
+	CategoryIds []int64 `json:"category_ids"`
+}
+
+func (q *Queries) AssociateExistingCategories(ctx context.Context, arg AssociateExistingCategoriesParams) error {
+	_, err := q.db.ExecContext(ctx, associateExistingCategories, arg.ProductID, pq.Array(arg.CategoryIds))
+	return err
+}
+
 const associateProductWithCategory = `-- name: AssociateProductWithCategory :one
 INSERT INTO product_categories (product_id, category_id)
 VALUES ($1, $2)
@@ -43,21 +61,40 @@ func (q *Queries) CountCategory(ctx context.Context) (int64, error) {
 const createCategory = `-- name: CreateCategory :one
 INSERT INTO categories (
     name,
-    description
+    description,
+    parent_id,
+    slug,
+    display_order
 ) VALUES (
-    $1, $2
-) RETURNING id, name, description
+    $1, $2, $3, $4, $5
+) RETURNING id, name, description, parent_id, slug, display_order
 `
 
 type CreateCategoryParams struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ParentID     int64  `json:"parent_id"`
+	Slug         string `json:"slug"`
+	DisplayOrder int32  `json:"display_order"`
 }
 
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
-	row := q.db.QueryRowContext(ctx, createCategory, arg.Name, arg.Description)
+	row := q.db.QueryRowContext(ctx, createCategory,
+		arg.Name,
+		arg.Description,
+		arg.ParentID,
+		arg.Slug,
+		arg.DisplayOrder,
+	)
 	var i Category
-	err := row.Scan(&i.ID, &i.Name, &i.Description)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.ParentID,
+		&i.Slug,
+		&i.DisplayOrder,
+	)
 	return i, err
 }
 
@@ -71,6 +108,16 @@ func (q *Queries) DeleteCategory(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteProductCategories = `-- name: DeleteProductCategories :exec
+DELETE FROM product_categories
+WHERE product_id = $1
+`
+
+func (q *Queries) DeleteProductCategories(ctx context.Context, productID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteProductCategories, productID)
+	return err
+}
+
 const disassociateProductFromCategory = `-- name: DisassociateProductFromCategory :one
 DELETE FROM product_categories
 WHERE product_id = $1 AND category_id = $2
@@ -90,19 +137,80 @@ func (q *Queries) DisassociateProductFromCategory(ctx context.Context, arg Disas
 }
 
 const getCategory = `-- name: GetCategory :one
-SELECT id, name, description FROM categories 
+SELECT id, name, description, parent_id, slug, display_order FROM categories 
 WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetCategory(ctx context.Context, id int64) (Category, error) {
 	row := q.db.QueryRowContext(ctx, getCategory, id)
 	var i Category
-	err := row.Scan(&i.ID, &i.Name, &i.Description)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.ParentID,
+		&i.Slug,
+		&i.DisplayOrder,
+	)
 	return i, err
 }
 
+const getCategoryBySlug = `-- name: GetCategoryBySlug :one
+SELECT id, name, description, parent_id, slug, display_order FROM categories
+WHERE slug = $1 LIMIT 1
+`
+
+func (q *Queries) GetCategoryBySlug(ctx context.Context, slug string) (Category, error) {
+	row := q.db.QueryRowContext(ctx, getCategoryBySlug, slug)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.ParentID,
+		&i.Slug,
+		&i.DisplayOrder,
+	)
+	return i, err
+}
+
+const listAllCategories = `-- name: ListAllCategories :many
+SELECT id, name, description, parent_id, slug, display_order FROM categories
+ORDER BY parent_id, display_order, name
+`
+
+func (q *Queries) ListAllCategories(ctx context.Context) ([]Category, error) {
+	rows, err := q.db.QueryContext(ctx, listAllCategories)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Category{}
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.ParentID,
+			&i.Slug,
+			&i.DisplayOrder,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCategories = `-- name: ListCategories :many
-SELECT id, name, description FROM categories 
+SELECT id, name, description, parent_id, slug, display_order FROM categories 
 ORDER BY id
 LIMIT $1
 OFFSET $2
@@ -122,7 +230,14 @@ func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams)
 	items := []Category{}
 	for rows.Next() {
 		var i Category
-		if err := rows.Scan(&i.ID, &i.Name, &i.Description); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.ParentID,
+			&i.Slug,
+			&i.DisplayOrder,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -137,7 +252,7 @@ func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams)
 }
 
 const listCategoriesByProduct = `-- name: ListCategoriesByProduct :many
-SELECT c.id, c.name, c.description
+SELECT c.id, c.name, c.description, c.parent_id, c.slug, c.display_order
 FROM categories c
 JOIN product_categories pc ON c.id = pc.category_id
 WHERE pc.product_id = $1
@@ -153,7 +268,83 @@ func (q *Queries) ListCategoriesByProduct(ctx context.Context, productID int64)
 	items := []Category{}
 	for rows.Next() {
 		var i Category
-		if err := rows.Scan(&i.ID, &i.Name, &i.Description); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.ParentID,
+			&i.Slug,
+			&i.DisplayOrder,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoriesBySale = `-- name: ListCategoriesBySale :many
+SELECT DISTINCT c.id, c.name, c.description, c.parent_id, c.slug, c.display_order
+FROM categories c
+JOIN product_categories pc ON c.id = pc.category_id
+JOIN sale_items si ON si.product_id = pc.product_id
+WHERE si.sale_id = $1
+ORDER BY c.id
+`
+
+func (q *Queries) ListCategoriesBySale(ctx context.Context, saleID int64) ([]Category, error) {
+	rows, err := q.db.QueryContext(ctx, listCategoriesBySale, saleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Category{}
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.ParentID,
+			&i.Slug,
+			&i.DisplayOrder,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductCategoriesByProducts = `-- name: ListProductCategoriesByProducts :many
+SELECT product_id, category_id
+FROM product_categories
+WHERE product_id = ANY($1::bigint[])
+ORDER BY product_id, category_id
+`
+
+func (q *Queries) ListProductCategoriesByProducts(ctx context.Context, productIds []int64) ([]ProductCategory, error) {
+	rows, err := q.db.QueryContext(ctx, listProductCategoriesByProducts, pq.Array(productIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductCategory{}
+	for rows.Next() {
+		var i ProductCategory
+		if err := rows.Scan(&i.ProductID, &i.CategoryID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -168,7 +359,9 @@ func (q *Queries) ListCategoriesByProduct(ctx context.Context, productID int64)
 }
 
 const listProductsByCategory = `-- name: ListProductsByCategory :many
-SELECT p.id, p.name, p.description, p.user_id, p.username, p.price, p.old_price, p.sku, p.images, p.categories, p.url, p.created_at, p.changed_at
+SELECT p.id, p.name, p.description, p.user_id, p.username, p.price, p.old_price, p.sku, p.url, p.created_at,
+    p.changed_at, p.promotion_ends_at, p.supplier_id, p.cost_price, p.last_cost, p.brand_id, p.status, p.published_at,
+    p.deleted_at, p.version
 FROM products p
 JOIN product_categories pc ON p.id = pc.product_id
 WHERE pc.category_id = $1
@@ -193,11 +386,18 @@ func (q *Queries) ListProductsByCategory(ctx context.Context, categoryID int64)
 			&i.Price,
 			&i.OldPrice,
 			&i.Sku,
-			pq.Array(&i.Images),
-			pq.Array(&i.Categories),
 			&i.Url,
 			&i.CreatedAt,
 			&i.ChangedAt,
+			&i.PromotionEndsAt,
+			&i.SupplierID,
+			&i.CostPrice,
+			&i.LastCost,
+			&i.BrandID,
+			&i.Status,
+			&i.PublishedAt,
+			&i.DeletedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -212,24 +412,60 @@ func (q *Queries) ListProductsByCategory(ctx context.Context, categoryID int64)
 	return items, nil
 }
 
+const moveChildCategories = `-- name: MoveChildCategories :exec
+UPDATE categories
+SET parent_id = $1
+WHERE parent_id = $2
+`
+
+type MoveChildCategoriesParams struct {
+	NewParentID int64 `json:"new_parent_id"`
+	ParentID    int64 `json:"parent_id"`
+}
+
+func (q *Queries) MoveChildCategories(ctx context.Context, arg MoveChildCategoriesParams) error {
+	_, err := q.db.ExecContext(ctx, moveChildCategories, arg.NewParentID, arg.ParentID)
+	return err
+}
+
 const updateCategory = `-- name: UpdateCategory :one
 UPDATE categories 
 SET 
     name = COALESCE($2, name),
-    description = COALESCE($3, description)
+    description = COALESCE($3, description),
+    parent_id = COALESCE($4, parent_id),
+    slug = COALESCE($5, slug),
+    display_order = COALESCE($6, display_order)
 WHERE id = $1
-RETURNING id, name, description
+RETURNING id, name, description, parent_id, slug, display_order
 `
 
 type UpdateCategoryParams struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ParentID     int64  `json:"parent_id"`
+	Slug         string `json:"slug"`
+	DisplayOrder int32  `json:"display_order"`
 }
 
 func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
-	row := q.db.QueryRowContext(ctx, updateCategory, arg.ID, arg.Name, arg.Description)
+	row := q.db.QueryRowContext(ctx, updateCategory,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.ParentID,
+		arg.Slug,
+		arg.DisplayOrder,
+	)
 	var i Category
-	err := row.Scan(&i.ID, &i.Name, &i.Description)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.ParentID,
+		&i.Slug,
+		&i.DisplayOrder,
+	)
 	return i, err
 }
@@ -13,6 +13,7 @@ func createRandomCategory(t *testing.T) Category {
 	arg := CreateCategoryParams{
 		Name:        util.RandomFullName(),
 		Description: util.RandomDescription(),
+		Slug:        util.RandomString(12),
 	}
 
 	category, err := testQueries.CreateCategory(context.Background(), arg)
@@ -142,3 +143,34 @@ func TestDeleteCategory(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, sql.ErrNoRows.Error())
 }
+
+func TestFilterProductsIncludesSubcategories(t *testing.T) {
+	parent := createRandomCategory(t)
+	child, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		Name:     util.RandomFullName(),
+		ParentID: parent.ID,
+		Slug:     util.RandomString(12),
+	})
+	require.NoError(t, err)
+
+	product := createRandomProduct(t)
+	store := NewSortableStore(testDB)
+	_, err = store.SetProductCategoriesTx(context.Background(), ProductCategoriesTxParams{
+		ProductID:   product.ID,
+		CategoryIDs: []int64{child.ID},
+	})
+	require.NoError(t, err)
+
+	products, total, err := store.FilterProducts(context.Background(), []int64{parent.ID}, nil, 1, 5, "", "", "", false)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Len(t, products, 1)
+	require.Equal(t, product.ID, products[0].ID)
+
+	err = testQueries.MoveChildCategories(context.Background(), MoveChildCategoriesParams{ParentID: parent.ID})
+	require.NoError(t, err)
+
+	moved, err := testQueries.GetCategory(context.Background(), child.ID)
+	require.NoError(t, err)
+	require.Zero(t, moved.ParentID)
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+)
+
+// DeleteCategoryTxResult is the result of the delete category transaction
+type DeleteCategoryTxResult struct {
+	Category Category  `json:"category"`
+	Products []Product `json:"products"`
+}
+
+// DeleteCategoryTx unlinks the products from the category, moves its subcategories up to its parent and deletes it.
+// The products are returned as they are after losing the category. It fails with sql.ErrNoRows when the category doesn't exist.
+func (store *SQLStore) DeleteCategoryTx(ctx context.Context, id int64) (DeleteCategoryTxResult, error) {
+	var result DeleteCategoryTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Category, err = q.GetCategory(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		result.Products, err = disassociateCategory(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		err = q.MoveChildCategories(ctx, MoveChildCategoriesParams{
+			ParentID:    id,
+			NewParentID: result.Category.ParentID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteCategory(ctx, id)
+	})
+
+	return result, err
+}
+
+// disassociateCategory unlinks every product from the category and bumps their version
+func disassociateCategory(ctx context.Context, q *Queries, categoryID int64) ([]Product, error) {
+	products, err := q.ListProductsByCategory(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, product := range products {
+		_, err := q.DisassociateProductFromCategory(ctx, DisassociateProductFromCategoryParams{
+			ProductID:  product.ID,
+			CategoryID: categoryID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		products[i], err = q.TouchProduct(ctx, product.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return products, nil
+}
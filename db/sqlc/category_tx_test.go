@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"super-pet-delivery/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createChildCategory(t *testing.T, parentID int64) Category {
+	category, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		Name:     util.RandomFullName(),
+		ParentID: parentID,
+		Slug:     util.RandomString(12),
+	})
+	require.NoError(t, err)
+	return category
+}
+
+func TestDeleteCategoryTx(t *testing.T) {
+	store := NewStore(testDB)
+	parent := createRandomCategory(t)
+	category := createChildCategory(t, parent.ID)
+	child := createChildCategory(t, category.ID)
+	other := createRandomCategory(t)
+
+	product, err := store.AssociateCategoriesTx(context.Background(), ProductCategoriesTxParams{
+		ProductID:   createRandomProduct(t).ID,
+		CategoryIDs: []int64{category.ID, other.ID},
+	})
+	require.NoError(t, err)
+
+	result, err := store.DeleteCategoryTx(context.Background(), category.ID)
+	require.NoError(t, err)
+	require.Equal(t, category.ID, result.Category.ID)
+	require.Len(t, result.Products, 1)
+	require.Equal(t, product.ID, result.Products[0].ID)
+
+	_, err = testQueries.GetCategory(context.Background(), category.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	child, err = testQueries.GetCategory(context.Background(), child.ID)
+	require.NoError(t, err)
+	require.Equal(t, parent.ID, child.ParentID)
+
+	categories, err := testQueries.ListCategoriesByProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, categories, 1)
+	require.Equal(t, other.ID, categories[0].ID)
+
+	_, err = store.DeleteCategoryTx(context.Background(), category.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestDeleteCategoryTxRollback(t *testing.T) {
+	store := NewStore(testDB)
+	category := createRandomCategory(t)
+	child := createChildCategory(t, category.ID)
+
+	product, err := store.AssociateCategoriesTx(context.Background(), ProductCategoriesTxParams{
+		ProductID:   createRandomProduct(t).ID,
+		CategoryIDs: []int64{category.ID},
+	})
+	require.NoError(t, err)
+
+	// a product linked to the category while it's deleted makes the delete fail after the products
+	// were unlinked and the subcategories moved
+	linked := createRandomProduct(t)
+	err = runBlockedTx(t, func(q *Queries) {
+		err := q.AssociateExistingCategories(context.Background(), AssociateExistingCategoriesParams{
+			ProductID:   linked.ID,
+			CategoryIds: []int64{category.ID},
+		})
+		require.NoError(t, err)
+	}, func() error {
+		_, err := store.DeleteCategoryTx(context.Background(), category.ID)
+		return err
+	})
+	requireForeignKeyViolation(t, err)
+
+	categories, err := testQueries.ListCategoriesByProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, categories, 1)
+	require.Equal(t, category.ID, categories[0].ID)
+
+	child, err = testQueries.GetCategory(context.Background(), child.ID)
+	require.NoError(t, err)
+	require.Equal(t, category.ID, child.ParentID)
+}
@@ -34,7 +34,7 @@ INSERT INTO client (
     address_reference
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING id, full_name, phone_whatsapp, phone_line, pet_name, pet_breed, address_street, address_city, address_number, address_neighborhood, address_reference, created_at, changed_at
+) RETURNING id, full_name, phone_whatsapp, phone_line, pet_name, pet_breed, address_street, address_city, address_number, address_neighborhood, address_reference, created_at, changed_at, version
 `
 
 type CreateClientParams struct {
@@ -78,6 +78,7 @@ func (q *Queries) CreateClient(ctx context.Context, arg CreateClientParams) (Cli
 		&i.AddressReference,
 		&i.CreatedAt,
 		&i.ChangedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -93,7 +94,7 @@ func (q *Queries) DeleteClient(ctx context.Context, id int64) error {
 }
 
 const getClient = `-- name: GetClient :one
-SELECT id, full_name, phone_whatsapp, phone_line, pet_name, pet_breed, address_street, address_city, address_number, address_neighborhood, address_reference, created_at, changed_at FROM client
+SELECT id, full_name, phone_whatsapp, phone_line, pet_name, pet_breed, address_street, address_city, address_number, address_neighborhood, address_reference, created_at, changed_at, version FROM client
 WHERE id = $1 LIMIT 1
 `
 
@@ -114,12 +115,41 @@ func (q *Queries) GetClient(ctx context.Context, id int64) (Client, error) {
 		&i.AddressReference,
 		&i.CreatedAt,
 		&i.ChangedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getClientForUpdate = `-- name: GetClientForUpdate :one
+SELECT id, full_name, phone_whatsapp, phone_line, pet_name, pet_breed, address_street, address_city, address_number, address_neighborhood, address_reference, created_at, changed_at, version FROM client
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetClientForUpdate(ctx context.Context, id int64) (Client, error) {
+	row := q.db.QueryRowContext(ctx, getClientForUpdate, id)
+	var i Client
+	err := row.Scan(
+		&i.ID,
+		&i.FullName,
+		&i.PhoneWhatsapp,
+		&i.PhoneLine,
+		&i.PetName,
+		&i.PetBreed,
+		&i.AddressStreet,
+		&i.AddressCity,
+		&i.AddressNumber,
+		&i.AddressNeighborhood,
+		&i.AddressReference,
+		&i.CreatedAt,
+		&i.ChangedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getSalesByClientID = `-- name: GetSalesByClientID :many
-SELECT id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at FROM sale
+SELECT id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at, status, version FROM sale
 WHERE client_id = $1
 `
 
@@ -142,6 +172,8 @@ func (q *Queries) GetSalesByClientID(ctx context.Context, clientID int64) ([]Sal
 			&i.CreatedAt,
 			&i.ChangedAt,
 			&i.PdfGeneratedAt,
+			&i.Status,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -157,7 +189,7 @@ func (q *Queries) GetSalesByClientID(ctx context.Context, clientID int64) ([]Sal
 }
 
 const listClients = `-- name: ListClients :many
-SELECT id, full_name, phone_whatsapp, phone_line, pet_name, pet_breed, address_street, address_city, address_number, address_neighborhood, address_reference, created_at, changed_at FROM client
+SELECT id, full_name, phone_whatsapp, phone_line, pet_name, pet_breed, address_street, address_city, address_number, address_neighborhood, address_reference, created_at, changed_at, version FROM client
 ORDER BY id DESC
 LIMIT $1
 OFFSET $2
@@ -191,6 +223,7 @@ func (q *Queries) ListClients(ctx context.Context, arg ListClientsParams) ([]Cli
 			&i.AddressReference,
 			&i.CreatedAt,
 			&i.ChangedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -217,9 +250,11 @@ SET
     address_city = COALESCE($8, address_city),
     address_number = COALESCE($9, address_number),
     address_neighborhood = COALESCE($10, address_neighborhood),
-    address_reference = COALESCE($11, address_reference)
-WHERE id = $1
-RETURNING id, full_name, phone_whatsapp, phone_line, pet_name, pet_breed, address_street, address_city, address_number, address_neighborhood, address_reference, created_at, changed_at
+    address_reference = COALESCE($11, address_reference),
+    version = version + 1,
+    changed_at = now()
+WHERE id = $1 AND version = $12
+RETURNING id, full_name, phone_whatsapp, phone_line, pet_name, pet_breed, address_street, address_city, address_number, address_neighborhood, address_reference, created_at, changed_at, version
 `
 
 type UpdateClientParams struct {
@@ -234,6 +269,7 @@ type UpdateClientParams struct {
 	AddressNumber       string `json:"address_number"`
 	AddressNeighborhood string `json:"address_neighborhood"`
 	AddressReference    string `json:"address_reference"`
+	Version             int64  `json:"version"`
 }
 
 func (q *Queries) UpdateClient(ctx context.Context, arg UpdateClientParams) (Client, error) {
@@ -249,6 +285,7 @@ func (q *Queries) UpdateClient(ctx context.Context, arg UpdateClientParams) (Cli
 		arg.AddressNumber,
 		arg.AddressNeighborhood,
 		arg.AddressReference,
+		arg.Version,
 	)
 	var i Client
 	err := row.Scan(
@@ -265,6 +302,7 @@ func (q *Queries) UpdateClient(ctx context.Context, arg UpdateClientParams) (Cli
 		&i.AddressReference,
 		&i.CreatedAt,
 		&i.ChangedAt,
+		&i.Version,
 	)
 	return i, err
 }
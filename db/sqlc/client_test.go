@@ -73,6 +73,7 @@ func TestUpdateClient(t *testing.T) {
 
 	arg := UpdateClientParams{
 		ID:                  client1.ID,
+		Version:             client1.Version,
 		FullName:            util.RandomFullName(),
 		PhoneWhatsapp:       client1.PhoneWhatsapp,
 		PhoneLine:           client1.PhoneLine,
@@ -85,6 +86,7 @@ func TestUpdateClient(t *testing.T) {
 	}
 	arg2 := UpdateClientParams{
 		ID:                  client2.ID,
+		Version:             client2.Version,
 		FullName:            client2.FullName,
 		PhoneWhatsapp:       util.RandomString(9),
 		PhoneLine:           client2.PhoneLine,
@@ -97,6 +99,7 @@ func TestUpdateClient(t *testing.T) {
 	}
 	arg3 := UpdateClientParams{
 		ID:                  client3.ID,
+		Version:             client3.Version,
 		FullName:            client3.FullName,
 		PhoneWhatsapp:       client3.PhoneWhatsapp,
 		PhoneLine:           client3.PhoneLine,
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrClientHasSales is returned when a client with sales is deleted without a client to move them to
+var ErrClientHasSales = errors.New("client has associated sales")
+
+// ErrClientHasSubscriptions is returned when a client with subscriptions is deleted
+var ErrClientHasSubscriptions = errors.New("client has subscriptions")
+
+// ErrClientHasPoints is returned when a client with a points balance is deleted without a client to move them to
+var ErrClientHasPoints = errors.New("client has loyalty points")
+
+// DeleteClientTxParams contains the input parameters of the delete client transaction.
+// NewClientID is the client the sales move to, it's only needed when the client has sales.
+type DeleteClientTxParams struct {
+	ID          int64 `json:"id"`
+	NewClientID int64 `json:"new_client_id"`
+}
+
+// DeleteClientTx moves the sales, notes and loyalty points of the client to the new client and deletes it.
+// Either everything is moved and the client deleted or nothing changes. The subscriptions aren't moved,
+// a client with subscriptions fails with ErrClientHasSubscriptions. It fails with ErrVersionConflict
+// when a sale is changed while it's moved.
+func (store *SQLStore) DeleteClientTx(ctx context.Context, arg DeleteClientTxParams) error {
+	return store.ExecTx(ctx, func(q *Queries) error {
+		subscriptions, err := q.ListSubscriptionsByClient(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if len(subscriptions) > 0 {
+			return ErrClientHasSubscriptions
+		}
+
+		sales, err := q.GetSalesByClientID(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(sales) > 0 {
+			if arg.NewClientID == 0 || arg.NewClientID == arg.ID {
+				return ErrClientHasSales
+			}
+			if err := reassignSales(ctx, q, sales, arg.NewClientID); err != nil {
+				return err
+			}
+		}
+
+		if arg.NewClientID != 0 && arg.NewClientID != arg.ID {
+			if err := reassignClientHistory(ctx, q, arg.ID, arg.NewClientID); err != nil {
+				return err
+			}
+		} else {
+			balance, err := q.GetLoyaltyBalance(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+			if balance != 0 {
+				return ErrClientHasPoints
+			}
+		}
+
+		return q.DeleteClient(ctx, arg.ID)
+	})
+}
+
+// reassignClientHistory moves the notes and the loyalty entries of the client to the new client,
+// the entries follow the sales they were earned or redeemed with
+func reassignClientHistory(ctx context.Context, q *Queries, clientID int64, newClientID int64) error {
+	err := q.ReassignClientNotes(ctx, ReassignClientNotesParams{NewClientID: newClientID, ClientID: clientID})
+	if err != nil {
+		return err
+	}
+
+	return q.ReassignLoyaltyEntries(ctx, ReassignLoyaltyEntriesParams{NewClientID: newClientID, ClientID: clientID})
+}
+
+// reassignSales moves the sales to the new client, with its current name.
+// The sales are updated with the version they were read with, a sale changed since then is a conflict.
+func reassignSales(ctx context.Context, q *Queries, sales []Sale, newClientID int64) error {
+	newClient, err := q.GetClient(ctx, newClientID)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sales {
+		_, err := q.UpdateSale(ctx, UpdateSaleParams{
+			ID:          s.ID,
+			ClientID:    newClient.ID,
+			ClientName:  newClient.FullName,
+			Product:     s.Product,
+			Price:       s.Price,
+			Observation: s.Observation,
+			Status:      s.Status,
+			Version:     s.Version,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrVersionConflict
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// runBlockedTx runs the transaction while another transaction holds the rows it needs to change,
+// the other transaction is committed once the first one waits for its locks
+func runBlockedTx(t *testing.T, other func(q *Queries), run func() error) error {
+	tx, err := testDB.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	other(New(tx))
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- run()
+	}()
+
+	require.Eventually(t, func() bool {
+		var waiting int
+		err := testDB.QueryRowContext(context.Background(),
+			"SELECT COUNT(*) FROM pg_stat_activity WHERE datname = current_database() AND wait_event_type = 'Lock'").Scan(&waiting)
+		return err == nil && waiting > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, tx.Commit())
+	return <-errc
+}
+
+// requireForeignKeyViolation checks the error is a foreign key violation
+func requireForeignKeyViolation(t *testing.T, err error) {
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, "foreign_key_violation", pqErr.Code.Name())
+}
+
+func TestDeleteClientTx(t *testing.T) {
+	store := NewStore(testDB)
+	client := createRandomClient(t)
+	newClient := createRandomClient(t)
+	sale := createRandomSaleTx(t, client, 0, 0).Sale
+
+	// a client with sales needs a client to move them to
+	err := store.DeleteClientTx(context.Background(), DeleteClientTxParams{ID: client.ID})
+	require.ErrorIs(t, err, ErrClientHasSales)
+
+	err = store.DeleteClientTx(context.Background(), DeleteClientTxParams{ID: client.ID, NewClientID: newClient.ID})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetClient(context.Background(), client.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	moved, err := testQueries.GetSale(context.Background(), sale.ID)
+	require.NoError(t, err)
+	require.Equal(t, newClient.ID, moved.ClientID)
+	require.Equal(t, newClient.FullName, moved.ClientName)
+}
+
+func TestDeleteClientTxMovesHistory(t *testing.T) {
+	store := NewStore(testDB)
+	client := createRandomClient(t)
+	newClient := createRandomClient(t)
+	createRandomSaleTx(t, client, 0, 100)
+
+	note, err := testQueries.CreateClientNote(context.Background(), CreateClientNoteParams{
+		ClientID: client.ID,
+		Author:   util.RandomUsername(),
+		Body:     util.RandomString(20),
+	})
+	require.NoError(t, err)
+
+	err = store.DeleteClientTx(context.Background(), DeleteClientTxParams{ID: client.ID, NewClientID: newClient.ID})
+	require.NoError(t, err)
+
+	// the notes and the points go with the sales
+	note, err = testQueries.GetClientNote(context.Background(), note.ID)
+	require.NoError(t, err)
+	require.Equal(t, newClient.ID, note.ClientID)
+
+	balance, err := testQueries.GetLoyaltyBalance(context.Background(), newClient.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), balance)
+}
+
+func TestDeleteClientTxKeepsSubscriptionsAndPoints(t *testing.T) {
+	store := NewStore(testDB)
+
+	subscription := createRandomSubscription(t, time.Now()).Subscription
+	err := store.DeleteClientTx(context.Background(), DeleteClientTxParams{ID: subscription.ClientID})
+	require.ErrorIs(t, err, ErrClientHasSubscriptions)
+
+	_, err = testQueries.GetSubscription(context.Background(), subscription.ID)
+	require.NoError(t, err)
+
+	// the points of a deleted sale without its reversal
+	client := createRandomClient(t)
+	_, err = testQueries.CreateLoyaltyEntry(context.Background(), CreateLoyaltyEntryParams{
+		ClientID: client.ID,
+		SaleID:   util.RandomInt(1000000, 2000000),
+		Points:   50,
+		Kind:     LoyaltyKindEarn,
+	})
+	require.NoError(t, err)
+
+	err = store.DeleteClientTx(context.Background(), DeleteClientTxParams{ID: client.ID})
+	require.ErrorIs(t, err, ErrClientHasPoints)
+
+	_, err = testQueries.GetClient(context.Background(), client.ID)
+	require.NoError(t, err)
+}
+
+func TestDeleteClientTxRollback(t *testing.T) {
+	store := NewStore(testDB)
+	client := createRandomClient(t)
+	newClient := createRandomClient(t)
+	sale := createRandomSaleTx(t, client, 0, 0).Sale
+
+	// a sale created for the client while it's deleted makes the delete fail after the sales were moved
+	err := runBlockedTx(t, func(q *Queries) {
+		_, err := q.CreateSale(context.Background(), CreateSaleParams{
+			ClientID:   client.ID,
+			ClientName: client.FullName,
+			Product:    util.RandomString(9),
+			Price:      100,
+			Status:     SaleStatusPending,
+		})
+		require.NoError(t, err)
+	}, func() error {
+		return store.DeleteClientTx(context.Background(), DeleteClientTxParams{ID: client.ID, NewClientID: newClient.ID})
+	})
+	requireForeignKeyViolation(t, err)
+
+	got, err := testQueries.GetSale(context.Background(), sale.ID)
+	require.NoError(t, err)
+	require.Equal(t, client.ID, got.ClientID)
+	require.Equal(t, client.FullName, got.ClientName)
+	require.Equal(t, sale.Version, got.Version)
+
+	_, err = testQueries.GetClient(context.Background(), client.ID)
+	require.NoError(t, err)
+
+	// the new client doesn't exist, nothing is deleted
+	err = store.DeleteClientTx(context.Background(), DeleteClientTxParams{ID: client.ID, NewClientID: newClient.ID + 1000000})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.GetClient(context.Background(), client.ID)
+	require.NoError(t, err)
+}
+
+func TestDeleteClientTxVersionConflict(t *testing.T) {
+	store := NewStore(testDB)
+	client := createRandomClient(t)
+	newClient := createRandomClient(t)
+	sale := createRandomSaleTx(t, client, 0, 0).Sale
+
+	// a sale changed while it's moved to the new client keeps the change and the client isn't deleted
+	err := runBlockedTx(t, func(q *Queries) {
+		_, err := q.UpdateSale(context.Background(), UpdateSaleParams{
+			ID:          sale.ID,
+			ClientID:    sale.ClientID,
+			ClientName:  sale.ClientName,
+			Product:     sale.Product,
+			Price:       sale.Price + 10,
+			Observation: sale.Observation,
+			Status:      sale.Status,
+			Version:     sale.Version,
+		})
+		require.NoError(t, err)
+	}, func() error {
+		return store.DeleteClientTx(context.Background(), DeleteClientTxParams{ID: client.ID, NewClientID: newClient.ID})
+	})
+	require.ErrorIs(t, err, ErrVersionConflict)
+
+	got, err := testQueries.GetSale(context.Background(), sale.ID)
+	require.NoError(t, err)
+	require.Equal(t, client.ID, got.ClientID)
+	require.Equal(t, sale.Price+10, got.Price)
+
+	_, err = testQueries.GetClient(context.Background(), client.ID)
+	require.NoError(t, err)
+}
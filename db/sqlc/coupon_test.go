@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomCoupon(t *testing.T, maxUsesPerClient int32) Coupon {
+	arg := CreateCouponParams{
+		Code:             strings.ToUpper(util.RandomString(8)),
+		Description:      util.RandomString(12),
+		DiscountType:     "percentage",
+		DiscountValue:    10,
+		StartsAt:         time.Now().Add(-time.Hour),
+		EndsAt:           time.Now().Add(time.Hour),
+		MaxUsesPerClient: maxUsesPerClient,
+		CategoryIds:      []int64{},
+		ProductIds:       []int64{},
+	}
+
+	coupon, err := testQueries.CreateCoupon(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Code, coupon.Code)
+	require.True(t, coupon.Active)
+	require.Empty(t, coupon.CategoryIds)
+
+	return coupon
+}
+
+func TestCreateSaleTxWithCoupon(t *testing.T) {
+	client := createRandomClient(t)
+	coupon := createRandomCoupon(t, 1)
+	store := NewStore(testDB)
+
+	arg := CreateSaleTxParams{
+		CreateSaleParams: CreateSaleParams{
+			ClientID:    client.ID,
+			ClientName:  client.FullName,
+			Product:     util.RandomString(9),
+			Price:       90,
+			Observation: util.RandomString(9),
+			Status:      SaleStatusConfirmed,
+		},
+		Coupon: &SaleCouponParams{CouponID: coupon.ID, Code: coupon.Code, Amount: 10},
+	}
+
+	result, err := store.CreateSaleTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Discounts, 1)
+	require.Equal(t, result.Sale.ID, result.Discounts[0].SaleID)
+
+	discounts, err := testQueries.ListSaleDiscounts(context.Background(), result.Sale.ID)
+	require.NoError(t, err)
+	require.Len(t, discounts, 1)
+
+	// the client already used the coupon once
+	_, err = store.CreateSaleTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrCouponUsageLimit)
+
+	uses, err := testQueries.CountCouponUses(context.Background(), coupon.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), uses)
+}
+
+func TestDeactivateCouponKeepsDiscounts(t *testing.T) {
+	client := createRandomClient(t)
+	coupon := createRandomCoupon(t, 0)
+	sale := createRandomSale(t)
+
+	_, err := testQueries.CreateSaleDiscount(context.Background(), CreateSaleDiscountParams{
+		SaleID:   sale.ID,
+		CouponID: coupon.ID,
+		ClientID: client.ID,
+		Code:     coupon.Code,
+		Amount:   10,
+	})
+	require.NoError(t, err)
+
+	deactivated, err := testQueries.DeactivateCoupon(context.Background(), coupon.ID)
+	require.NoError(t, err)
+	require.False(t, deactivated.Active)
+	require.Equal(t, coupon.Code, deactivated.Code)
+
+	uses, err := testQueries.CountCouponUses(context.Background(), coupon.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), uses)
+
+	// the discounts of the past sales keep the coupon from being deleted
+	_, err = testDB.ExecContext(context.Background(), "DELETE FROM coupons WHERE id = $1", coupon.ID)
+	require.Error(t, err)
+}
@@ -8,6 +8,8 @@ package db
 import (
 	"context"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 const associateProductWithImage = `-- name: AssociateProductWithImage :one
@@ -88,6 +90,45 @@ func (q *Queries) DeleteImage(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteImageLinks = `-- name: DeleteImageLinks :many
+DELETE FROM product_images
+WHERE image_id = $1
+RETURNING product_id
+`
+
+func (q *Queries) DeleteImageLinks(ctx context.Context, imageID int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, deleteImageLinks, imageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var product_id int64
+		if err := rows.Scan(&product_id); err != nil {
+			return nil, err
+		}
+		items = append(items, product_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteProductImages = `-- name: DeleteProductImages :exec
+DELETE FROM product_images
+WHERE product_id = $1
+`
+
+func (q *Queries) DeleteProductImages(ctx context.Context, productID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteProductImages, productID)
+	return err
+}
+
 const disassociateProductFromImage = `-- name: DisassociateProductFromImage :one
 DELETE FROM product_images
 WHERE product_id = $1 AND image_id = $2
@@ -194,7 +235,7 @@ SELECT c.id, c.name, c.description, c.alt, c.image_path, c.created_at, c.changed
 FROM images c
 JOIN product_images pc ON c.id = pc.image_id
 WHERE pc.product_id = $1
-ORDER BY pc."order"
+ORDER BY pc."order", pc.image_id
 `
 
 type ListImagesByProductRow struct {
@@ -240,6 +281,49 @@ func (q *Queries) ListImagesByProduct(ctx context.Context, productID int64) ([]L
 	return items, nil
 }
 
+const listImagesByProducts = `-- name: ListImagesByProducts :many
+SELECT pi.product_id, i.id, i.image_path, i.alt
+FROM product_images pi
+JOIN images i ON i.id = pi.image_id
+WHERE pi.product_id = ANY($1::bigint[])
+ORDER BY pi.product_id, pi."order", pi.image_id
+`
+
+type ListImagesByProductsRow struct {
+	ProductID int64  `json:"product_id"`
+	ID        int64  `json:"id"`
+	ImagePath string `json:"image_path"`
+	Alt       string `json:"alt"`
+}
+
+func (q *Queries) ListImagesByProducts(ctx context.Context, productIds []int64) ([]ListImagesByProductsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listImagesByProducts, pq.Array(productIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListImagesByProductsRow{}
+	for rows.Next() {
+		var i ListImagesByProductsRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ID,
+			&i.ImagePath,
+			&i.Alt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateImage = `-- name: UpdateImage :one
 UPDATE images 
 SET 
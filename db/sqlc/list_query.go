@@ -0,0 +1,214 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// listQuery builds the queries of the sortable and searchable lists, the page of rows and the count of
+// all the rows matching the same conditions. The values are always passed as parameters and the sort field
+// must be one of the sort columns, nothing that comes from a request is written in the SQL.
+type listQuery struct {
+	with        string
+	columns     string
+	table       string
+	sortColumns map[string]string
+	conditions  []string
+	args        []interface{}
+	orderBy     string
+	limit       int32
+	offset      int32
+}
+
+func newListQuery(table string, columns string, sortColumns map[string]string) *listQuery {
+	return &listQuery{table: table, columns: columns, sortColumns: sortColumns}
+}
+
+// arg adds a parameter to the query and returns its placeholder
+func (q *listQuery) arg(value interface{}) string {
+	q.args = append(q.args, value)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+// withCTE puts a WITH before the select, its parameters are added with arg
+func (q *listQuery) withCTE(cte string) *listQuery {
+	q.with = cte
+	return q
+}
+
+// where adds a condition, the rows match all of them
+func (q *listQuery) where(condition string) *listQuery {
+	q.conditions = append(q.conditions, condition)
+	return q
+}
+
+// search keeps the rows where any of the columns contains the search, ignoring the case.
+// An empty search keeps every row.
+func (q *listQuery) search(search string, columns ...string) *listQuery {
+	if search == "" {
+		return q
+	}
+
+	pattern := q.arg("%" + search + "%")
+	matches := make([]string, len(columns))
+	for i, column := range columns {
+		matches[i] = fmt.Sprintf("LOWER(%s) LIKE LOWER(%s)", column, pattern)
+	}
+	return q.where("(" + strings.Join(matches, " OR ") + ")")
+}
+
+// sort orders the rows by one of the sort columns, the id breaks the ties
+func (q *listQuery) sort(sortField string, sortDirection string) error {
+	if err := checkSort(sortField, sortDirection, q.sortColumns); err != nil {
+		return err
+	}
+	q.orderBy = fmt.Sprintf("%[1]s %[2]s, id %[2]s", sortField, sortDirection)
+	return nil
+}
+
+// searchSort is sort for the searches, without both a sort field and a direction the order doesn't change
+func (q *listQuery) searchSort(sortField string, sortDirection string) error {
+	if err := checkSearchSort(sortField, sortDirection, q.sortColumns); err != nil {
+		return err
+	}
+	if sortField != "" && sortDirection != "" {
+		return q.sort(sortField, sortDirection)
+	}
+	return nil
+}
+
+// defaultOrder is the ORDER BY when no sort was chosen, it's written as it is
+func (q *listQuery) defaultOrder(orderBy string) *listQuery {
+	if q.orderBy == "" {
+		q.orderBy = orderBy
+	}
+	return q
+}
+
+// page limits the select to a page of rows, the count isn't limited
+func (q *listQuery) page(limit int32, offset int32) *listQuery {
+	q.limit, q.offset = limit, offset
+	return q
+}
+
+func (q *listQuery) whereClause() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+// selectSQL is the select of the page and its parameters
+func (q *listQuery) selectSQL() (string, []interface{}) {
+	query := q.with + "SELECT " + q.columns + " FROM " + q.table + q.whereClause()
+	if q.orderBy != "" {
+		query += " ORDER BY " + q.orderBy
+	}
+
+	args := q.args
+	if q.limit > 0 {
+		args = append(append([]interface{}{}, q.args...), q.limit, q.offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+	return query, args
+}
+
+// countSQL is the count of all the rows matching the conditions and its parameters
+func (q *listQuery) countSQL() (string, []interface{}) {
+	return q.with + "SELECT COUNT(*) FROM " + q.table + q.whereClause(), q.args
+}
+
+// scanner is implemented by sql.Row and sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// listRows runs the select of the query and scans each row
+func listRows[T any](ctx context.Context, db DBTX, q *listQuery, scan func(scanner, ...interface{}) (T, error)) ([]T, error) {
+	query, args := q.selectSQL()
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []T
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// listAndCount runs the select and the count of the query
+func listAndCount[T any](ctx context.Context, db DBTX, q *listQuery, scan func(scanner, ...interface{}) (T, error)) ([]T, int64, error) {
+	items, err := listRows(ctx, db, q, scan)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := countRows(ctx, db, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// countRows runs the count of the query
+func countRows(ctx context.Context, db DBTX, q *listQuery) (int64, error) {
+	query, args := q.countSQL()
+
+	var total int64
+	err := db.QueryRowContext(ctx, query, args...).Scan(&total)
+	return total, err
+}
+
+// The columns of the lists, in the order their scan functions read them.
+// The extra destinations of the scans read the columns added after these ones.
+const (
+	clientColumns  = "id, full_name, phone_whatsapp, phone_line, pet_name, pet_breed, address_street, address_city, address_number, address_neighborhood, address_reference, created_at, changed_at, version"
+	saleColumns    = "id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at, status, version"
+	productColumns = "id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at, promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version"
+	imageColumns   = "id, name, description, alt, image_path, created_at, changed_at"
+)
+
+func scanClient(row scanner, extra ...interface{}) (Client, error) {
+	var c Client
+	err := row.Scan(append([]interface{}{&c.ID, &c.FullName, &c.PhoneWhatsapp, &c.PhoneLine, &c.PetName, &c.PetBreed, &c.AddressStreet, &c.AddressCity, &c.AddressNumber, &c.AddressNeighborhood, &c.AddressReference, &c.CreatedAt, &c.ChangedAt, &c.Version}, extra...)...)
+	return c, err
+}
+
+func scanSale(row scanner, extra ...interface{}) (Sale, error) {
+	var s Sale
+	err := row.Scan(append([]interface{}{&s.ID, &s.ClientID, &s.ClientName, &s.Product, &s.Price, &s.Observation, &s.CreatedAt, &s.ChangedAt, &s.PdfGeneratedAt, &s.Status, &s.Version}, extra...)...)
+	return s, err
+}
+
+func scanProduct(row scanner, extra ...interface{}) (Product, error) {
+	var p Product
+	err := row.Scan(append([]interface{}{&p.ID, &p.Name, &p.Description, &p.UserID, &p.Username, &p.Price, &p.OldPrice, &p.Sku, &p.Url, &p.CreatedAt, &p.ChangedAt, &p.PromotionEndsAt, &p.SupplierID, &p.CostPrice, &p.LastCost, &p.BrandID, &p.Status, &p.PublishedAt, &p.DeletedAt, &p.Version}, extra...)...)
+	return p, err
+}
+
+func scanImage(row scanner, extra ...interface{}) (Image, error) {
+	var i Image
+	err := row.Scan(append([]interface{}{&i.ID, &i.Name, &i.Description, &i.Alt, &i.ImagePath, &i.CreatedAt, &i.ChangedAt}, extra...)...)
+	return i, err
+}
+
+// The columns each search looks in
+var (
+	clientSearchColumns = []string{"full_name", "phone_whatsapp", "phone_line", "pet_name", "pet_breed", "address_street", "address_city", "address_number", "address_neighborhood", "address_reference", "CAST(id AS TEXT)"}
+	saleSearchColumns   = []string{"product", "client_name", "observation", "CAST(price AS TEXT)", "CAST(id AS TEXT)"}
+	imageSearchColumns  = []string{"name", "description", "alt", "image_path"}
+)
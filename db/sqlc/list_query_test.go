@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListQuerySQL(t *testing.T) {
+	q := newListQuery("client", clientColumns, clientSortColumns).
+		search("rex", "full_name", "pet_name").
+		page(10, 20)
+	require.NoError(t, q.sort("full_name", "asc"))
+
+	query, args := q.selectSQL()
+	require.Equal(t, "SELECT "+clientColumns+" FROM client WHERE (LOWER(full_name) LIKE LOWER($1) OR LOWER(pet_name) LIKE LOWER($1)) ORDER BY full_name asc, id asc LIMIT $2 OFFSET $3", query)
+	require.Equal(t, []interface{}{"%rex%", int32(10), int32(20)}, args)
+
+	// the count has the same conditions, without the order and the page
+	query, args = q.countSQL()
+	require.Equal(t, "SELECT COUNT(*) FROM client WHERE (LOWER(full_name) LIKE LOWER($1) OR LOWER(pet_name) LIKE LOWER($1))", query)
+	require.Equal(t, []interface{}{"%rex%"}, args)
+
+	// an empty search keeps every row
+	query, args = newListQuery("client", clientColumns, clientSortColumns).search("", "full_name").countSQL()
+	require.Equal(t, "SELECT COUNT(*) FROM client", query)
+	require.Empty(t, args)
+}
+
+func TestListQueryRejectsInjection(t *testing.T) {
+	sorts := []struct {
+		field     string
+		direction string
+	}{
+		{"name; DROP TABLE images", "asc"},
+		{"name desc", "asc"},
+		{"(SELECT password FROM users)", "asc"},
+		{"name", "asc, id"},
+		{"name", "asc; DROP TABLE images"},
+		{"NAME", "ASC"},
+	}
+
+	for _, s := range sorts {
+		q := newListQuery("images", imageColumns, imageSortColumns)
+		require.ErrorIs(t, q.sort(s.field, s.direction), ErrInvalidSort, s.field+" "+s.direction)
+		require.ErrorIs(t, q.searchSort(s.field, s.direction), ErrInvalidSort, s.field+" "+s.direction)
+
+		query, _ := q.selectSQL()
+		require.NotContains(t, query, "ORDER BY")
+	}
+
+	// the search is only passed as a parameter, never written in the SQL
+	search := "'); DROP TABLE images; --"
+	q := newListQuery("images", imageColumns, imageSortColumns).search(search, imageSearchColumns...)
+	query, args := q.selectSQL()
+	require.NotContains(t, query, "DROP")
+	require.Equal(t, []interface{}{"%" + search + "%"}, args)
+
+	store := NewSortableStore(testDB)
+	_, err := store.ListImagesSorted(context.Background(), ListImagesParams{Limit: 5}, "name; DROP TABLE images", "asc")
+	require.ErrorIs(t, err, ErrInvalidSort)
+	_, _, err = store.FilterProducts(context.Background(), nil, nil, 1, 5, "price desc, (SELECT 1)", "asc", "", true)
+	require.ErrorIs(t, err, ErrInvalidSort)
+}
+
+func TestListQueryProducts(t *testing.T) {
+	q := productListQuery(true).page(5, 0)
+	filterProducts(q, []int64{1, 2}, []int64{3})
+	searchProducts(q, "ração")
+
+	query, args := q.selectSQL()
+	require.Contains(t, query, "WITH RECURSIVE category_tree")
+	require.Contains(t, query, "ANY($1::bigint[])")
+	require.Contains(t, query, "brand_id = ANY($2::bigint[])")
+	require.NotContains(t, query, "ração")
+	require.Len(t, args, 5)
+	require.Equal(t, "ração", args[2])
+
+	// a chosen sort replaces the rank of the search
+	q = productListQuery(false)
+	require.NoError(t, q.searchSort("price", "desc"))
+	searchProducts(q, "ração")
+	query, _ = q.selectSQL()
+	require.Contains(t, query, "ORDER BY price desc, id desc")
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"super-pet-delivery/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSaleTx(t *testing.T, client Client, redeem int64, earn int64) CreateSaleTxResult {
+	store := NewStore(testDB)
+
+	result, err := store.CreateSaleTx(context.Background(), CreateSaleTxParams{
+		CreateSaleParams: CreateSaleParams{
+			ClientID:    client.ID,
+			ClientName:  client.FullName,
+			Product:     util.RandomString(9),
+			Price:       100,
+			Observation: util.RandomString(9),
+			Status:      SaleStatusConfirmed,
+		},
+		RedeemPoints: redeem,
+		EarnPoints:   earn,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, result.Sale.ID)
+
+	return result
+}
+
+func TestCreateSaleTxEarnsAndRedeems(t *testing.T) {
+	client := createRandomClient(t)
+	store := NewStore(testDB)
+
+	result := createRandomSaleTx(t, client, 0, 100)
+	require.Len(t, result.Entries, 1)
+	require.Equal(t, LoyaltyKindEarn, result.Entries[0].Kind)
+
+	result = createRandomSaleTx(t, client, 40, 60)
+	require.Len(t, result.Entries, 2)
+	require.Equal(t, int64(-40), result.Entries[0].Points)
+
+	balance, err := testQueries.GetLoyaltyBalance(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(120), balance)
+
+	// redeeming more than the balance fails without creating the sale
+	_, err = store.CreateSaleTx(context.Background(), CreateSaleTxParams{
+		CreateSaleParams: CreateSaleParams{ClientID: client.ID, Product: "x", Price: 1, Status: SaleStatusConfirmed},
+		RedeemPoints:     500,
+	})
+	require.ErrorIs(t, err, ErrInsufficientPoints)
+
+	sales, err := testQueries.GetSalesByClientID(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Len(t, sales, 2)
+}
+
+func TestDeleteSalesTxReversesPoints(t *testing.T) {
+	client := createRandomClient(t)
+	store := NewStore(testDB)
+
+	sale1 := createRandomSaleTx(t, client, 0, 100).Sale
+	sale2 := createRandomSaleTx(t, client, 50, 50).Sale
+	sale3 := createRandomSaleTx(t, client, 0, 30).Sale
+
+	require.NoError(t, store.DeleteSaleTx(context.Background(), sale3.ID))
+
+	balance, err := testQueries.GetLoyaltyBalance(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), balance)
+
+	require.NoError(t, store.DeleteSalesTx(context.Background(), []int32{int32(sale1.ID), int32(sale2.ID)}))
+
+	balance, err = testQueries.GetLoyaltyBalance(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Zero(t, balance)
+
+	entries, err := testQueries.ListLoyaltyEntries(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+}
+
+func TestUpdateSaleTxCreditsPointsOnce(t *testing.T) {
+	client := createRandomClient(t)
+	store := NewStore(testDB)
+
+	result, err := store.CreateSaleTx(context.Background(), CreateSaleTxParams{
+		CreateSaleParams: CreateSaleParams{
+			ClientID:   client.ID,
+			ClientName: client.FullName,
+			Product:    util.RandomString(9),
+			Price:      100,
+			Status:     SaleStatusPending,
+		},
+	})
+	require.NoError(t, err)
+	sale := result.Sale
+
+	// confirmed, back to pending and confirmed again
+	for _, status := range []string{SaleStatusConfirmed, SaleStatusPending, SaleStatusConfirmed} {
+		sale, err = store.UpdateSaleTx(context.Background(), UpdateSaleTxParams{
+			UpdateSaleParams: UpdateSaleParams{
+				ID:         sale.ID,
+				ClientID:   sale.ClientID,
+				ClientName: sale.ClientName,
+				Product:    sale.Product,
+				Price:      sale.Price,
+				Status:     status,
+				Version:    sale.Version,
+			},
+			EarnPoints: 100,
+		})
+		require.NoError(t, err)
+	}
+
+	balance, err := testQueries.GetLoyaltyBalance(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), balance)
+}
@@ -5,15 +5,41 @@
 package db
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type AuditLog struct {
+	ID        int64           `json:"id"`
+	Actor     string          `json:"actor"`
+	Action    string          `json:"action"`
+	Entity    string          `json:"entity"`
+	EntityID  int64           `json:"entity_id"`
+	Changes   json.RawMessage `json:"changes"`
+	Ip        string          `json:"ip"`
+	UserAgent string          `json:"user_agent"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type Brand struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	LogoID      int64     `json:"logo_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
 type Category struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ParentID     int64  `json:"parent_id"`
+	Slug         string `json:"slug"`
+	DisplayOrder int32  `json:"display_order"`
 }
 
 type Client struct {
@@ -30,6 +56,35 @@ type Client struct {
 	AddressReference    string    `json:"address_reference"`
 	CreatedAt           time.Time `json:"created_at"`
 	ChangedAt           time.Time `json:"changed_at"`
+	Version             int64     `json:"version"`
+}
+
+type ClientNote struct {
+	ID        int64     `json:"id"`
+	ClientID  int64     `json:"client_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	Pinned    bool      `json:"pinned"`
+	CreatedAt time.Time `json:"created_at"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+type Coupon struct {
+	ID               int64     `json:"id"`
+	Code             string    `json:"code"`
+	Description      string    `json:"description"`
+	DiscountType     string    `json:"discount_type"`
+	DiscountValue    float64   `json:"discount_value"`
+	MinOrderValue    float64   `json:"min_order_value"`
+	StartsAt         time.Time `json:"starts_at"`
+	EndsAt           time.Time `json:"ends_at"`
+	MaxUses          int32     `json:"max_uses"`
+	MaxUsesPerClient int32     `json:"max_uses_per_client"`
+	CategoryIds      []int64   `json:"category_ids"`
+	ProductIds       []int64   `json:"product_ids"`
+	Active           bool      `json:"active"`
+	CreatedAt        time.Time `json:"created_at"`
+	ChangedAt        time.Time `json:"changed_at"`
 }
 
 type Image struct {
@@ -42,20 +97,53 @@ type Image struct {
 	ChangedAt   time.Time `json:"changed_at"`
 }
 
-type Product struct {
+type LoyaltyLedger struct {
 	ID          int64     `json:"id"`
-	Name        string    `json:"name"`
+	ClientID    int64     `json:"client_id"`
+	SaleID      int64     `json:"sale_id"`
+	Points      int64     `json:"points"`
+	Kind        string    `json:"kind"`
 	Description string    `json:"description"`
-	UserID      int64     `json:"user_id"`
-	Username    string    `json:"username"`
-	Price       float64   `json:"price"`
-	OldPrice    float64   `json:"old_price"`
-	Sku         string    `json:"sku"`
-	Images      []string  `json:"images"`
-	Categories  []int64   `json:"categories"`
-	Url         string    `json:"url"`
 	CreatedAt   time.Time `json:"created_at"`
-	ChangedAt   time.Time `json:"changed_at"`
+}
+
+type NotificationOutbox struct {
+	ID            int64     `json:"id"`
+	Channel       string    `json:"channel"`
+	Event         string    `json:"event"`
+	SaleID        int64     `json:"sale_id"`
+	Recipient     string    `json:"recipient"`
+	Subject       string    `json:"subject"`
+	Body          string    `json:"body"`
+	Status        string    `json:"status"`
+	Attempts      int32     `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	SentAt        time.Time `json:"sent_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type Product struct {
+	ID              int64     `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	UserID          int64     `json:"user_id"`
+	Username        string    `json:"username"`
+	Price           float64   `json:"price"`
+	OldPrice        float64   `json:"old_price"`
+	Sku             string    `json:"sku"`
+	Url             string    `json:"url"`
+	CreatedAt       time.Time `json:"created_at"`
+	ChangedAt       time.Time `json:"changed_at"`
+	PromotionEndsAt time.Time `json:"promotion_ends_at"`
+	SupplierID      int64     `json:"supplier_id"`
+	CostPrice       float64   `json:"cost_price"`
+	LastCost        float64   `json:"last_cost"`
+	BrandID         int64     `json:"brand_id"`
+	Status          string    `json:"status"`
+	PublishedAt     time.Time `json:"published_at"`
+	DeletedAt       time.Time `json:"deleted_at"`
+	Version         int64     `json:"version"`
 }
 
 type ProductCategory struct {
@@ -69,6 +157,40 @@ type ProductImage struct {
 	Order     int32 `json:"order"`
 }
 
+type ProductPriceHistory struct {
+	ID        int64     `json:"id"`
+	ProductID int64     `json:"product_id"`
+	Price     float64   `json:"price"`
+	OldPrice  float64   `json:"old_price"`
+	ChangedBy string    `json:"changed_by"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PurchaseOrder struct {
+	ID           int64     `json:"id"`
+	SupplierID   int64     `json:"supplier_id"`
+	SupplierName string    `json:"supplier_name"`
+	Status       string    `json:"status"`
+	Notes        string    `json:"notes"`
+	ExpectedAt   time.Time `json:"expected_at"`
+	SentAt       time.Time `json:"sent_at"`
+	ReceivedAt   time.Time `json:"received_at"`
+	CreatedAt    time.Time `json:"created_at"`
+	ChangedAt    time.Time `json:"changed_at"`
+}
+
+type PurchaseOrderLine struct {
+	ID               int64   `json:"id"`
+	PurchaseOrderID  int64   `json:"purchase_order_id"`
+	ProductID        int64   `json:"product_id"`
+	ProductName      string  `json:"product_name"`
+	Quantity         int32   `json:"quantity"`
+	ReceivedQuantity int32   `json:"received_quantity"`
+	UnitCost         float64 `json:"unit_cost"`
+	ReceivedUnitCost float64 `json:"received_unit_cost"`
+}
+
 type Sale struct {
 	ID             int64     `json:"id"`
 	ClientID       int64     `json:"client_id"`
@@ -79,6 +201,51 @@ type Sale struct {
 	CreatedAt      time.Time `json:"created_at"`
 	ChangedAt      time.Time `json:"changed_at"`
 	PdfGeneratedAt time.Time `json:"pdf_generated_at"`
+	Status         string    `json:"status"`
+	Version        int64     `json:"version"`
+}
+
+type SaleDiscount struct {
+	ID          int64     `json:"id"`
+	SaleID      int64     `json:"sale_id"`
+	CouponID    int64     `json:"coupon_id"`
+	ClientID    int64     `json:"client_id"`
+	Code        string    `json:"code"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type SaleItem struct {
+	ID          int64     `json:"id"`
+	SaleID      int64     `json:"sale_id"`
+	ProductID   int64     `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	Quantity    int32     `json:"quantity"`
+	UnitPrice   float64   `json:"unit_price"`
+	UnitCost    float64   `json:"unit_cost"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type SaleStatusHistory struct {
+	ID        int64     `json:"id"`
+	SaleID    int64     `json:"sale_id"`
+	Status    string    `json:"status"`
+	ChangedBy string    `json:"changed_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ScheduledPrice struct {
+	ID               int64     `json:"id"`
+	ProductID        int64     `json:"product_id"`
+	Price            float64   `json:"price"`
+	StartsAt         time.Time `json:"starts_at"`
+	EndsAt           time.Time `json:"ends_at"`
+	Status           string    `json:"status"`
+	PreviousPrice    float64   `json:"previous_price"`
+	PreviousOldPrice float64   `json:"previous_old_price"`
+	CreatedAt        time.Time `json:"created_at"`
+	ChangedAt        time.Time `json:"changed_at"`
 }
 
 type Session struct {
@@ -98,6 +265,43 @@ type SliderImageWidget struct {
 	Order   int32 `json:"order"`
 }
 
+type Subscription struct {
+	ID           int64     `json:"id"`
+	ClientID     int64     `json:"client_id"`
+	IntervalDays int32     `json:"interval_days"`
+	NextRunDate  time.Time `json:"next_run_date"`
+	Paused       bool      `json:"paused"`
+	Observation  string    `json:"observation"`
+	CreatedAt    time.Time `json:"created_at"`
+	ChangedAt    time.Time `json:"changed_at"`
+}
+
+type SubscriptionItem struct {
+	ID             int64   `json:"id"`
+	SubscriptionID int64   `json:"subscription_id"`
+	Product        string  `json:"product"`
+	Quantity       int32   `json:"quantity"`
+	Price          float64 `json:"price"`
+}
+
+type SubscriptionRun struct {
+	SubscriptionID int64     `json:"subscription_id"`
+	RunDate        time.Time `json:"run_date"`
+	SaleID         int64     `json:"sale_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type Supplier struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Document  string    `json:"document"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	Notes     string    `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
 type User struct {
 	ID                int64     `json:"id"`
 	Username          string    `json:"username"`
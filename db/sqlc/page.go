@@ -0,0 +1,183 @@
+package db
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCursor is returned by the page methods when the cursor wasn't made by them
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageParams are the input of the keyset pagination.
+// An empty Cursor asks for the first page, the next ones pass the cursor returned by the previous page.
+// The sort of the first page is kept in the cursor, so SortField and SortDirection are only read without it.
+type PageParams struct {
+	Cursor        string `json:"cursor"`
+	Limit         int32  `json:"limit"`
+	SortField     string `json:"sort_field"`
+	SortDirection string `json:"sort_direction"`
+	Search        string `json:"search"`
+}
+
+// ProductPageParams are the input of the keyset pagination of the products, with the filters of FilterProducts
+type ProductPageParams struct {
+	PageParams
+	CategoryIDs []int64 `json:"category_ids"`
+	BrandIDs    []int64 `json:"brand_ids"`
+	PublicOnly  bool    `json:"public_only"`
+}
+
+// cursor is the position after the last row of a page, its sort value and id
+type cursor struct {
+	SortField     string `json:"f"`
+	SortDirection string `json:"d"`
+	Value         string `json:"v"`
+	ID            int64  `json:"i"`
+}
+
+func (c cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// keyset orders the rows by a column and the id and continues after the cursor, if there's one.
+// columns are the columns that can be sorted and their postgres type, the value in the cursor is cast to it.
+type keyset struct {
+	column    string
+	direction string
+	from      *cursor
+	sqlType   string
+}
+
+func newKeyset(arg PageParams, columns map[string]string) (keyset, error) {
+	k := keyset{column: "id", direction: "desc"}
+	if arg.SortField != "" && arg.SortDirection != "" {
+		k.column, k.direction = arg.SortField, arg.SortDirection
+	}
+
+	if arg.Cursor != "" {
+		c, err := decodeCursor(arg.Cursor)
+		if err != nil {
+			return k, err
+		}
+		k.column, k.direction, k.from = c.SortField, c.SortDirection, &c
+	}
+
+	if err := checkSort(k.column, k.direction, columns); err != nil {
+		return k, err
+	}
+	k.sqlType = columns[k.column]
+
+	return k, nil
+}
+
+// apply orders the query by the keyset, continues it after the cursor and selects the sort value
+// of the rows as text after their columns, to be kept in the next cursor.
+// It selects one row more than the limit to know if there's a next page.
+func (k keyset) apply(q *listQuery, limit int32) {
+	if k.from != nil {
+		operator := ">"
+		if k.direction == "desc" {
+			operator = "<"
+		}
+		q.where(fmt.Sprintf("(%s, id) %s (%s::text::%s, %s::bigint)", k.column, operator, q.arg(k.from.Value), k.sqlType, q.arg(k.from.ID)))
+	}
+
+	q.orderBy = fmt.Sprintf("%[1]s %[2]s, id %[2]s", k.column, k.direction)
+	q.columns += fmt.Sprintf(", %s::text", k.column)
+	q.page(limit+1, 0)
+}
+
+// keysetPage runs the query after apply and returns the rows of the page and the cursor of the next one, empty on the last page
+func keysetPage[T any](ctx context.Context, db DBTX, k keyset, q *listQuery, limit int32, scan func(scanner, ...interface{}) (T, error), id func(T) int64) ([]T, string, error) {
+	k.apply(q, limit)
+
+	var values []string
+	items, err := listRows(ctx, db, q, func(row scanner, _ ...interface{}) (T, error) {
+		var value string
+		item, err := scan(row, &value)
+		values = append(values, value)
+		return item, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(items) <= int(limit) {
+		return items, "", nil
+	}
+
+	last := items[limit-1]
+	next := cursor{SortField: k.column, SortDirection: k.direction, Value: values[limit-1], ID: id(last)}.encode()
+	return items[:limit], next, nil
+}
+
+// ListSalesPage lists the sales after the cursor and returns the cursor of the next page, empty on the last one.
+// The search matches the same fields as SearchSales and the filter is the one of FilterSales.
+func (store *SortableSQLStore) ListSalesPage(ctx context.Context, arg SalePageParams) ([]Sale, string, error) {
+	k, err := newKeyset(arg.PageParams, saleSortColumns)
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := newListQuery("sale", saleColumns, saleSortColumns).search(arg.Search, saleSearchColumns...)
+	arg.SaleFilter.apply(q)
+	return keysetPage(ctx, store.db, k, q, arg.Limit, scanSale, func(s Sale) int64 { return s.ID })
+}
+
+// ListClientsPage lists the clients after the cursor and returns the cursor of the next page, empty on the last one.
+// The search matches the same fields as SearchClients.
+func (store *SortableSQLStore) ListClientsPage(ctx context.Context, arg PageParams) ([]Client, string, error) {
+	k, err := newKeyset(arg, clientSortColumns)
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := newListQuery("client", clientColumns, clientSortColumns).search(arg.Search, clientSearchColumns...)
+	return keysetPage(ctx, store.db, k, q, arg.Limit, scanClient, func(c Client) int64 { return c.ID })
+}
+
+// ListProductsPage lists the products after the cursor and returns the cursor of the next page, empty on the last one.
+// It filters like FilterProducts, but the rows are ordered by the sort field or the id, not by the rank of the search.
+func (store *SortableSQLStore) ListProductsPage(ctx context.Context, arg ProductPageParams) ([]Product, string, error) {
+	k, err := newKeyset(arg.PageParams, productSortColumns)
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := productListQuery(arg.PublicOnly)
+	filterProducts(q, arg.CategoryIDs, arg.BrandIDs)
+	if arg.Search != "" {
+		q.where(productSearchCondition(q.arg(arg.Search)))
+	}
+	return keysetPage(ctx, store.db, k, q, arg.Limit, scanProduct, func(p Product) int64 { return p.ID })
+}
+
+// ListImagesPage lists the images after the cursor and returns the cursor of the next page, empty on the last one.
+// The search matches the same fields as SearchImages.
+func (store *SortableSQLStore) ListImagesPage(ctx context.Context, arg PageParams) ([]Image, string, error) {
+	k, err := newKeyset(arg, imageSortColumns)
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := newListQuery("images", imageColumns, imageSortColumns).search(arg.Search, imageSearchColumns...)
+	return keysetPage(ctx, store.db, k, q, arg.Limit, scanImage, func(i Image) int64 { return i.ID })
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"super-pet-delivery/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSalesPage(t *testing.T) {
+	client := createRandomClient(t)
+	token := util.RandomString(12)
+
+	var ids []int64
+	for i := 0; i < 7; i++ {
+		sale, err := testQueries.CreateSale(context.Background(), CreateSaleParams{
+			ClientID: client.ID,
+			Product:  fmt.Sprintf("%s %d", token, i),
+			Price:    1000,
+			Status:   SaleStatusConfirmed,
+		})
+		require.NoError(t, err)
+		ids = append(ids, sale.ID)
+	}
+
+	// every sale has the same price, the id keeps the order between them
+	store := NewSortableStore(testDB)
+	arg := SalePageParams{PageParams: PageParams{Limit: 3, SortField: "price", SortDirection: "asc", Search: token}}
+
+	var got []int64
+	pages := 0
+	for {
+		sales, next, err := store.ListSalesPage(context.Background(), arg)
+		require.NoError(t, err)
+		pages++
+		for _, sale := range sales {
+			got = append(got, sale.ID)
+		}
+
+		if next == "" {
+			break
+		}
+		// a sale created meanwhile doesn't shift the next pages
+		if pages == 1 {
+			_, err = testQueries.CreateSale(context.Background(), CreateSaleParams{ClientID: client.ID, Product: token, Price: 1, Status: SaleStatusConfirmed})
+			require.NoError(t, err)
+		}
+		arg = SalePageParams{PageParams: PageParams{Cursor: next, Limit: 3, Search: token}}
+	}
+
+	require.Equal(t, 3, pages)
+	require.Equal(t, ids, got)
+}
+
+func TestListSalesPageInvalidCursor(t *testing.T) {
+	store := NewSortableStore(testDB)
+
+	_, _, err := store.ListSalesPage(context.Background(), SalePageParams{PageParams: PageParams{Cursor: "not a cursor", Limit: 5}})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	// a cursor sorted by a column the sales don't have
+	c := cursor{SortField: "full_name; DROP TABLE sale", SortDirection: "asc", Value: "a", ID: 1}.encode()
+	_, _, err = store.ListSalesPage(context.Background(), SalePageParams{PageParams: PageParams{Cursor: c, Limit: 5}})
+	require.Error(t, err)
+}
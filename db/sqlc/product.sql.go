@@ -7,8 +7,7 @@ package db
 
 import (
 	"context"
-
-	"github.com/lib/pq"
+	"time"
 )
 
 const countProducts = `-- name: CountProducts :one
@@ -22,6 +21,18 @@ func (q *Queries) CountProducts(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countPublishedProducts = `-- name: CountPublishedProducts :one
+SELECT COUNT(*) FROM products
+WHERE status = 'published' AND deleted_at = '0001-01-01 00:00:00Z'
+`
+
+func (q *Queries) CountPublishedProducts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPublishedProducts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProduct = `-- name: CreateProduct :one
 INSERT INTO products (
     name,
@@ -32,24 +43,31 @@ INSERT INTO products (
     old_price,
     sku,
     url,
-    categories,
-    images
+    supplier_id,
+    cost_price,
+    brand_id,
+    status,
+    published_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING id, name, description, user_id, username, price, old_price, sku, images, categories, url, created_at, changed_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+    CASE WHEN $12::varchar = 'published' THEN now() AT TIME ZONE 'America/Sao_Paulo' ELSE '0001-01-01 00:00:00Z' END
+) RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
 `
 
 type CreateProductParams struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	UserID      int64    `json:"user_id"`
-	Username    string   `json:"username"`
-	Price       float64  `json:"price"`
-	OldPrice    float64  `json:"old_price"`
-	Sku         string   `json:"sku"`
-	Url         string   `json:"url"`
-	Categories  []int64  `json:"categories"`
-	Images      []string `json:"images"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	UserID      int64   `json:"user_id"`
+	Username    string  `json:"username"`
+	Price       float64 `json:"price"`
+	OldPrice    float64 `json:"old_price"`
+	Sku         string  `json:"sku"`
+	Url         string  `json:"url"`
+	SupplierID  int64   `json:"supplier_id"`
+	CostPrice   float64 `json:"cost_price"`
+	BrandID     int64   `json:"brand_id"`
+	Status      string  `json:"status"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
@@ -62,8 +80,10 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.OldPrice,
 		arg.Sku,
 		arg.Url,
-		pq.Array(arg.Categories),
-		pq.Array(arg.Images),
+		arg.SupplierID,
+		arg.CostPrice,
+		arg.BrandID,
+		arg.Status,
 	)
 	var i Product
 	err := row.Scan(
@@ -75,11 +95,18 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.Price,
 		&i.OldPrice,
 		&i.Sku,
-		pq.Array(&i.Images),
-		pq.Array(&i.Categories),
 		&i.Url,
 		&i.CreatedAt,
 		&i.ChangedAt,
+		&i.PromotionEndsAt,
+		&i.SupplierID,
+		&i.CostPrice,
+		&i.LastCost,
+		&i.BrandID,
+		&i.Status,
+		&i.PublishedAt,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -95,7 +122,9 @@ func (q *Queries) DeleteProduct(ctx context.Context, id int64) error {
 }
 
 const getProduct = `-- name: GetProduct :one
-SELECT id, name, description, user_id, username, price, old_price, sku, images, categories, url, created_at, changed_at FROM products 
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
 WHERE id = $1 LIMIT 1
 `
 
@@ -111,17 +140,26 @@ func (q *Queries) GetProduct(ctx context.Context, id int64) (Product, error) {
 		&i.Price,
 		&i.OldPrice,
 		&i.Sku,
-		pq.Array(&i.Images),
-		pq.Array(&i.Categories),
 		&i.Url,
 		&i.CreatedAt,
 		&i.ChangedAt,
+		&i.PromotionEndsAt,
+		&i.SupplierID,
+		&i.CostPrice,
+		&i.LastCost,
+		&i.BrandID,
+		&i.Status,
+		&i.PublishedAt,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getProductByURL = `-- name: GetProductByURL :one
-SELECT id, name, description, user_id, username, price, old_price, sku, images, categories, url, created_at, changed_at FROM products 
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
 WHERE url = $1 LIMIT 1
 `
 
@@ -137,17 +175,62 @@ func (q *Queries) GetProductByURL(ctx context.Context, url string) (Product, err
 		&i.Price,
 		&i.OldPrice,
 		&i.Sku,
-		pq.Array(&i.Images),
-		pq.Array(&i.Categories),
 		&i.Url,
 		&i.CreatedAt,
 		&i.ChangedAt,
+		&i.PromotionEndsAt,
+		&i.SupplierID,
+		&i.CostPrice,
+		&i.LastCost,
+		&i.BrandID,
+		&i.Status,
+		&i.PublishedAt,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getProductForUpdate = `-- name: GetProductForUpdate :one
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetProductForUpdate(ctx context.Context, id int64) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getProductForUpdate, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.UserID,
+		&i.Username,
+		&i.Price,
+		&i.OldPrice,
+		&i.Sku,
+		&i.Url,
+		&i.CreatedAt,
+		&i.ChangedAt,
+		&i.PromotionEndsAt,
+		&i.SupplierID,
+		&i.CostPrice,
+		&i.LastCost,
+		&i.BrandID,
+		&i.Status,
+		&i.PublishedAt,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const listProducts = `-- name: ListProducts :many
-SELECT id, name, description, user_id, username, price, old_price, sku, images, categories, url, created_at, changed_at FROM products 
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
 ORDER BY id DESC
 LIMIT $1
 OFFSET $2
@@ -176,11 +259,18 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 			&i.Price,
 			&i.OldPrice,
 			&i.Sku,
-			pq.Array(&i.Images),
-			pq.Array(&i.Categories),
 			&i.Url,
 			&i.CreatedAt,
 			&i.ChangedAt,
+			&i.PromotionEndsAt,
+			&i.SupplierID,
+			&i.CostPrice,
+			&i.LastCost,
+			&i.BrandID,
+			&i.Status,
+			&i.PublishedAt,
+			&i.DeletedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -196,7 +286,9 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 }
 
 const listProductsByUser = `-- name: ListProductsByUser :many
-SELECT id, name, description, user_id, username, price, old_price, sku, images, categories, url, created_at, changed_at FROM products
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
 WHERE user_id = $1
 ORDER BY id
 `
@@ -219,11 +311,77 @@ func (q *Queries) ListProductsByUser(ctx context.Context, userID int64) ([]Produ
 			&i.Price,
 			&i.OldPrice,
 			&i.Sku,
-			pq.Array(&i.Images),
-			pq.Array(&i.Categories),
 			&i.Url,
 			&i.CreatedAt,
 			&i.ChangedAt,
+			&i.PromotionEndsAt,
+			&i.SupplierID,
+			&i.CostPrice,
+			&i.LastCost,
+			&i.BrandID,
+			&i.Status,
+			&i.PublishedAt,
+			&i.DeletedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublishedProducts = `-- name: ListPublishedProducts :many
+SELECT id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+FROM products
+WHERE status = 'published' AND deleted_at = '0001-01-01 00:00:00Z'
+ORDER BY id DESC
+LIMIT $1
+OFFSET $2
+`
+
+type ListPublishedProductsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListPublishedProducts(ctx context.Context, arg ListPublishedProductsParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listPublishedProducts, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.UserID,
+			&i.Username,
+			&i.Price,
+			&i.OldPrice,
+			&i.Sku,
+			&i.Url,
+			&i.CreatedAt,
+			&i.ChangedAt,
+			&i.PromotionEndsAt,
+			&i.SupplierID,
+			&i.CostPrice,
+			&i.LastCost,
+			&i.BrandID,
+			&i.Status,
+			&i.PublishedAt,
+			&i.DeletedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -238,6 +396,138 @@ func (q *Queries) ListProductsByUser(ctx context.Context, userID int64) ([]Produ
 	return items, nil
 }
 
+const reassignProductsUser = `-- name: ReassignProductsUser :execrows
+UPDATE products
+SET
+    user_id = $1,
+    username = $2,
+    version = version + 1,
+    changed_at = now()
+WHERE user_id = $3
+`
+
+type ReassignProductsUserParams struct {
+	NewUserID   int64  `json:"new_user_id"`
+	NewUsername string `json:"new_username"`
+	UserID      int64  `json:"user_id"`
+}
+
+func (q *Queries) ReassignProductsUser(ctx context.Context, arg ReassignProductsUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reassignProductsUser, arg.NewUserID, arg.NewUsername, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreProduct = `-- name: RestoreProduct :one
+UPDATE products
+SET deleted_at = '0001-01-01 00:00:00Z', version = version + 1, changed_at = now()
+WHERE id = $1 AND deleted_at <> '0001-01-01 00:00:00Z'
+RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+`
+
+func (q *Queries) RestoreProduct(ctx context.Context, id int64) (Product, error) {
+	row := q.db.QueryRowContext(ctx, restoreProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.UserID,
+		&i.Username,
+		&i.Price,
+		&i.OldPrice,
+		&i.Sku,
+		&i.Url,
+		&i.CreatedAt,
+		&i.ChangedAt,
+		&i.PromotionEndsAt,
+		&i.SupplierID,
+		&i.CostPrice,
+		&i.LastCost,
+		&i.BrandID,
+		&i.Status,
+		&i.PublishedAt,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const softDeleteProduct = `-- name: SoftDeleteProduct :one
+UPDATE products
+SET deleted_at = now() AT TIME ZONE 'America/Sao_Paulo', version = version + 1, changed_at = now()
+WHERE id = $1 AND deleted_at = '0001-01-01 00:00:00Z'
+RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+`
+
+func (q *Queries) SoftDeleteProduct(ctx context.Context, id int64) (Product, error) {
+	row := q.db.QueryRowContext(ctx, softDeleteProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.UserID,
+		&i.Username,
+		&i.Price,
+		&i.OldPrice,
+		&i.Sku,
+		&i.Url,
+		&i.CreatedAt,
+		&i.ChangedAt,
+		&i.PromotionEndsAt,
+		&i.SupplierID,
+		&i.CostPrice,
+		&i.LastCost,
+		&i.BrandID,
+		&i.Status,
+		&i.PublishedAt,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const touchProduct = `-- name: TouchProduct :one
+UPDATE products
+SET version = version + 1, changed_at = now()
+WHERE id = $1
+RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+`
+
+func (q *Queries) TouchProduct(ctx context.Context, id int64) (Product, error) {
+	row := q.db.QueryRowContext(ctx, touchProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.UserID,
+		&i.Username,
+		&i.Price,
+		&i.OldPrice,
+		&i.Sku,
+		&i.Url,
+		&i.CreatedAt,
+		&i.ChangedAt,
+		&i.PromotionEndsAt,
+		&i.SupplierID,
+		&i.CostPrice,
+		&i.LastCost,
+		&i.BrandID,
+		&i.Status,
+		&i.PublishedAt,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
 const updateProduct = `-- name: UpdateProduct :one
 UPDATE  products 
 SET 
@@ -249,24 +539,37 @@ SET
     old_price = COALESCE($7, old_price),
     sku = COALESCE($8, sku),
     url = COALESCE($9, url),
-    images = COALESCE($10, images),
-    categories = COALESCE($11, categories)
-WHERE id = $1
-RETURNING id, name, description, user_id, username, price, old_price, sku, images, categories, url, created_at, changed_at
+    supplier_id = COALESCE($10, supplier_id),
+    cost_price = COALESCE($11, cost_price),
+    brand_id = COALESCE($12, brand_id),
+    status = COALESCE(NULLIF($13::varchar, ''), status),
+    -- published_at keeps the first time the product was published
+    published_at = CASE
+        WHEN $13::varchar = 'published' AND published_at = '0001-01-01 00:00:00Z' THEN now() AT TIME ZONE 'America/Sao_Paulo'
+        ELSE published_at
+    END,
+    version = version + 1,
+    changed_at = now()
+WHERE id = $1 AND version = $14
+RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
 `
 
 type UpdateProductParams struct {
-	ID          int64    `json:"id"`
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	UserID      int64    `json:"user_id"`
-	Username    string   `json:"username"`
-	Price       float64  `json:"price"`
-	OldPrice    float64  `json:"old_price"`
-	Sku         string   `json:"sku"`
-	Url         string   `json:"url"`
-	Images      []string `json:"images"`
-	Categories  []int64  `json:"categories"`
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	UserID      int64   `json:"user_id"`
+	Username    string  `json:"username"`
+	Price       float64 `json:"price"`
+	OldPrice    float64 `json:"old_price"`
+	Sku         string  `json:"sku"`
+	Url         string  `json:"url"`
+	SupplierID  int64   `json:"supplier_id"`
+	CostPrice   float64 `json:"cost_price"`
+	BrandID     int64   `json:"brand_id"`
+	Status      string  `json:"status"`
+	Version     int64   `json:"version"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error) {
@@ -280,8 +583,80 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		arg.OldPrice,
 		arg.Sku,
 		arg.Url,
-		pq.Array(arg.Images),
-		pq.Array(arg.Categories),
+		arg.SupplierID,
+		arg.CostPrice,
+		arg.BrandID,
+		arg.Status,
+		arg.Version,
+	)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.UserID,
+		&i.Username,
+		&i.Price,
+		&i.OldPrice,
+		&i.Sku,
+		&i.Url,
+		&i.CreatedAt,
+		&i.ChangedAt,
+		&i.PromotionEndsAt,
+		&i.SupplierID,
+		&i.CostPrice,
+		&i.LastCost,
+		&i.BrandID,
+		&i.Status,
+		&i.PublishedAt,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const updateProductLastCost = `-- name: UpdateProductLastCost :exec
+UPDATE products
+SET last_cost = $2, version = version + 1, changed_at = now()
+WHERE id = $1
+`
+
+type UpdateProductLastCostParams struct {
+	ID       int64   `json:"id"`
+	LastCost float64 `json:"last_cost"`
+}
+
+func (q *Queries) UpdateProductLastCost(ctx context.Context, arg UpdateProductLastCostParams) error {
+	_, err := q.db.ExecContext(ctx, updateProductLastCost, arg.ID, arg.LastCost)
+	return err
+}
+
+const updateProductPricing = `-- name: UpdateProductPricing :one
+UPDATE products
+SET
+    price = $2,
+    old_price = $3,
+    promotion_ends_at = $4,
+    version = version + 1,
+    changed_at = now()
+WHERE id = $1
+RETURNING id, name, description, user_id, username, price, old_price, sku, url, created_at, changed_at,
+    promotion_ends_at, supplier_id, cost_price, last_cost, brand_id, status, published_at, deleted_at, version
+`
+
+type UpdateProductPricingParams struct {
+	ID              int64     `json:"id"`
+	Price           float64   `json:"price"`
+	OldPrice        float64   `json:"old_price"`
+	PromotionEndsAt time.Time `json:"promotion_ends_at"`
+}
+
+func (q *Queries) UpdateProductPricing(ctx context.Context, arg UpdateProductPricingParams) (Product, error) {
+	row := q.db.QueryRowContext(ctx, updateProductPricing,
+		arg.ID,
+		arg.Price,
+		arg.OldPrice,
+		arg.PromotionEndsAt,
 	)
 	var i Product
 	err := row.Scan(
@@ -293,11 +668,18 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.Price,
 		&i.OldPrice,
 		&i.Sku,
-		pq.Array(&i.Images),
-		pq.Array(&i.Categories),
 		&i.Url,
 		&i.CreatedAt,
 		&i.ChangedAt,
+		&i.PromotionEndsAt,
+		&i.SupplierID,
+		&i.CostPrice,
+		&i.LastCost,
+		&i.BrandID,
+		&i.Status,
+		&i.PublishedAt,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+)
+
+// ProductCategoriesTxParams contains the input parameters of the product category transactions
+type ProductCategoriesTxParams struct {
+	ProductID   int64   `json:"product_id"`
+	CategoryIDs []int64 `json:"category_ids"`
+}
+
+// AssociateCategoriesTx links the product to the categories it is not linked to yet
+func (store *SQLStore) AssociateCategoriesTx(ctx context.Context, arg ProductCategoriesTxParams) (Product, error) {
+	var product Product
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		if err = associateCategories(ctx, q, arg.ProductID, arg.CategoryIDs); err != nil {
+			return err
+		}
+
+		product, err = q.TouchProduct(ctx, arg.ProductID)
+		return err
+	})
+
+	return product, err
+}
+
+// DisassociateCategoriesTx removes the links of the product to the categories.
+// It fails with sql.ErrNoRows when the product isn't linked to one of them.
+func (store *SQLStore) DisassociateCategoriesTx(ctx context.Context, arg ProductCategoriesTxParams) (Product, error) {
+	var product Product
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		for _, categoryID := range arg.CategoryIDs {
+			_, err = q.DisassociateProductFromCategory(ctx, DisassociateProductFromCategoryParams{
+				ProductID:  arg.ProductID,
+				CategoryID: categoryID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		product, err = q.TouchProduct(ctx, arg.ProductID)
+		return err
+	})
+
+	return product, err
+}
+
+// SetProductCategoriesTx replaces all the categories of the product
+func (store *SQLStore) SetProductCategoriesTx(ctx context.Context, arg ProductCategoriesTxParams) (Product, error) {
+	var product Product
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+		product, err = setProductCategories(ctx, q, arg.ProductID, arg.CategoryIDs)
+		return err
+	})
+
+	return product, err
+}
+
+func associateCategories(ctx context.Context, q *Queries, productID int64, categoryIDs []int64) error {
+	current, err := q.ListCategoriesByProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	linked := make(map[int64]bool, len(current))
+	for _, category := range current {
+		linked[category.ID] = true
+	}
+
+	for _, categoryID := range categoryIDs {
+		if linked[categoryID] {
+			continue
+		}
+
+		_, err = q.AssociateProductWithCategory(ctx, AssociateProductWithCategoryParams{
+			ProductID:  productID,
+			CategoryID: categoryID,
+		})
+		if err != nil {
+			return err
+		}
+		linked[categoryID] = true
+	}
+
+	return nil
+}
+
+func setProductCategories(ctx context.Context, q *Queries, productID int64, categoryIDs []int64) (Product, error) {
+	if err := q.DeleteProductCategories(ctx, productID); err != nil {
+		return Product{}, err
+	}
+
+	if err := associateCategories(ctx, q, productID, categoryIDs); err != nil {
+		return Product{}, err
+	}
+
+	return q.TouchProduct(ctx, productID)
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisassociateCategoriesTxKeepsProduct(t *testing.T) {
+	store := NewStore(testDB)
+	category1 := createRandomCategory(t)
+	category2 := createRandomCategory(t)
+
+	product := createRandomProduct(t)
+	product, err := testQueries.UpdateProductPricing(context.Background(), UpdateProductPricingParams{
+		ID:       product.ID,
+		Price:    80,
+		OldPrice: 100,
+	})
+	require.NoError(t, err)
+
+	product, err = store.AssociateCategoriesTx(context.Background(), ProductCategoriesTxParams{
+		ProductID:   product.ID,
+		CategoryIDs: []int64{category2.ID, category1.ID, category1.ID},
+	})
+	require.NoError(t, err)
+
+	categories, err := testQueries.ListCategoriesByProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, categories, 2)
+
+	product, err = store.DisassociateCategoriesTx(context.Background(), ProductCategoriesTxParams{
+		ProductID:   product.ID,
+		CategoryIDs: []int64{category1.ID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 100.0, product.OldPrice)
+
+	// nothing changes when one of the links doesn't exist
+	_, err = store.DisassociateCategoriesTx(context.Background(), ProductCategoriesTxParams{
+		ProductID:   product.ID,
+		CategoryIDs: []int64{category2.ID, category1.ID},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	categories, err = testQueries.ListCategoriesByProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, categories, 1)
+	require.Equal(t, category2.ID, categories[0].ID)
+}
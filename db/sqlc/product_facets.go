@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// ProductPriceBuckets are the limits of the price ranges counted by the product facets,
+// the first range starts at 0 and the last one has no upper limit
+var ProductPriceBuckets = []float64{50, 100, 200, 500}
+
+// ProductFacet is a category or brand the products can be filtered by and how many products it matches
+type ProductFacet struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Count int64  `json:"count"`
+}
+
+// PriceFacet is a price range and how many products it matches, Max is 0 for the last range
+type PriceFacet struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int64   `json:"count"`
+}
+
+// ProductFacets are the counts the storefront shows next to the product filters
+type ProductFacets struct {
+	Categories []ProductFacet `json:"categories"`
+	Brands     []ProductFacet `json:"brands"`
+	Prices     []PriceFacet   `json:"prices"`
+}
+
+// the category counts include the products of the subcategories, like FilterProducts does.
+// The categories are counted with the brand filter only and the brands with the category filter only,
+// so choosing a category doesn't hide the other ones.
+const productFacetsQuery = `, category_ancestors AS (
+		SELECT id AS category_id, id AS ancestor_id, parent_id FROM categories
+		UNION ALL
+		SELECT a.category_id, c.id, c.parent_id FROM category_ancestors a JOIN categories c ON c.id = a.parent_id
+	), matched AS (
+		SELECT id, brand_id, price,
+		(COALESCE(array_length($1::bigint[], 1), 0) = 0 OR EXISTS (SELECT 1 FROM product_categories pc WHERE pc.product_id = products.id AND pc.category_id IN (SELECT id FROM category_tree))) AS in_categories,
+		(COALESCE(array_length($3::bigint[], 1), 0) = 0 OR brand_id = ANY($3)) AS in_brands
+		FROM products
+		WHERE `
+
+const productFacetsCounts = `
+	)
+	SELECT 'category', c.id, c.name, c.slug, COUNT(DISTINCT m.id)
+	FROM matched m
+	JOIN product_categories pc ON pc.product_id = m.id
+	JOIN category_ancestors a ON a.category_id = pc.category_id
+	JOIN categories c ON c.id = a.ancestor_id
+	WHERE m.in_brands
+	GROUP BY c.id, c.name, c.slug
+	UNION ALL
+	SELECT 'brand', b.id, b.name, b.slug, COUNT(*)
+	FROM matched m
+	JOIN brands b ON b.id = m.brand_id
+	WHERE m.in_categories
+	GROUP BY b.id, b.name, b.slug
+	UNION ALL
+	SELECT 'price', width_bucket(m.price::float8, $4::float8[]), '', '', COUNT(*)
+	FROM matched m
+	WHERE m.in_categories AND m.in_brands
+	GROUP BY 2
+	ORDER BY 1, 5 DESC, 3`
+
+// ProductFacets counts the products of the current search by category, brand and price range in a single query.
+// publicOnly leaves out the products that aren't published or were deleted.
+func (store *SortableSQLStore) ProductFacets(ctx context.Context, categoryIds []int64, brandIds []int64, search string, publicOnly bool) (ProductFacets, error) {
+	query := categoryTree("$1") + productFacetsQuery + productSearchCondition("$2")
+	if publicOnly {
+		query += " AND " + publicProductFilter
+	}
+	query += productFacetsCounts
+
+	facets := ProductFacets{
+		Categories: []ProductFacet{},
+		Brands:     []ProductFacet{},
+		Prices:     make([]PriceFacet, len(ProductPriceBuckets)+1),
+	}
+	for i := range facets.Prices {
+		if i > 0 {
+			facets.Prices[i].Min = ProductPriceBuckets[i-1]
+		}
+		if i < len(ProductPriceBuckets) {
+			facets.Prices[i].Max = ProductPriceBuckets[i]
+		}
+	}
+
+	rows, err := store.db.QueryContext(ctx, query, pq.Array(categoryIds), search, pq.Array(brandIds), pq.Array(ProductPriceBuckets))
+	if err != nil {
+		return ProductFacets{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var kind string
+		var facet ProductFacet
+		if err := rows.Scan(&kind, &facet.ID, &facet.Name, &facet.Slug, &facet.Count); err != nil {
+			return ProductFacets{}, err
+		}
+
+		switch kind {
+		case "category":
+			facets.Categories = append(facets.Categories, facet)
+		case "brand":
+			facets.Brands = append(facets.Brands, facet)
+		case "price":
+			facets.Prices[facet.ID].Count = facet.Count
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return ProductFacets{}, err
+	}
+
+	return facets, nil
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"super-pet-delivery/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductFacets(t *testing.T) {
+	parent := createRandomCategory(t)
+	child, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		Name:     util.RandomFullName(),
+		ParentID: parent.ID,
+		Slug:     util.RandomString(12),
+	})
+	require.NoError(t, err)
+	brand := createRandomBrand(t)
+	user := createRandomUser(t)
+	token := util.RandomString(10)
+
+	store := NewSortableStore(testDB)
+	product, err := store.CreateProductTx(context.Background(), CreateProductTxParams{
+		CreateProductParams: CreateProductParams{
+			Name:    "Ração " + token,
+			UserID:  user.ID,
+			Price:   75,
+			Sku:     util.RandomString(8),
+			BrandID: brand.ID,
+			Status:  ProductStatusPublished,
+		},
+		Categories: []int64{child.ID},
+	})
+	require.NoError(t, err)
+
+	facets, err := store.ProductFacets(context.Background(), nil, nil, token, true)
+	require.NoError(t, err)
+
+	// the parent category counts the products of its subcategories
+	counts := map[int64]int64{}
+	for _, facet := range facets.Categories {
+		counts[facet.ID] = facet.Count
+	}
+	require.Equal(t, int64(1), counts[parent.ID])
+	require.Equal(t, int64(1), counts[child.ID])
+
+	require.Len(t, facets.Brands, 1)
+	require.Equal(t, ProductFacet{ID: brand.ID, Name: brand.Name, Slug: brand.Slug, Count: 1}, facets.Brands[0])
+
+	require.Len(t, facets.Prices, len(ProductPriceBuckets)+1)
+	require.Equal(t, PriceFacet{Min: 50, Max: 100, Count: 1}, facets.Prices[1])
+
+	// a brand filter that leaves the product out empties the category counts, the brand counts stay
+	other := createRandomBrand(t)
+	facets, err = store.ProductFacets(context.Background(), nil, []int64{other.ID}, token, true)
+	require.NoError(t, err)
+	require.Empty(t, facets.Categories)
+	require.Len(t, facets.Brands, 1)
+	require.Zero(t, facets.Prices[1].Count)
+
+	// the deleted products aren't counted for the visitors
+	_, err = testQueries.SoftDeleteProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	facets, err = store.ProductFacets(context.Background(), nil, nil, token, true)
+	require.NoError(t, err)
+	require.Empty(t, facets.Brands)
+}
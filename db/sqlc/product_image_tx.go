@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+)
+
+// ProductImageOrder is an image linked to a product and its position among the product images
+type ProductImageOrder struct {
+	ImageID int64 `json:"id"`
+	Order   int32 `json:"order"`
+}
+
+// ProductImagesTxParams contains the input parameters of the product image transactions
+type ProductImagesTxParams struct {
+	ProductID int64               `json:"product_id"`
+	Images    []ProductImageOrder `json:"images"`
+}
+
+// AssociateImagesTx links the product to the images it is not linked to yet
+func (store *SQLStore) AssociateImagesTx(ctx context.Context, arg ProductImagesTxParams) (Product, error) {
+	var product Product
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		if err = associateImages(ctx, q, arg.ProductID, arg.Images); err != nil {
+			return err
+		}
+
+		product, err = q.TouchProduct(ctx, arg.ProductID)
+		return err
+	})
+
+	return product, err
+}
+
+// DisassociateImagesTx removes the links of the product to the images.
+// It fails with sql.ErrNoRows when the product isn't linked to one of them.
+func (store *SQLStore) DisassociateImagesTx(ctx context.Context, productID int64, imageIDs []int64) (Product, error) {
+	var product Product
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		for _, imageID := range imageIDs {
+			_, err = q.DisassociateProductFromImage(ctx, DisassociateProductFromImageParams{
+				ProductID: productID,
+				ImageID:   imageID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		product, err = q.TouchProduct(ctx, productID)
+		return err
+	})
+
+	return product, err
+}
+
+// EditImageOrderTxResult is the result of the edit image order transaction
+type EditImageOrderTxResult struct {
+	Product Product        `json:"product"`
+	Images  []ProductImage `json:"images"`
+}
+
+// EditImageOrderTx changes the order of the images of the product, either all of them change or none.
+// It fails with sql.ErrNoRows when the product isn't linked to one of the images.
+func (store *SQLStore) EditImageOrderTx(ctx context.Context, arg ProductImagesTxParams) (EditImageOrderTxResult, error) {
+	var result EditImageOrderTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		result.Images = make([]ProductImage, 0, len(arg.Images))
+		for _, image := range arg.Images {
+			updated, err := q.EditAssociation(ctx, EditAssociationParams{
+				ProductID: arg.ProductID,
+				ImageID:   image.ImageID,
+				Order:     image.Order,
+			})
+			if err != nil {
+				return err
+			}
+			result.Images = append(result.Images, updated)
+		}
+
+		var err error
+		result.Product, err = q.TouchProduct(ctx, arg.ProductID)
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteImageTx removes the image from the products using it and deletes it
+func (store *SQLStore) DeleteImageTx(ctx context.Context, imageID int64) error {
+	return store.ExecTx(ctx, func(q *Queries) error {
+		productIDs, err := q.DeleteImageLinks(ctx, imageID)
+		if err != nil {
+			return err
+		}
+
+		for _, productID := range productIDs {
+			if _, err = q.TouchProduct(ctx, productID); err != nil {
+				return err
+			}
+		}
+
+		return q.DeleteImage(ctx, imageID)
+	})
+}
+
+func associateImages(ctx context.Context, q *Queries, productID int64, images []ProductImageOrder) error {
+	current, err := q.ListImagesByProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	linked := make(map[int64]bool, len(current))
+	for _, image := range current {
+		linked[image.ID] = true
+	}
+
+	for _, image := range images {
+		if linked[image.ImageID] {
+			continue
+		}
+
+		_, err = q.AssociateProductWithImage(ctx, AssociateProductWithImageParams{
+			ProductID: productID,
+			ImageID:   image.ImageID,
+			Order:     image.Order,
+		})
+		if err != nil {
+			return err
+		}
+		linked[image.ImageID] = true
+	}
+
+	return nil
+}
+
+// setProductImages replaces all the images of the product, the order is the position in imageIDs
+func setProductImages(ctx context.Context, q *Queries, productID int64, imageIDs []int64) (Product, error) {
+	if err := q.DeleteProductImages(ctx, productID); err != nil {
+		return Product{}, err
+	}
+
+	images := make([]ProductImageOrder, len(imageIDs))
+	for i, imageID := range imageIDs {
+		images[i] = ProductImageOrder{ImageID: imageID, Order: int32(i)}
+	}
+
+	if err := associateImages(ctx, q, productID, images); err != nil {
+		return Product{}, err
+	}
+
+	return q.TouchProduct(ctx, productID)
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"super-pet-delivery/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomImage(t *testing.T) Image {
+	name := util.RandomString(8)
+	image, err := testQueries.CreateImage(context.Background(), CreateImageParams{
+		Name:      name,
+		Alt:       util.RandomString(12),
+		ImagePath: fmt.Sprintf("/media/2023/10/%s.jpg", name),
+	})
+	require.NoError(t, err)
+	require.NotZero(t, image.ID)
+
+	return image
+}
+
+// productImagePaths lists the image paths of the product in order
+func productImagePaths(t *testing.T, productID int64) []string {
+	rows, err := testQueries.ListImagesByProducts(context.Background(), []int64{productID})
+	require.NoError(t, err)
+
+	paths := []string{}
+	for _, row := range rows {
+		paths = append(paths, row.ImagePath)
+	}
+	return paths
+}
+
+func TestEditImageOrderTx(t *testing.T) {
+	store := NewStore(testDB)
+	image1 := createRandomImage(t)
+	image2 := createRandomImage(t)
+	product := createRandomProduct(t)
+
+	product, err := store.AssociateImagesTx(context.Background(), ProductImagesTxParams{
+		ProductID: product.ID,
+		Images:    []ProductImageOrder{{ImageID: image1.ID, Order: 0}, {ImageID: image2.ID, Order: 1}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{image1.ImagePath, image2.ImagePath}, productImagePaths(t, product.ID))
+
+	result, err := store.EditImageOrderTx(context.Background(), ProductImagesTxParams{
+		ProductID: product.ID,
+		Images:    []ProductImageOrder{{ImageID: image1.ID, Order: 1}, {ImageID: image2.ID, Order: 0}},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Images, 2)
+	require.Equal(t, product.Version+1, result.Product.Version)
+	require.Equal(t, []string{image2.ImagePath, image1.ImagePath}, productImagePaths(t, product.ID))
+
+	// the order is kept when one of the images isn't linked to the product
+	other := createRandomImage(t)
+	_, err = store.EditImageOrderTx(context.Background(), ProductImagesTxParams{
+		ProductID: product.ID,
+		Images:    []ProductImageOrder{{ImageID: image1.ID, Order: 0}, {ImageID: other.ID, Order: 1}},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	images, err := testQueries.ListImagesByProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	require.Equal(t, image2.ID, images[0].ID)
+	require.Equal(t, image1.ID, images[1].ID)
+}
+
+func TestDeleteImageTx(t *testing.T) {
+	store := NewStore(testDB)
+	image1 := createRandomImage(t)
+	image2 := createRandomImage(t)
+	product := createRandomProduct(t)
+
+	product, err := store.UpdateProductTx(context.Background(), UpdateProductTxParams{
+		UpdateProductParams: UpdateProductParams{
+			ID:          product.ID,
+			Version:     product.Version,
+			Name:        product.Name,
+			Description: product.Description,
+			UserID:      product.UserID,
+			Username:    product.Username,
+			Price:       product.Price,
+			OldPrice:    product.OldPrice,
+			Sku:         product.Sku,
+			Url:         product.Url,
+		},
+		ImageIDs: []int64{image2.ID, image1.ID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{image2.ImagePath, image1.ImagePath}, productImagePaths(t, product.ID))
+
+	err = store.DeleteImageTx(context.Background(), image2.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetImage(context.Background(), image2.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	got, err := testQueries.GetProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, product.Version+1, got.Version)
+	require.Equal(t, []string{image1.ImagePath}, productImagePaths(t, product.ID))
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateProductTxRecordsPriceHistory(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	product, err := store.CreateProductTx(context.Background(), CreateProductTxParams{
+		CreateProductParams: CreateProductParams{
+			Name:        user.Username,
+			Description: "ração",
+			UserID:      user.ID,
+			Username:    user.Username,
+			Price:       100,
+			Url:         user.Username,
+		},
+		ChangedBy: user.Username,
+	})
+	require.NoError(t, err)
+
+	arg := UpdateProductTxParams{
+		UpdateProductParams: UpdateProductParams{
+			ID:          product.ID,
+			Version:     product.Version,
+			Name:        product.Name,
+			Description: "ração premium",
+			UserID:      product.UserID,
+			Username:    product.Username,
+			Price:       product.Price,
+			Url:         product.Url,
+		},
+		ChangedBy: user.Username,
+	}
+
+	// a change that does not touch the prices is not recorded
+	product, err = store.UpdateProductTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	arg.Version = product.Version
+	arg.Price = 80
+	arg.OldPrice = 100
+	_, err = store.UpdateProductTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	history, err := testQueries.ListProductPriceHistory(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, 80.0, history[0].Price)
+	require.Equal(t, 100.0, history[0].OldPrice)
+	require.Equal(t, PriceSourceManual, history[0].Source)
+	require.Equal(t, user.Username, history[0].ChangedBy)
+	require.Equal(t, PriceSourceInitial, history[1].Source)
+
+	current, err := testQueries.GetProductPriceAt(context.Background(), GetProductPriceAtParams{
+		ProductID: product.ID,
+		CreatedAt: time.Now().Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, history[0].ID, current.ID)
+
+	prices, err := testQueries.ListProductPricesAt(context.Background(), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+	require.NotEmpty(t, prices)
+}
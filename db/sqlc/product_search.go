@@ -0,0 +1,23 @@
+package db
+
+import "fmt"
+
+// productSearchCondition matches the products to the search term in the parameter p, an empty term matches
+// every product. The full text search finds the stems without accents, "racoes" finds "ração", the sku is
+// matched by its prefix, where % and _ are plain characters, and the trigram similarity of the name tolerates typos, "golde" finds "Golden".
+// The three are alternatives of the same search, productSearchRank puts the typos after the other matches.
+// The vector is computed by product_search_vector, the same expression as the index so it is used.
+func productSearchCondition(p string) string {
+	return fmt.Sprintf(`(%[1]s::text = '' OR
+	product_search_vector(name, sku, description) @@ websearch_to_tsquery('portuguese_unaccent', %[1]s::text) OR
+	LOWER(sku) LIKE replace(replace(replace(LOWER(%[1]s::text), '\', '\\'), '%%', '\%%'), '_', '\_') || '%%' OR
+	immutable_unaccent(LOWER(%[1]s::text)) <%% immutable_unaccent(LOWER(name)))`, p)
+}
+
+// productSearchRank orders the products found by the search term in the parameter p, the matches of the
+// full text search come first by their rank and the other matches after them by their similarity
+func productSearchRank(p string) string {
+	return fmt.Sprintf(`(product_search_vector(name, sku, description) @@ websearch_to_tsquery('portuguese_unaccent', %[1]s::text)) DESC,
+	ts_rank(product_search_vector(name, sku, description), websearch_to_tsquery('portuguese_unaccent', %[1]s::text)) DESC,
+	word_similarity(immutable_unaccent(LOWER(%[1]s::text)), immutable_unaccent(LOWER(name))) DESC, id DESC`, p)
+}
@@ -0,0 +1,22 @@
+package db
+
+// Statuses of a product. Only published products that aren't deleted are shown
+// on the public routes, drafts and archived products are seen by the admins only.
+const (
+	ProductStatusDraft     = "draft"
+	ProductStatusPublished = "published"
+	ProductStatusArchived  = "archived"
+)
+
+// publicProductFilter is the condition of the products shown on the public routes
+const publicProductFilter = "status = 'published' AND deleted_at = '0001-01-01 00:00:00Z'"
+
+// IsPublic tells whether the product is shown on the public routes
+func (product Product) IsPublic() bool {
+	return product.Status == ProductStatusPublished && product.DeletedAt.IsZero()
+}
+
+// IsDeleted tells whether the product was soft deleted
+func (product Product) IsDeleted() bool {
+	return !product.DeletedAt.IsZero()
+}
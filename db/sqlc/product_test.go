@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"super-pet-delivery/util"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -16,7 +17,7 @@ func createRandomProduct(t *testing.T) Product {
 		Description: util.RandomDescription(),
 		UserID:      user.ID,
 		Price:       0,
-		Images:      []string{},
+		Status:      ProductStatusDraft,
 	}
 
 	product, err := testQueries.CreateProduct(context.Background(), arg)
@@ -26,6 +27,8 @@ func createRandomProduct(t *testing.T) Product {
 	require.Equal(t, arg.Name, product.Name)
 	require.Equal(t, arg.Description, product.Description)
 	require.Equal(t, arg.UserID, product.UserID)
+	require.Equal(t, ProductStatusDraft, product.Status)
+	require.True(t, product.PublishedAt.IsZero())
 
 	require.NotZero(t, product.ID)
 	return product
@@ -110,13 +113,7 @@ func TestListProductsByCategory(t *testing.T) {
 	require.NoError(t, err3)
 	require.NotEmpty(t, associatedCat3)
 
-	paramsList := ListProductsByCategoryParams{
-		CategoryID: category.ID,
-		Limit:      3,
-		Offset:     0,
-	}
-
-	products, err := testQueries.ListProductsByCategory(context.Background(), paramsList)
+	products, err := testQueries.ListProductsByCategory(context.Background(), category.ID)
 	require.NoError(t, err)
 	require.Len(t, products, 3)
 
@@ -152,29 +149,29 @@ func TestUpdateProduct(t *testing.T) {
 	// update name
 	arg := UpdateProductParams{
 		ID:          product1.ID,
+		Version:     product1.Version,
 		Name:        util.RandomFullName(),
 		Description: product1.Description,
 		UserID:      product1.UserID,
 		Price:       product1.Price,
-		Images:      product1.Images,
 	}
 	// update description
 	arg2 := UpdateProductParams{
 		ID:          product2.ID,
+		Version:     product2.Version,
 		Name:        product2.Name,
 		Description: util.RandomDescription(),
 		UserID:      product2.UserID,
 		Price:       product2.Price,
-		Images:      product2.Images,
 	}
 	// update userID
 	arg3 := UpdateProductParams{
 		ID:          product3.ID,
+		Version:     product3.Version,
 		Name:        product3.Name,
 		Description: product3.Description,
 		UserID:      user.ID,
 		Price:       product3.Price,
-		Images:      product3.Images,
 	}
 
 	// testing update name
@@ -226,3 +223,168 @@ func TestDeleteProduct(t *testing.T) {
 	require.EqualError(t, err, sql.ErrNoRows.Error())
 	require.Empty(t, product2)
 }
+
+func TestPublishAndSoftDeleteProduct(t *testing.T) {
+	product := createRandomProduct(t)
+	store := NewSortableStore(testDB)
+	require.False(t, product.IsPublic())
+
+	// drafts are left out of the public lists
+	products, total, err := store.SearchProducts(context.Background(), product.Name, 1, 5, "", "", true)
+	require.NoError(t, err)
+	require.Empty(t, products)
+	require.Zero(t, total)
+
+	arg := UpdateProductParams{
+		ID:          product.ID,
+		Version:     product.Version,
+		Name:        product.Name,
+		Description: product.Description,
+		UserID:      product.UserID,
+		Status:      ProductStatusPublished,
+	}
+	published, err := testQueries.UpdateProduct(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, published.IsPublic())
+	require.False(t, published.PublishedAt.IsZero())
+
+	products, total, err = store.SearchProducts(context.Background(), product.Name, 1, 5, "", "", true)
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	require.Equal(t, int64(1), total)
+
+	// publishing again keeps the first publication date
+	arg.Status = ""
+	arg.Version = published.Version
+	updated, err := testQueries.UpdateProduct(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, ProductStatusPublished, updated.Status)
+	require.WithinDuration(t, published.PublishedAt, updated.PublishedAt, time.Second)
+
+	deleted, err := testQueries.SoftDeleteProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.True(t, deleted.IsDeleted())
+	require.False(t, deleted.IsPublic())
+
+	_, err = testQueries.SoftDeleteProduct(context.Background(), product.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	restored, err := testQueries.RestoreProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.False(t, restored.IsDeleted())
+	require.True(t, restored.IsPublic())
+
+	_, err = testQueries.RestoreProduct(context.Background(), product.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSearchProductsFullText(t *testing.T) {
+	user := createRandomUser(t)
+	token := util.RandomString(8)
+	product, err := testQueries.CreateProduct(context.Background(), CreateProductParams{
+		Name:        "Ração Cães Adultos " + token,
+		Description: "Alimento completo para cães",
+		UserID:      user.ID,
+		Sku:         "SKU-" + token,
+		Status:      ProductStatusPublished,
+	})
+	require.NoError(t, err)
+
+	store := NewSortableStore(testDB)
+	searches := []string{
+		// without accents
+		"racao " + token,
+		// sku prefix
+		"sku-" + token[:4],
+		// a typo in the name
+		token[:len(token)-1] + "x",
+	}
+	for _, search := range searches {
+		products, total, err := store.SearchProducts(context.Background(), search, 1, 10, "", "", true)
+		require.NoError(t, err, search)
+		require.NotZero(t, total, search)
+
+		found := false
+		for _, p := range products {
+			found = found || p.ID == product.ID
+		}
+		require.True(t, found, search)
+	}
+}
+
+func TestSearchProductsRanksMatchesFirst(t *testing.T) {
+	user := createRandomUser(t)
+	token := util.RandomString(8)
+
+	// the typo is close to the name but only the other product matches the words
+	typo, err := testQueries.CreateProduct(context.Background(), CreateProductParams{
+		Name:   token + "x",
+		UserID: user.ID,
+		Sku:    util.RandomString(10),
+		Status: ProductStatusPublished,
+	})
+	require.NoError(t, err)
+	match, err := testQueries.CreateProduct(context.Background(), CreateProductParams{
+		Name:        "Areia Sanitária",
+		Description: "Areia para gatos " + token,
+		UserID:      user.ID,
+		Sku:         util.RandomString(10),
+		Status:      ProductStatusPublished,
+	})
+	require.NoError(t, err)
+
+	store := NewSortableStore(testDB)
+	products, _, err := store.SearchProducts(context.Background(), token, 1, 10, "", "", true)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(products), 2)
+	require.Equal(t, match.ID, products[0].ID)
+	require.Equal(t, typo.ID, products[1].ID)
+}
+
+func TestSearchProductsSkuWildcards(t *testing.T) {
+	user := createRandomUser(t)
+	token := util.RandomString(8)
+	product, err := testQueries.CreateProduct(context.Background(), CreateProductParams{
+		Name:   util.RandomFullName(),
+		UserID: user.ID,
+		Sku:    "AB" + token,
+		Status: ProductStatusPublished,
+	})
+	require.NoError(t, err)
+
+	store := NewSortableStore(testDB)
+	products, _, err := store.SearchProducts(context.Background(), "ab"+token[:4], 1, 10, "", "", true)
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	require.Equal(t, product.ID, products[0].ID)
+
+	// _ and % only match themselves
+	for _, search := range []string{"a_" + token, "%" + token} {
+		products, _, err = store.SearchProducts(context.Background(), search, 1, 10, "", "", true)
+		require.NoError(t, err, search)
+		require.Empty(t, products, search)
+	}
+}
+
+func TestReassignProductsUser(t *testing.T) {
+	product := createPricedProduct(t, 150)
+	newUser := createRandomUser(t)
+
+	reassigned, err := testQueries.ReassignProductsUser(context.Background(), ReassignProductsUserParams{
+		NewUserID:   newUser.ID,
+		NewUsername: newUser.Username,
+		UserID:      product.UserID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), reassigned)
+
+	// only the owner changes
+	updated, err := testQueries.GetProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, newUser.ID, updated.UserID)
+	require.Equal(t, newUser.Username, updated.Username)
+	require.Equal(t, product.Name, updated.Name)
+	require.Equal(t, product.Price, updated.Price)
+	require.Equal(t, product.Status, updated.Status)
+	require.Equal(t, product.Version+1, updated.Version)
+}
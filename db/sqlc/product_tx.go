@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+)
+
+// Sources of a product price change
+const (
+	PriceSourceInitial   = "initial"
+	PriceSourceManual    = "manual"
+	PriceSourceScheduled = "scheduled"
+)
+
+// PriceChangedByScheduler is recorded as the author of the changes made by the price scheduler
+const PriceChangedByScheduler = "scheduler"
+
+// CreateProductTxParams contains the input parameters of the create product transaction
+type CreateProductTxParams struct {
+	CreateProductParams
+	Categories []int64 `json:"categories"`
+	ImageIDs   []int64 `json:"image_ids"`
+	ChangedBy  string  `json:"changed_by"`
+}
+
+// CreateProductTx creates a product linked to its categories and images and records its initial price in the price history
+func (store *SQLStore) CreateProductTx(ctx context.Context, arg CreateProductTxParams) (Product, error) {
+	var product Product
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		product, err = q.CreateProduct(ctx, arg.CreateProductParams)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateProductPriceHistory(ctx, CreateProductPriceHistoryParams{
+			ProductID: product.ID,
+			Price:     product.Price,
+			OldPrice:  product.OldPrice,
+			ChangedBy: arg.ChangedBy,
+			Source:    PriceSourceInitial,
+		})
+		if err != nil {
+			return err
+		}
+
+		product, err = setProductCategories(ctx, q, product.ID, arg.Categories)
+		if err != nil {
+			return err
+		}
+
+		product, err = setProductImages(ctx, q, product.ID, arg.ImageIDs)
+		return err
+	})
+
+	return product, err
+}
+
+// UpdateProductTxParams contains the input parameters of the update product transaction.
+// Categories and ImageIDs replace the current ones when not nil.
+type UpdateProductTxParams struct {
+	UpdateProductParams
+	Categories []int64 `json:"categories"`
+	ImageIDs   []int64 `json:"image_ids"`
+	ChangedBy  string  `json:"changed_by"`
+}
+
+// UpdateProductTx updates a product and records the new prices in the price history when they changed.
+// It fails with ErrVersionConflict when the version isn't the current one of the product.
+func (store *SQLStore) UpdateProductTx(ctx context.Context, arg UpdateProductTxParams) (Product, error) {
+	var product Product
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		previous, err := q.GetProductForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if previous.Version != arg.Version {
+			return ErrVersionConflict
+		}
+
+		product, err = q.UpdateProduct(ctx, arg.UpdateProductParams)
+		if err != nil {
+			return err
+		}
+
+		if arg.Categories != nil {
+			product, err = setProductCategories(ctx, q, product.ID, arg.Categories)
+			if err != nil {
+				return err
+			}
+		}
+
+		if arg.ImageIDs != nil {
+			product, err = setProductImages(ctx, q, product.ID, arg.ImageIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		return recordPriceChange(ctx, q, previous, product, arg.ChangedBy, PriceSourceManual)
+	})
+
+	return product, err
+}
+
+// recordPriceChange adds an entry to the price history if price or old_price differ between the two versions
+func recordPriceChange(ctx context.Context, q *Queries, previous, current Product, changedBy, source string) error {
+	if previous.Price == current.Price && previous.OldPrice == current.OldPrice {
+		return nil
+	}
+
+	_, err := q.CreateProductPriceHistory(ctx, CreateProductPriceHistoryParams{
+		ProductID: current.ID,
+		Price:     current.Price,
+		OldPrice:  current.OldPrice,
+		ChangedBy: changedBy,
+		Source:    source,
+	})
+	return err
+}
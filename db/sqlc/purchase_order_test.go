@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceivePurchaseOrderTx(t *testing.T) {
+	supplier := createRandomSupplier(t)
+	product := createRandomProduct(t)
+	store := NewStore(testDB)
+
+	created, err := store.CreatePurchaseOrderTx(context.Background(), CreatePurchaseOrderTxParams{
+		CreatePurchaseOrderParams: CreatePurchaseOrderParams{
+			SupplierID:   supplier.ID,
+			SupplierName: supplier.Name,
+		},
+		Lines: []PurchaseOrderLineParams{{ProductID: product.ID, ProductName: product.Name, Quantity: 5, UnitCost: 20}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderDraft, created.Order.Status)
+	require.Len(t, created.Lines, 1)
+	line := created.Lines[0]
+
+	// a draft can't be received
+	_, err = store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+		ID:    created.Order.ID,
+		Lines: []ReceiveLineParams{{LineID: line.ID, Quantity: 1}},
+	})
+	require.ErrorIs(t, err, ErrPurchaseOrderStatus)
+
+	sent, err := store.SendPurchaseOrderTx(context.Background(), created.Order.ID)
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderSent, sent.Status)
+	require.False(t, sent.SentAt.IsZero())
+
+	received, err := store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+		ID:    created.Order.ID,
+		Lines: []ReceiveLineParams{{LineID: line.ID, Quantity: 3, UnitCost: 22}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderPartiallyReceived, received.Order.Status)
+	require.Equal(t, int32(3), received.Lines[0].ReceivedQuantity)
+	// the ordered cost is kept next to what was charged
+	require.Equal(t, 20.0, received.Lines[0].UnitCost)
+	require.Equal(t, 22.0, received.Lines[0].ReceivedUnitCost)
+
+	updated, err := testQueries.GetProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, 22.0, updated.LastCost)
+
+	_, err = store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+		ID:    created.Order.ID,
+		Lines: []ReceiveLineParams{{LineID: line.ID, Quantity: 3}},
+	})
+	require.ErrorIs(t, err, ErrReceiveExceedsOrdered)
+
+	received, err = store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+		ID:    created.Order.ID,
+		Lines: []ReceiveLineParams{{LineID: line.ID, Quantity: 2}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderReceived, received.Order.Status)
+}
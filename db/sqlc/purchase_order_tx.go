@@ -0,0 +1,244 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Statuses of a purchase order
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderSent              = "sent"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+)
+
+// ErrPurchaseOrderStatus is returned when the operation is not allowed in the current status of the order
+var ErrPurchaseOrderStatus = errors.New("operation not allowed in the current status of the purchase order")
+
+// ErrReceiveExceedsOrdered is returned when receiving more units than are still missing on a line
+var ErrReceiveExceedsOrdered = errors.New("received quantity exceeds the ordered quantity")
+
+// ErrUnknownPurchaseOrderLine is returned when receiving a line of another purchase order
+var ErrUnknownPurchaseOrderLine = errors.New("line does not belong to the purchase order")
+
+// PurchaseOrderLineParams is a product ordered from the supplier
+type PurchaseOrderLineParams struct {
+	ProductID   int64   `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	Quantity    int32   `json:"quantity"`
+	UnitCost    float64 `json:"unit_cost"`
+}
+
+// PurchaseOrderTxResult is the result of the purchase order transactions
+type PurchaseOrderTxResult struct {
+	Order PurchaseOrder       `json:"order"`
+	Lines []PurchaseOrderLine `json:"lines"`
+}
+
+// CreatePurchaseOrderTxParams contains the input parameters of the create purchase order transaction
+type CreatePurchaseOrderTxParams struct {
+	CreatePurchaseOrderParams
+	Lines []PurchaseOrderLineParams `json:"lines"`
+}
+
+// CreatePurchaseOrderTx creates a draft purchase order with its lines
+func (store *SQLStore) CreatePurchaseOrderTx(ctx context.Context, arg CreatePurchaseOrderTxParams) (PurchaseOrderTxResult, error) {
+	var result PurchaseOrderTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Order, err = q.CreatePurchaseOrder(ctx, arg.CreatePurchaseOrderParams)
+		if err != nil {
+			return err
+		}
+
+		result.Lines, err = createPurchaseOrderLines(ctx, q, result.Order.ID, arg.Lines)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdatePurchaseOrderTxParams contains the input parameters of the update purchase order transaction.
+// Lines replace the current ones when not nil.
+type UpdatePurchaseOrderTxParams struct {
+	UpdatePurchaseOrderParams
+	Lines []PurchaseOrderLineParams `json:"lines"`
+}
+
+// UpdatePurchaseOrderTx updates a draft purchase order. Orders already sent to the supplier can't change.
+func (store *SQLStore) UpdatePurchaseOrderTx(ctx context.Context, arg UpdatePurchaseOrderTxParams) (PurchaseOrderTxResult, error) {
+	var result PurchaseOrderTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		order, err := q.GetPurchaseOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if order.Status != PurchaseOrderDraft {
+			return ErrPurchaseOrderStatus
+		}
+
+		result.Order, err = q.UpdatePurchaseOrder(ctx, arg.UpdatePurchaseOrderParams)
+		if err != nil {
+			return err
+		}
+
+		if arg.Lines == nil {
+			result.Lines, err = q.ListPurchaseOrderLines(ctx, arg.ID)
+			return err
+		}
+
+		if err = q.DeletePurchaseOrderLines(ctx, arg.ID); err != nil {
+			return err
+		}
+
+		result.Lines, err = createPurchaseOrderLines(ctx, q, arg.ID, arg.Lines)
+		return err
+	})
+
+	return result, err
+}
+
+func createPurchaseOrderLines(ctx context.Context, q *Queries, orderID int64, lines []PurchaseOrderLineParams) ([]PurchaseOrderLine, error) {
+	created := []PurchaseOrderLine{}
+	for _, line := range lines {
+		orderLine, err := q.CreatePurchaseOrderLine(ctx, CreatePurchaseOrderLineParams{
+			PurchaseOrderID: orderID,
+			ProductID:       line.ProductID,
+			ProductName:     line.ProductName,
+			Quantity:        line.Quantity,
+			UnitCost:        line.UnitCost,
+		})
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, orderLine)
+	}
+	return created, nil
+}
+
+// SendPurchaseOrderTx marks a draft purchase order as sent to the supplier
+func (store *SQLStore) SendPurchaseOrderTx(ctx context.Context, id int64) (PurchaseOrder, error) {
+	var result PurchaseOrder
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		order, err := q.GetPurchaseOrderForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if order.Status != PurchaseOrderDraft {
+			return ErrPurchaseOrderStatus
+		}
+
+		result, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+			ID:         id,
+			Status:     PurchaseOrderSent,
+			SentAt:     time.Now(),
+			ReceivedAt: order.ReceivedAt,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ReceiveLineParams is the quantity of a purchase order line that arrived.
+// UnitCost is what was actually charged, 0 means the ordered cost. It is stored as the received
+// unit cost of the line, the ordered unit cost is kept.
+type ReceiveLineParams struct {
+	LineID   int64   `json:"line_id"`
+	Quantity int32   `json:"quantity"`
+	UnitCost float64 `json:"unit_cost"`
+}
+
+// ReceivePurchaseOrderTxParams contains the input parameters of the receive purchase order transaction
+type ReceivePurchaseOrderTxParams struct {
+	ID    int64               `json:"id"`
+	Lines []ReceiveLineParams `json:"lines"`
+}
+
+// ReceivePurchaseOrderTx records the goods received for a sent purchase order, updates the last cost
+// of the received products and moves the order to received or partially received.
+func (store *SQLStore) ReceivePurchaseOrderTx(ctx context.Context, arg ReceivePurchaseOrderTxParams) (PurchaseOrderTxResult, error) {
+	var result PurchaseOrderTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		order, err := q.GetPurchaseOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if order.Status != PurchaseOrderSent && order.Status != PurchaseOrderPartiallyReceived {
+			return ErrPurchaseOrderStatus
+		}
+
+		lines, err := q.ListPurchaseOrderLines(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[int64]int, len(lines))
+		for i, line := range lines {
+			byID[line.ID] = i
+		}
+
+		for _, received := range arg.Lines {
+			i, ok := byID[received.LineID]
+			if !ok {
+				return fmt.Errorf("%w: line %d", ErrUnknownPurchaseOrderLine, received.LineID)
+			}
+
+			line := lines[i]
+			if line.ReceivedQuantity+received.Quantity > line.Quantity {
+				return ErrReceiveExceedsOrdered
+			}
+
+			unitCost := received.UnitCost
+			if unitCost == 0 {
+				unitCost = line.UnitCost
+			}
+
+			lines[i], err = q.ReceivePurchaseOrderLine(ctx, ReceivePurchaseOrderLineParams{
+				ID:               line.ID,
+				ReceivedQuantity: received.Quantity,
+				ReceivedUnitCost: unitCost,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = q.UpdateProductLastCost(ctx, UpdateProductLastCostParams{
+				ID:       line.ProductID,
+				LastCost: unitCost,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Lines = lines
+		result.Order, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+			ID:         arg.ID,
+			Status:     ReceivedStatus(lines),
+			SentAt:     order.SentAt,
+			ReceivedAt: time.Now(),
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ReceivedStatus is received when every line arrived in full and partially received otherwise
+func ReceivedStatus(lines []PurchaseOrderLine) string {
+	for _, line := range lines {
+		if line.ReceivedQuantity < line.Quantity {
+			return PurchaseOrderPartiallyReceived
+		}
+	}
+	return PurchaseOrderReceived
+}
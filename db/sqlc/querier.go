@@ -6,72 +6,192 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AssociateExistingCategories(ctx context.Context, arg AssociateExistingCategoriesParams) error
 	AssociateProductWithCategory(ctx context.Context, arg AssociateProductWithCategoryParams) (ProductCategory, error)
 	AssociateProductWithImage(ctx context.Context, arg AssociateProductWithImageParams) (ProductImage, error)
+	ClaimDueNotifications(ctx context.Context, arg ClaimDueNotificationsParams) ([]NotificationOutbox, error)
+	CountAuditLogs(ctx context.Context, arg CountAuditLogsParams) (int64, error)
 	CountCategory(ctx context.Context) (int64, error)
 	CountClients(ctx context.Context) (int64, error)
+	CountCouponUses(ctx context.Context, couponID int64) (int64, error)
+	CountCouponUsesByClient(ctx context.Context, arg CountCouponUsesByClientParams) (int64, error)
+	CountCoupons(ctx context.Context) (int64, error)
 	CountImages(ctx context.Context) (int64, error)
+	CountLoyaltyEntriesBySale(ctx context.Context, arg CountLoyaltyEntriesBySaleParams) (int64, error)
 	CountProducts(ctx context.Context) (int64, error)
+	CountProductsByBrand(ctx context.Context, brandID int64) (int64, error)
+	CountProductsBySupplier(ctx context.Context, supplierID int64) (int64, error)
+	CountPublishedProducts(ctx context.Context) (int64, error)
+	CountPurchaseOrders(ctx context.Context, status string) (int64, error)
+	CountPurchaseOrdersBySupplier(ctx context.Context, supplierID int64) (int64, error)
 	CountSales(ctx context.Context) (int64, error)
+	CountSubscriptions(ctx context.Context) (int64, error)
+	CountSuppliers(ctx context.Context) (int64, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
+	CreateBrand(ctx context.Context, arg CreateBrandParams) (Brand, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
 	CreateClient(ctx context.Context, arg CreateClientParams) (Client, error)
+	CreateClientNote(ctx context.Context, arg CreateClientNoteParams) (ClientNote, error)
+	CreateCoupon(ctx context.Context, arg CreateCouponParams) (Coupon, error)
 	CreateImage(ctx context.Context, arg CreateImageParams) (Image, error)
+	CreateLoyaltyEntry(ctx context.Context, arg CreateLoyaltyEntryParams) (LoyaltyLedger, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (NotificationOutbox, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreateProductPriceHistory(ctx context.Context, arg CreateProductPriceHistoryParams) (ProductPriceHistory, error)
+	CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error)
+	CreatePurchaseOrderLine(ctx context.Context, arg CreatePurchaseOrderLineParams) (PurchaseOrderLine, error)
 	CreateSale(ctx context.Context, arg CreateSaleParams) (Sale, error)
+	CreateSaleDiscount(ctx context.Context, arg CreateSaleDiscountParams) (SaleDiscount, error)
+	CreateSaleItem(ctx context.Context, arg CreateSaleItemParams) (SaleItem, error)
+	CreateSaleStatusChange(ctx context.Context, arg CreateSaleStatusChangeParams) (SaleStatusHistory, error)
+	CreateScheduledPrice(ctx context.Context, arg CreateScheduledPriceParams) (ScheduledPrice, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateSliderImage(ctx context.Context, arg CreateSliderImageParams) (SliderImageWidget, error)
+	CreateSubscription(ctx context.Context, arg CreateSubscriptionParams) (Subscription, error)
+	CreateSubscriptionItem(ctx context.Context, arg CreateSubscriptionItemParams) (SubscriptionItem, error)
+	CreateSubscriptionRun(ctx context.Context, arg CreateSubscriptionRunParams) (SubscriptionRun, error)
+	CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeactivateCoupon(ctx context.Context, id int64) (Coupon, error)
+	DeleteAuditLogsBefore(ctx context.Context, createdAt time.Time) (int64, error)
+	DeleteBrand(ctx context.Context, id int64) error
 	DeleteByImageId(ctx context.Context, imageID int64) error
 	DeleteCategory(ctx context.Context, id int64) error
 	DeleteClient(ctx context.Context, id int64) error
+	DeleteClientNote(ctx context.Context, id int64) error
 	DeleteImage(ctx context.Context, id int64) error
+	DeleteImageLinks(ctx context.Context, imageID int64) ([]int64, error)
 	DeleteProduct(ctx context.Context, id int64) error
+	DeleteProductCategories(ctx context.Context, productID int64) error
+	DeleteProductImages(ctx context.Context, productID int64) error
+	DeletePurchaseOrder(ctx context.Context, id int64) error
+	DeletePurchaseOrderLines(ctx context.Context, purchaseOrderID int64) error
 	DeleteSale(ctx context.Context, id int64) error
 	DeleteSales(ctx context.Context, dollar_1 []int32) error
 	DeleteSliderImage(ctx context.Context, id int64) error
+	DeleteSubscription(ctx context.Context, id int64) error
+	DeleteSubscriptionItems(ctx context.Context, subscriptionID int64) error
+	DeleteSupplier(ctx context.Context, id int64) error
 	DeleteUser(ctx context.Context, id int64) error
 	DisassociateProductFromCategory(ctx context.Context, arg DisassociateProductFromCategoryParams) (ProductCategory, error)
 	DisassociateProductFromImage(ctx context.Context, arg DisassociateProductFromImageParams) (ProductImage, error)
 	EditAssociation(ctx context.Context, arg EditAssociationParams) (ProductImage, error)
 	GetAllSaleIDs(ctx context.Context) ([]int64, error)
+	GetBrand(ctx context.Context, id int64) (Brand, error)
+	GetBrandBySlug(ctx context.Context, slug string) (Brand, error)
 	GetCategory(ctx context.Context, id int64) (Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (Category, error)
 	GetClient(ctx context.Context, id int64) (Client, error)
+	GetClientForUpdate(ctx context.Context, id int64) (Client, error)
+	GetClientNote(ctx context.Context, id int64) (ClientNote, error)
+	GetCoupon(ctx context.Context, id int64) (Coupon, error)
+	GetCouponByCode(ctx context.Context, code string) (Coupon, error)
+	GetCouponForUpdate(ctx context.Context, id int64) (Coupon, error)
 	GetImage(ctx context.Context, id int64) (Image, error)
+	GetLoyaltyBalance(ctx context.Context, clientID int64) (int64, error)
+	GetMarginByCategory(ctx context.Context, arg GetMarginByCategoryParams) ([]GetMarginByCategoryRow, error)
+	GetMarginByPeriod(ctx context.Context, arg GetMarginByPeriodParams) ([]GetMarginByPeriodRow, error)
+	GetMarginByProduct(ctx context.Context, arg GetMarginByProductParams) ([]GetMarginByProductRow, error)
 	GetProduct(ctx context.Context, id int64) (Product, error)
 	GetProductByURL(ctx context.Context, url string) (Product, error)
+	GetProductForUpdate(ctx context.Context, id int64) (Product, error)
+	GetProductPriceAt(ctx context.Context, arg GetProductPriceAtParams) (ProductPriceHistory, error)
+	GetPurchaseOrder(ctx context.Context, id int64) (PurchaseOrder, error)
+	GetPurchaseOrderForUpdate(ctx context.Context, id int64) (PurchaseOrder, error)
 	GetSale(ctx context.Context, id int64) (Sale, error)
+	GetSaleForUpdate(ctx context.Context, id int64) (Sale, error)
 	GetSalesByClientID(ctx context.Context, clientID int64) ([]Sale, error)
 	GetSalesByDate(ctx context.Context, arg GetSalesByDateParams) ([]int64, error)
+	GetScheduledPrice(ctx context.Context, id int64) (ScheduledPrice, error)
+	GetScheduledPriceForUpdate(ctx context.Context, id int64) (ScheduledPrice, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetSubscription(ctx context.Context, id int64) (Subscription, error)
+	GetSubscriptionForUpdate(ctx context.Context, id int64) (Subscription, error)
+	GetSupplier(ctx context.Context, id int64) (Supplier, error)
 	GetUser(ctx context.Context, id int64) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
+	ListActiveSubscriptions(ctx context.Context) ([]Subscription, error)
+	ListAllCategories(ctx context.Context) ([]Category, error)
+	ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuditLog, error)
+	ListBrands(ctx context.Context) ([]ListBrandsRow, error)
 	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
 	ListCategoriesByProduct(ctx context.Context, productID int64) ([]Category, error)
+	ListCategoriesBySale(ctx context.Context, saleID int64) ([]Category, error)
+	ListClientNotes(ctx context.Context, clientID int64) ([]ClientNote, error)
 	ListClients(ctx context.Context, arg ListClientsParams) ([]Client, error)
+	ListCoupons(ctx context.Context, arg ListCouponsParams) ([]Coupon, error)
+	ListDueScheduledPrices(ctx context.Context, startsAt time.Time) ([]ScheduledPrice, error)
+	ListDueSubscriptions(ctx context.Context, nextRunDate time.Time) ([]Subscription, error)
+	ListExpiredScheduledPrices(ctx context.Context, endsAt time.Time) ([]ScheduledPrice, error)
 	ListImages(ctx context.Context, arg ListImagesParams) ([]Image, error)
 	ListImagesByProduct(ctx context.Context, productID int64) ([]ListImagesByProductRow, error)
+	ListImagesByProducts(ctx context.Context, productIds []int64) ([]ListImagesByProductsRow, error)
+	ListLoyaltyEntries(ctx context.Context, clientID int64) ([]LoyaltyLedger, error)
+	ListNotificationsBySale(ctx context.Context, saleID int64) ([]NotificationOutbox, error)
+	ListPinnedClientNotes(ctx context.Context, clientID int64) ([]ClientNote, error)
+	ListProductCategoriesByProducts(ctx context.Context, productIds []int64) ([]ProductCategory, error)
+	ListProductPriceHistory(ctx context.Context, productID int64) ([]ProductPriceHistory, error)
+	ListProductPricesAt(ctx context.Context, createdAt time.Time) ([]ProductPriceHistory, error)
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
 	ListProductsByCategory(ctx context.Context, categoryID int64) ([]Product, error)
 	ListProductsByUser(ctx context.Context, userID int64) ([]Product, error)
+	ListPublishedProducts(ctx context.Context, arg ListPublishedProductsParams) ([]Product, error)
+	ListPurchaseOrderLines(ctx context.Context, purchaseOrderID int64) ([]PurchaseOrderLine, error)
+	ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]PurchaseOrder, error)
+	ListSaleClientIDs(ctx context.Context) ([]int64, error)
+	ListSaleDiscounts(ctx context.Context, saleID int64) ([]SaleDiscount, error)
+	ListSaleDiscountsByClient(ctx context.Context, clientID int64) ([]SaleDiscount, error)
+	ListSaleItems(ctx context.Context, saleID int64) ([]SaleItem, error)
+	ListSaleStatusHistory(ctx context.Context, saleID int64) ([]SaleStatusHistory, error)
+	ListSaleStatusHistoryByClient(ctx context.Context, clientID int64) ([]SaleStatusHistory, error)
 	ListSales(ctx context.Context, arg ListSalesParams) ([]Sale, error)
+	ListScheduledPricesByProduct(ctx context.Context, productID int64) ([]ScheduledPrice, error)
 	ListSessionsByUsername(ctx context.Context, username string) ([]Session, error)
 	ListSliderImages(ctx context.Context, arg ListSliderImagesParams) ([]SliderImageWidget, error)
+	ListSubscriptionItems(ctx context.Context, subscriptionID int64) ([]SubscriptionItem, error)
+	ListSubscriptionRuns(ctx context.Context, subscriptionID int64) ([]SubscriptionRun, error)
+	ListSubscriptions(ctx context.Context, arg ListSubscriptionsParams) ([]Subscription, error)
+	ListSubscriptionsByClient(ctx context.Context, clientID int64) ([]Subscription, error)
+	ListSuppliers(ctx context.Context, arg ListSuppliersParams) ([]Supplier, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	MarkNotificationFailed(ctx context.Context, arg MarkNotificationFailedParams) (NotificationOutbox, error)
+	MarkNotificationSent(ctx context.Context, id int64) (NotificationOutbox, error)
+	MoveChildCategories(ctx context.Context, arg MoveChildCategoriesParams) error
+	ReassignClientNotes(ctx context.Context, arg ReassignClientNotesParams) error
+	ReassignLoyaltyEntries(ctx context.Context, arg ReassignLoyaltyEntriesParams) error
+	ReassignProductsUser(ctx context.Context, arg ReassignProductsUserParams) (int64, error)
+	ReceivePurchaseOrderLine(ctx context.Context, arg ReceivePurchaseOrderLineParams) (PurchaseOrderLine, error)
+	RestoreProduct(ctx context.Context, id int64) (Product, error)
+	SoftDeleteProduct(ctx context.Context, id int64) (Product, error)
+	SumLoyaltyPointsBySale(ctx context.Context, saleID int64) (int64, error)
+	TouchProduct(ctx context.Context, id int64) (Product, error)
+	UpdateBrand(ctx context.Context, arg UpdateBrandParams) (Brand, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
 	UpdateClient(ctx context.Context, arg UpdateClientParams) (Client, error)
+	UpdateClientNote(ctx context.Context, arg UpdateClientNoteParams) (ClientNote, error)
+	UpdateCoupon(ctx context.Context, arg UpdateCouponParams) (Coupon, error)
 	UpdateImage(ctx context.Context, arg UpdateImageParams) (Image, error)
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error)
+	UpdateProductLastCost(ctx context.Context, arg UpdateProductLastCostParams) error
+	UpdateProductPricing(ctx context.Context, arg UpdateProductPricingParams) (Product, error)
+	UpdatePurchaseOrder(ctx context.Context, arg UpdatePurchaseOrderParams) (PurchaseOrder, error)
+	UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) (PurchaseOrder, error)
 	UpdateSale(ctx context.Context, arg UpdateSaleParams) (Sale, error)
+	UpdateScheduledPriceStatus(ctx context.Context, arg UpdateScheduledPriceStatusParams) (ScheduledPrice, error)
 	UpdateSession(ctx context.Context, arg UpdateSessionParams) (Session, error)
 	UpdateSessionsUsername(ctx context.Context, arg UpdateSessionsUsernameParams) ([]Session, error)
 	UpdateSliderImage(ctx context.Context, arg UpdateSliderImageParams) (SliderImageWidget, error)
 	UpdateSliderImageByImageId(ctx context.Context, arg UpdateSliderImageByImageIdParams) (SliderImageWidget, error)
+	UpdateSubscription(ctx context.Context, arg UpdateSubscriptionParams) (Subscription, error)
+	UpdateSubscriptionNextRun(ctx context.Context, arg UpdateSubscriptionNextRunParams) error
+	UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) (Supplier, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 }
 
@@ -29,10 +29,11 @@ INSERT INTO sale (
     client_name,
     product,
     price,
-    observation
+    observation,
+    status
 ) VALUES (
-    $1, $2, $3, $4, $5
-) RETURNING id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at, status, version
 `
 
 type CreateSaleParams struct {
@@ -41,6 +42,7 @@ type CreateSaleParams struct {
 	Product     string  `json:"product"`
 	Price       float64 `json:"price"`
 	Observation string  `json:"observation"`
+	Status      string  `json:"status"`
 }
 
 func (q *Queries) CreateSale(ctx context.Context, arg CreateSaleParams) (Sale, error) {
@@ -50,6 +52,7 @@ func (q *Queries) CreateSale(ctx context.Context, arg CreateSaleParams) (Sale, e
 		arg.Product,
 		arg.Price,
 		arg.Observation,
+		arg.Status,
 	)
 	var i Sale
 	err := row.Scan(
@@ -62,6 +65,8 @@ func (q *Queries) CreateSale(ctx context.Context, arg CreateSaleParams) (Sale, e
 		&i.CreatedAt,
 		&i.ChangedAt,
 		&i.PdfGeneratedAt,
+		&i.Status,
+		&i.Version,
 	)
 	return i, err
 }
@@ -115,7 +120,7 @@ func (q *Queries) GetAllSaleIDs(ctx context.Context) ([]int64, error) {
 }
 
 const getSale = `-- name: GetSale :one
-SELECT id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at FROM sale
+SELECT id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at, status, version FROM sale
 WHERE id = $1 LIMIT 1
 `
 
@@ -132,6 +137,33 @@ func (q *Queries) GetSale(ctx context.Context, id int64) (Sale, error) {
 		&i.CreatedAt,
 		&i.ChangedAt,
 		&i.PdfGeneratedAt,
+		&i.Status,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getSaleForUpdate = `-- name: GetSaleForUpdate :one
+SELECT id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at, status, version FROM sale
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetSaleForUpdate(ctx context.Context, id int64) (Sale, error) {
+	row := q.db.QueryRowContext(ctx, getSaleForUpdate, id)
+	var i Sale
+	err := row.Scan(
+		&i.ID,
+		&i.ClientID,
+		&i.ClientName,
+		&i.Product,
+		&i.Price,
+		&i.Observation,
+		&i.CreatedAt,
+		&i.ChangedAt,
+		&i.PdfGeneratedAt,
+		&i.Status,
+		&i.Version,
 	)
 	return i, err
 }
@@ -170,8 +202,36 @@ func (q *Queries) GetSalesByDate(ctx context.Context, arg GetSalesByDateParams)
 	return items, nil
 }
 
+const listSaleClientIDs = `-- name: ListSaleClientIDs :many
+SELECT DISTINCT client_id FROM sale
+ORDER BY client_id
+`
+
+func (q *Queries) ListSaleClientIDs(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listSaleClientIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var client_id int64
+		if err := rows.Scan(&client_id); err != nil {
+			return nil, err
+		}
+		items = append(items, client_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSales = `-- name: ListSales :many
-SELECT id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at FROM sale
+SELECT id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at, status, version FROM sale
 ORDER BY id DESC
 LIMIT $1
 OFFSET $2
@@ -201,6 +261,8 @@ func (q *Queries) ListSales(ctx context.Context, arg ListSalesParams) ([]Sale, e
 			&i.CreatedAt,
 			&i.ChangedAt,
 			&i.PdfGeneratedAt,
+			&i.Status,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -222,9 +284,12 @@ SET
     client_name = COALESCE($3, client_name),
     product = COALESCE($4, product),
     price = COALESCE($5, price),
-    observation = COALESCE($6, observation)
-WHERE id = $1
-RETURNING id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at
+    observation = COALESCE($6, observation),
+    status = COALESCE($7, status),
+    version = version + 1,
+    changed_at = now()
+WHERE id = $1 AND version = $8
+RETURNING id, client_id, client_name, product, price, observation, created_at, changed_at, pdf_generated_at, status, version
 `
 
 type UpdateSaleParams struct {
@@ -234,6 +299,8 @@ type UpdateSaleParams struct {
 	Product     string  `json:"product"`
 	Price       float64 `json:"price"`
 	Observation string  `json:"observation"`
+	Status      string  `json:"status"`
+	Version     int64   `json:"version"`
 }
 
 func (q *Queries) UpdateSale(ctx context.Context, arg UpdateSaleParams) (Sale, error) {
@@ -244,6 +311,8 @@ func (q *Queries) UpdateSale(ctx context.Context, arg UpdateSaleParams) (Sale, e
 		arg.Product,
 		arg.Price,
 		arg.Observation,
+		arg.Status,
+		arg.Version,
 	)
 	var i Sale
 	err := row.Scan(
@@ -256,6 +325,8 @@ func (q *Queries) UpdateSale(ctx context.Context, arg UpdateSaleParams) (Sale, e
 		&i.CreatedAt,
 		&i.ChangedAt,
 		&i.PdfGeneratedAt,
+		&i.Status,
+		&i.Version,
 	)
 	return i, err
 }
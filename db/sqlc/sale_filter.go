@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// SaleFilter narrows the sale lists down, the zero value of a field doesn't filter by it.
+// From and To include the sales created at those instants, Printed tells whether the pdf of the sale was generated.
+type SaleFilter struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	ClientID int64     `json:"client_id"`
+	MinPrice float64   `json:"min_price"`
+	MaxPrice float64   `json:"max_price"`
+	Product  string    `json:"product"`
+	Status   string    `json:"status"`
+	Printed  *bool     `json:"printed"`
+}
+
+// IsZero tells whether the filter keeps every sale
+func (f SaleFilter) IsZero() bool {
+	return f.From.IsZero() && f.To.IsZero() && f.ClientID == 0 && f.MinPrice == 0 && f.MaxPrice == 0 &&
+		f.Product == "" && f.Status == "" && f.Printed == nil
+}
+
+// apply adds the conditions of the filter to the query
+func (f SaleFilter) apply(q *listQuery) {
+	if !f.From.IsZero() {
+		q.where("created_at >= " + q.arg(f.From))
+	}
+	if !f.To.IsZero() {
+		q.where("created_at <= " + q.arg(f.To))
+	}
+	if f.ClientID != 0 {
+		q.where("client_id = " + q.arg(f.ClientID))
+	}
+	if f.MinPrice != 0 {
+		q.where("price >= " + q.arg(f.MinPrice))
+	}
+	if f.MaxPrice != 0 {
+		q.where("price <= " + q.arg(f.MaxPrice))
+	}
+	if f.Product != "" {
+		q.search(f.Product, "product")
+	}
+	if f.Status != "" {
+		q.where("status = " + q.arg(f.Status))
+	}
+	if f.Printed != nil {
+		if *f.Printed {
+			q.where("pdf_generated_at <> '0001-01-01 00:00:00Z'")
+		} else {
+			q.where("pdf_generated_at = '0001-01-01 00:00:00Z'")
+		}
+	}
+}
+
+// SalePageParams are the input of the keyset pagination of the sales, with the filters of FilterSales
+type SalePageParams struct {
+	PageParams
+	SaleFilter
+}
+
+// FilterSales lists the sales matching the filter and the search and counts all of them.
+// Without a sort the newest sales come first, like ListSales.
+func (store *SortableSQLStore) FilterSales(ctx context.Context, filter SaleFilter, pageId int, pageSize int, sortField string, sortDirection string, search string) ([]Sale, int64, error) {
+	q := newListQuery("sale", saleColumns, saleSortColumns).
+		search(search, saleSearchColumns...).
+		page(int32(pageSize), int32((pageId-1)*pageSize))
+	if err := q.searchSort(sortField, sortDirection); err != nil {
+		return nil, 0, err
+	}
+	q.defaultOrder("id DESC")
+	filter.apply(q)
+
+	return listAndCount(ctx, store.db, q, scanSale)
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterSales(t *testing.T) {
+	client := createRandomClient(t)
+	token := util.RandomString(12)
+
+	var sales []Sale
+	for _, price := range []float64{50, 150, 250} {
+		sale, err := testQueries.CreateSale(context.Background(), CreateSaleParams{
+			ClientID: client.ID,
+			Product:  token,
+			Price:    price,
+			Status:   SaleStatusConfirmed,
+		})
+		require.NoError(t, err)
+		sales = append(sales, sale)
+	}
+
+	store := NewSortableStore(testDB)
+	filter := SaleFilter{
+		From:     time.Now().Add(-time.Hour),
+		To:       time.Now().Add(time.Hour),
+		ClientID: client.ID,
+		MinPrice: 100,
+		MaxPrice: 300,
+		Product:  token,
+		Status:   SaleStatusConfirmed,
+	}
+
+	got, total, err := store.FilterSales(context.Background(), filter, 1, 5, "price", "asc", "")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Equal(t, []Sale{sales[1], sales[2]}, got)
+
+	// no sale of the client had its pdf generated
+	printed := true
+	filter.Printed = &printed
+	got, total, err = store.FilterSales(context.Background(), filter, 1, 5, "", "", "")
+	require.NoError(t, err)
+	require.Empty(t, got)
+	require.Zero(t, total)
+
+	// the cursor pages apply the same filter
+	page, next, err := store.ListSalesPage(context.Background(), SalePageParams{
+		PageParams: PageParams{Limit: 5},
+		SaleFilter: SaleFilter{ClientID: client.ID, MaxPrice: 100},
+	})
+	require.NoError(t, err)
+	require.Empty(t, next)
+	require.Equal(t, []Sale{sales[0]}, page)
+}
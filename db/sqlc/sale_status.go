@@ -0,0 +1,8 @@
+package db
+
+// Sale statuses. Sales created by hand are confirmed right away, while sales
+// generated from a subscription wait as pending until the store confirms them.
+const (
+	SaleStatusPending   = "pending"
+	SaleStatusConfirmed = "confirmed"
+)
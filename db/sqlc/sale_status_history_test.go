@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSaleTxRecordsStatusChanges(t *testing.T) {
+	client := createRandomClient(t)
+	store := NewStore(testDB)
+
+	result, err := store.CreateSaleTx(context.Background(), CreateSaleTxParams{
+		CreateSaleParams: CreateSaleParams{
+			ClientID:   client.ID,
+			ClientName: client.FullName,
+			Product:    "Ração",
+			Price:      100,
+			Status:     SaleStatusPending,
+		},
+		ChangedBy: "admin",
+	})
+	require.NoError(t, err)
+	sale := result.Sale
+
+	arg := UpdateSaleTxParams{
+		UpdateSaleParams: UpdateSaleParams{
+			ID:         sale.ID,
+			ClientID:   sale.ClientID,
+			ClientName: sale.ClientName,
+			Product:    sale.Product,
+			Price:      120,
+			Status:     sale.Status,
+			Version:    sale.Version,
+		},
+		ChangedBy: "seller",
+	}
+
+	// a change that keeps the status is not part of the history
+	sale, err = store.UpdateSaleTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	arg.Status = SaleStatusConfirmed
+	arg.Version = sale.Version
+	sale, err = store.UpdateSaleTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, SaleStatusConfirmed, sale.Status)
+
+	history, err := testQueries.ListSaleStatusHistory(context.Background(), sale.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, SaleStatusPending, history[0].Status)
+	require.Equal(t, "admin", history[0].ChangedBy)
+	require.Equal(t, SaleStatusConfirmed, history[1].Status)
+	require.Equal(t, "seller", history[1].ChangedBy)
+
+	byClient, err := testQueries.ListSaleStatusHistoryByClient(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Equal(t, history, byClient)
+}
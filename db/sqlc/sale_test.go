@@ -16,6 +16,7 @@ func createRandomSale(t *testing.T) Sale {
 		Product:     util.RandomString(9),
 		Price:       1000, // Set an appropriate price.
 		Observation: util.RandomString(9),
+		Status:      SaleStatusConfirmed,
 	}
 
 	sale, err := testQueries.CreateSale(context.Background(), arg)
@@ -23,6 +24,7 @@ func createRandomSale(t *testing.T) Sale {
 	require.NotEmpty(t, sale)
 
 	require.Equal(t, arg.Product, sale.Product)
+	require.Equal(t, arg.Status, sale.Status)
 
 	require.NotZero(t, sale.ID)
 	return sale
@@ -69,24 +71,30 @@ func TestUpdateSale(t *testing.T) {
 
 	arg := UpdateSaleParams{
 		ID:          sale1.ID,
+		Version:     sale1.Version,
 		ClientID:    sale1.ClientID,
 		Product:     util.RandomString(9),
 		Price:       1500, // Set an appropriate price.
 		Observation: util.RandomString(9),
+		Status:      SaleStatusConfirmed,
 	}
 	arg2 := UpdateSaleParams{
 		ID:          sale2.ID,
+		Version:     sale2.Version,
 		ClientID:    sale2.ClientID,
 		Product:     sale2.Product,
 		Price:       2000, // Set an appropriate price.
 		Observation: util.RandomString(9),
+		Status:      SaleStatusConfirmed,
 	}
 	arg3 := UpdateSaleParams{
 		ID:          sale3.ID,
+		Version:     sale3.Version,
 		ClientID:    sale3.ClientID,
 		Product:     sale3.Product,
 		Price:       sale3.Price,
 		Observation: util.RandomString(9),
+		Status:      SaleStatusConfirmed,
 	}
 	t.Logf("updated sale1: %v", arg)
 
@@ -0,0 +1,316 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Kinds of loyalty ledger entries
+const (
+	LoyaltyKindEarn     = "earn"
+	LoyaltyKindRedeem   = "redeem"
+	LoyaltyKindReversal = "reversal"
+)
+
+// ErrInsufficientPoints is returned when a client tries to redeem more points than they have
+var ErrInsufficientPoints = errors.New("insufficient loyalty points")
+
+// ErrCouponUsageLimit is returned when a coupon reached its global or per client usage limit
+var ErrCouponUsageLimit = errors.New("coupon usage limit reached")
+
+// SaleCouponParams is the coupon discount applied to a sale
+type SaleCouponParams struct {
+	CouponID    int64   `json:"coupon_id"`
+	Code        string  `json:"code"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+}
+
+// SaleItemParams is a catalog product sold in a sale with the price and cost it had at the moment of the sale
+type SaleItemParams struct {
+	ProductID   int64   `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	Quantity    int32   `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	UnitCost    float64 `json:"unit_cost"`
+}
+
+// CreateSaleTxParams contains the input parameters of the create sale transaction.
+// The sale price must already have the coupon and the redeemed points discounted.
+// ChangedBy is the user that created the sale, recorded with its first status.
+type CreateSaleTxParams struct {
+	CreateSaleParams
+	ChangedBy    string            `json:"changed_by"`
+	Items        []SaleItemParams  `json:"items"`
+	Coupon       *SaleCouponParams `json:"coupon"`
+	RedeemPoints int64             `json:"redeem_points"`
+	EarnPoints   int64             `json:"earn_points"`
+}
+
+// CreateSaleTxResult is the result of the create sale transaction
+type CreateSaleTxResult struct {
+	Sale      Sale            `json:"sale"`
+	Items     []SaleItem      `json:"items"`
+	Discounts []SaleDiscount  `json:"discounts"`
+	Entries   []LoyaltyLedger `json:"entries"`
+}
+
+// CreateSaleTx creates a sale with its items, records its coupon discount line, redeems the points used as discount
+// and credits the points it earns. The coupon and client rows are locked so concurrent sales can
+// neither exceed the coupon usage limits nor spend the same points twice.
+func (store *SQLStore) CreateSaleTx(ctx context.Context, arg CreateSaleTxParams) (CreateSaleTxResult, error) {
+	var result CreateSaleTxResult
+	result.Items = []SaleItem{}
+	result.Discounts = []SaleDiscount{}
+	result.Entries = []LoyaltyLedger{}
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		if arg.Coupon != nil {
+			if err = checkCouponUsage(ctx, q, arg.Coupon.CouponID, arg.ClientID); err != nil {
+				return err
+			}
+		}
+
+		if arg.RedeemPoints > 0 {
+			if _, err = q.GetClientForUpdate(ctx, arg.ClientID); err != nil {
+				return err
+			}
+
+			balance, err := q.GetLoyaltyBalance(ctx, arg.ClientID)
+			if err != nil {
+				return err
+			}
+			if balance < arg.RedeemPoints {
+				return ErrInsufficientPoints
+			}
+		}
+
+		result.Sale, err = q.CreateSale(ctx, arg.CreateSaleParams)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateSaleStatusChange(ctx, CreateSaleStatusChangeParams{
+			SaleID:    result.Sale.ID,
+			Status:    result.Sale.Status,
+			ChangedBy: arg.ChangedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range arg.Items {
+			saleItem, err := q.CreateSaleItem(ctx, CreateSaleItemParams{
+				SaleID:      result.Sale.ID,
+				ProductID:   item.ProductID,
+				ProductName: item.ProductName,
+				Quantity:    item.Quantity,
+				UnitPrice:   item.UnitPrice,
+				UnitCost:    item.UnitCost,
+			})
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, saleItem)
+		}
+
+		if arg.Coupon != nil {
+			discount, err := q.CreateSaleDiscount(ctx, CreateSaleDiscountParams{
+				SaleID:      result.Sale.ID,
+				CouponID:    arg.Coupon.CouponID,
+				ClientID:    arg.ClientID,
+				Code:        arg.Coupon.Code,
+				Description: arg.Coupon.Description,
+				Amount:      arg.Coupon.Amount,
+			})
+			if err != nil {
+				return err
+			}
+			result.Discounts = append(result.Discounts, discount)
+		}
+
+		if arg.RedeemPoints > 0 {
+			entry, err := q.CreateLoyaltyEntry(ctx, CreateLoyaltyEntryParams{
+				ClientID:    arg.ClientID,
+				SaleID:      result.Sale.ID,
+				Points:      -arg.RedeemPoints,
+				Kind:        LoyaltyKindRedeem,
+				Description: fmt.Sprintf("Resgate na venda #%d", result.Sale.ID),
+			})
+			if err != nil {
+				return err
+			}
+			result.Entries = append(result.Entries, entry)
+		}
+
+		if arg.EarnPoints > 0 {
+			entry, err := q.CreateLoyaltyEntry(ctx, CreateLoyaltyEntryParams{
+				ClientID:    arg.ClientID,
+				SaleID:      result.Sale.ID,
+				Points:      arg.EarnPoints,
+				Kind:        LoyaltyKindEarn,
+				Description: fmt.Sprintf("Pontos da venda #%d", result.Sale.ID),
+			})
+			if err != nil {
+				return err
+			}
+			result.Entries = append(result.Entries, entry)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+func checkCouponUsage(ctx context.Context, q *Queries, couponID int64, clientID int64) error {
+	coupon, err := q.GetCouponForUpdate(ctx, couponID)
+	if err != nil {
+		return err
+	}
+
+	if coupon.MaxUses > 0 {
+		uses, err := q.CountCouponUses(ctx, couponID)
+		if err != nil {
+			return err
+		}
+		if uses >= int64(coupon.MaxUses) {
+			return ErrCouponUsageLimit
+		}
+	}
+
+	if coupon.MaxUsesPerClient > 0 {
+		uses, err := q.CountCouponUsesByClient(ctx, CountCouponUsesByClientParams{
+			CouponID: couponID,
+			ClientID: clientID,
+		})
+		if err != nil {
+			return err
+		}
+		if uses >= int64(coupon.MaxUsesPerClient) {
+			return ErrCouponUsageLimit
+		}
+	}
+
+	return nil
+}
+
+// UpdateSaleTxParams contains the input parameters of the update sale transaction.
+// ChangedBy is the user that made the change, recorded when the status changes.
+// EarnPoints are credited when the update confirms a pending sale.
+type UpdateSaleTxParams struct {
+	UpdateSaleParams
+	ChangedBy  string `json:"changed_by"`
+	EarnPoints int64  `json:"earn_points"`
+}
+
+// UpdateSaleTx updates a sale and records its new status in the status history when it changed.
+// A pending sale earns its points once it is confirmed: the sale row is locked and the points
+// are only credited when the sale has no earn entry yet, so confirming it again after moving it
+// back to pending, or two concurrent confirmations, don't credit them twice.
+// It returns sql.ErrNoRows when the sale doesn't exist or its version is not arg.Version anymore.
+func (store *SQLStore) UpdateSaleTx(ctx context.Context, arg UpdateSaleTxParams) (Sale, error) {
+	var sale Sale
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		previous, err := q.GetSaleForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		sale, err = q.UpdateSale(ctx, arg.UpdateSaleParams)
+		if err != nil {
+			return err
+		}
+
+		if sale.Status == previous.Status {
+			return nil
+		}
+
+		_, err = q.CreateSaleStatusChange(ctx, CreateSaleStatusChangeParams{
+			SaleID:    sale.ID,
+			Status:    sale.Status,
+			ChangedBy: arg.ChangedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		if previous.Status != SaleStatusPending || sale.Status != SaleStatusConfirmed || arg.EarnPoints <= 0 {
+			return nil
+		}
+		return creditSalePoints(ctx, q, sale, arg.EarnPoints)
+	})
+
+	return sale, err
+}
+
+// creditSalePoints credits the points a sale earned unless it already earned them
+func creditSalePoints(ctx context.Context, q *Queries, sale Sale, points int64) error {
+	earned, err := q.CountLoyaltyEntriesBySale(ctx, CountLoyaltyEntriesBySaleParams{
+		SaleID: sale.ID,
+		Kind:   LoyaltyKindEarn,
+	})
+	if err != nil || earned > 0 {
+		return err
+	}
+
+	_, err = q.CreateLoyaltyEntry(ctx, CreateLoyaltyEntryParams{
+		ClientID:    sale.ClientID,
+		SaleID:      sale.ID,
+		Points:      points,
+		Kind:        LoyaltyKindEarn,
+		Description: fmt.Sprintf("Pontos da venda #%d", sale.ID),
+	})
+	return err
+}
+
+// DeleteSaleTx deletes a sale and reverses the loyalty points it earned or redeemed
+func (store *SQLStore) DeleteSaleTx(ctx context.Context, id int64) error {
+	return store.ExecTx(ctx, func(q *Queries) error {
+		if err := reverseSalePoints(ctx, q, id); err != nil {
+			return err
+		}
+		return q.DeleteSale(ctx, id)
+	})
+}
+
+// DeleteSalesTx deletes several sales and reverses the loyalty points of each one
+func (store *SQLStore) DeleteSalesTx(ctx context.Context, ids []int32) error {
+	return store.ExecTx(ctx, func(q *Queries) error {
+		for _, id := range ids {
+			if err := reverseSalePoints(ctx, q, int64(id)); err != nil {
+				return err
+			}
+		}
+		return q.DeleteSales(ctx, ids)
+	})
+}
+
+func reverseSalePoints(ctx context.Context, q *Queries, saleID int64) error {
+	sale, err := q.GetSale(ctx, saleID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	points, err := q.SumLoyaltyPointsBySale(ctx, saleID)
+	if err != nil || points == 0 {
+		return err
+	}
+
+	_, err = q.CreateLoyaltyEntry(ctx, CreateLoyaltyEntryParams{
+		ClientID:    sale.ClientID,
+		SaleID:      saleID,
+		Points:      -points,
+		Kind:        LoyaltyKindReversal,
+		Description: fmt.Sprintf("Estorno da venda #%d", saleID),
+	})
+	return err
+}
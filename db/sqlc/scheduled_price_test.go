@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createPricedProduct(t *testing.T, price float64) Product {
+	product := createRandomProduct(t)
+
+	product, err := testQueries.UpdateProductPricing(context.Background(), UpdateProductPricingParams{
+		ID:    product.ID,
+		Price: price,
+	})
+	require.NoError(t, err)
+	require.Equal(t, price, product.Price)
+
+	return product
+}
+
+func TestScheduledPromotion(t *testing.T) {
+	product := createPricedProduct(t, 100)
+	store := NewStore(testDB)
+	endsAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	entry, err := testQueries.CreateScheduledPrice(context.Background(), CreateScheduledPriceParams{
+		ProductID: product.ID,
+		Price:     80,
+		StartsAt:  time.Now().Add(-time.Minute),
+		EndsAt:    endsAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledPriceScheduled, entry.Status)
+
+	started, err := store.StartScheduledPriceTx(context.Background(), entry.ID)
+	require.NoError(t, err)
+	require.False(t, started.Skipped)
+	require.Equal(t, ScheduledPriceActive, started.ScheduledPrice.Status)
+	require.Equal(t, 80.0, started.Product.Price)
+	require.Equal(t, 100.0, started.Product.OldPrice)
+	require.WithinDuration(t, endsAt, started.Product.PromotionEndsAt, time.Second)
+
+	// starting twice is a no-op
+	again, err := store.StartScheduledPriceTx(context.Background(), entry.ID)
+	require.NoError(t, err)
+	require.True(t, again.Skipped)
+
+	finished, err := store.FinishScheduledPriceTx(context.Background(), FinishScheduledPriceTxParams{
+		ID:        entry.ID,
+		Status:    ScheduledPriceFinished,
+		ChangedBy: PriceChangedByScheduler,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledPriceFinished, finished.ScheduledPrice.Status)
+	require.False(t, finished.PriceKept)
+	require.Equal(t, 100.0, finished.Product.Price)
+	require.Zero(t, finished.Product.OldPrice)
+	require.True(t, finished.Product.PromotionEndsAt.IsZero())
+}
+
+func TestScheduledPromotionPriceChangedMeanwhile(t *testing.T) {
+	product := createPricedProduct(t, 100)
+	store := NewStore(testDB)
+
+	entry, err := testQueries.CreateScheduledPrice(context.Background(), CreateScheduledPriceParams{
+		ProductID: product.ID,
+		Price:     80,
+		StartsAt:  time.Now().Add(-time.Minute),
+		EndsAt:    time.Now().Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = store.StartScheduledPriceTx(context.Background(), entry.ID)
+	require.NoError(t, err)
+
+	// an admin sets a new price while the promotion runs
+	_, err = testQueries.UpdateProductPricing(context.Background(), UpdateProductPricingParams{
+		ID:    product.ID,
+		Price: 90,
+	})
+	require.NoError(t, err)
+
+	finished, err := store.FinishScheduledPriceTx(context.Background(), FinishScheduledPriceTxParams{
+		ID:        entry.ID,
+		Status:    ScheduledPriceFinished,
+		ChangedBy: PriceChangedByScheduler,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledPriceFinished, finished.ScheduledPrice.Status)
+	require.True(t, finished.PriceKept)
+	require.Equal(t, 90.0, finished.Product.Price)
+
+	current, err := testQueries.GetProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, 90.0, current.Price)
+}
+
+func TestScheduledPermanentPrice(t *testing.T) {
+	product := createPricedProduct(t, 100)
+	store := NewStore(testDB)
+
+	entry, err := testQueries.CreateScheduledPrice(context.Background(), CreateScheduledPriceParams{
+		ProductID: product.ID,
+		Price:     120,
+		StartsAt:  time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	started, err := store.StartScheduledPriceTx(context.Background(), entry.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledPriceFinished, started.ScheduledPrice.Status)
+	require.Equal(t, 120.0, started.Product.Price)
+	require.Zero(t, started.Product.OldPrice)
+
+	expired, err := testQueries.ListExpiredScheduledPrices(context.Background(), time.Now().Add(48*time.Hour))
+	require.NoError(t, err)
+	for _, e := range expired {
+		require.NotEqual(t, entry.ID, e.ID)
+	}
+}
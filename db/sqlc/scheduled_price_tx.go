@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// Statuses of a scheduled price
+const (
+	ScheduledPriceScheduled = "scheduled"
+	ScheduledPriceActive    = "active"
+	ScheduledPriceFinished  = "finished"
+	ScheduledPriceCancelled = "cancelled"
+)
+
+// ScheduledPriceTxResult is the result of the scheduled price transactions.
+// Skipped is true when the entry was no longer in the expected status.
+// PriceKept is true when an active entry ended without restoring the previous prices,
+// because the product price was changed by someone else while it was active.
+type ScheduledPriceTxResult struct {
+	ScheduledPrice ScheduledPrice `json:"scheduled_price"`
+	Product        Product        `json:"product"`
+	Skipped        bool           `json:"skipped"`
+	PriceKept      bool           `json:"price_kept"`
+}
+
+// StartScheduledPriceTx applies a scheduled price to its product.
+// Entries with an end date are promotions: the current price becomes old_price so the storefront
+// shows "de/por" until the end date. Entries without an end date are permanent price changes.
+// The previous prices are saved on the entry so they can be restored when it ends.
+func (store *SQLStore) StartScheduledPriceTx(ctx context.Context, id int64) (ScheduledPriceTxResult, error) {
+	var result ScheduledPriceTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		entry, err := q.GetScheduledPriceForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if entry.Status != ScheduledPriceScheduled {
+			result.ScheduledPrice = entry
+			result.Skipped = true
+			return nil
+		}
+
+		product, err := q.GetProductForUpdate(ctx, entry.ProductID)
+		if err != nil {
+			return err
+		}
+
+		arg := UpdateProductPricingParams{
+			ID:              product.ID,
+			Price:           entry.Price,
+			OldPrice:        0,
+			PromotionEndsAt: time.Time{},
+		}
+		status := ScheduledPriceFinished
+		if !entry.EndsAt.IsZero() {
+			status = ScheduledPriceActive
+			arg.PromotionEndsAt = entry.EndsAt
+			if entry.Price < product.Price {
+				arg.OldPrice = product.Price
+			}
+		}
+
+		result.Product, err = q.UpdateProductPricing(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		err = recordPriceChange(ctx, q, product, result.Product, PriceChangedByScheduler, PriceSourceScheduled)
+		if err != nil {
+			return err
+		}
+
+		result.ScheduledPrice, err = q.UpdateScheduledPriceStatus(ctx, UpdateScheduledPriceStatusParams{
+			ID:               entry.ID,
+			Status:           status,
+			PreviousPrice:    product.Price,
+			PreviousOldPrice: product.OldPrice,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// FinishScheduledPriceTxParams contains the input parameters of the finish scheduled price transaction
+type FinishScheduledPriceTxParams struct {
+	ID int64 `json:"id"`
+	// finished or cancelled
+	Status    string `json:"status"`
+	ChangedBy string `json:"changed_by"`
+}
+
+// FinishScheduledPriceTx ends an active promotion, restoring the prices the product had before it
+// started, and marks the entry with the given status. The prices are only restored while the product
+// still has the price the entry applied, a price changed meanwhile is kept and reported with PriceKept.
+func (store *SQLStore) FinishScheduledPriceTx(ctx context.Context, arg FinishScheduledPriceTxParams) (ScheduledPriceTxResult, error) {
+	var result ScheduledPriceTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		entry, err := q.GetScheduledPriceForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		switch entry.Status {
+		case ScheduledPriceActive:
+			product, err := q.GetProductForUpdate(ctx, entry.ProductID)
+			if err != nil {
+				return err
+			}
+
+			if product.Price != entry.Price {
+				result.Product = product
+				result.PriceKept = true
+				break
+			}
+
+			result.Product, err = q.UpdateProductPricing(ctx, UpdateProductPricingParams{
+				ID:              entry.ProductID,
+				Price:           entry.PreviousPrice,
+				OldPrice:        entry.PreviousOldPrice,
+				PromotionEndsAt: time.Time{},
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordPriceChange(ctx, q, product, result.Product, arg.ChangedBy, PriceSourceScheduled)
+			if err != nil {
+				return err
+			}
+		case ScheduledPriceScheduled:
+			// never applied, there is nothing to restore
+		default:
+			result.ScheduledPrice = entry
+			result.Skipped = true
+			return nil
+		}
+
+		result.ScheduledPrice, err = q.UpdateScheduledPriceStatus(ctx, UpdateScheduledPriceStatusParams{
+			ID:               entry.ID,
+			Status:           arg.Status,
+			PreviousPrice:    entry.PreviousPrice,
+			PreviousOldPrice: entry.PreviousOldPrice,
+		})
+		return err
+	})
+
+	return result, err
+}
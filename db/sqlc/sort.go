@@ -0,0 +1,38 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidSort is returned by the lists when the sort field or direction isn't one they allow
+var ErrInvalidSort = errors.New("invalid sort")
+
+// The columns each list can be sorted by and their postgres type. The sort field is written in the
+// ORDER BY as it is, so it must be one of these, and the keyset pagination casts the cursor value to the type.
+var (
+	saleSortColumns    = map[string]string{"id": "bigint", "product": "varchar", "price": "float8", "created_at": "timestamptz", "client_name": "varchar"}
+	clientSortColumns  = map[string]string{"id": "bigint", "full_name": "varchar", "pet_name": "varchar", "phone_whatsapp": "varchar"}
+	productSortColumns = map[string]string{"id": "bigint", "name": "varchar", "description": "varchar", "price": "float8", "username": "varchar", "sku": "varchar", "created_at": "timestamptz"}
+	imageSortColumns   = map[string]string{"id": "bigint", "name": "varchar", "description": "varchar", "alt": "varchar", "image_path": "varchar"}
+)
+
+// checkSort makes sure the sort field is one of the columns and the direction asc or desc.
+// The searches accept both empty for their default order, checkSort doesn't.
+func checkSort(sortField string, sortDirection string, columns map[string]string) error {
+	if _, ok := columns[sortField]; !ok {
+		return fmt.Errorf("%w field: %s", ErrInvalidSort, sortField)
+	}
+	if sortDirection != "asc" && sortDirection != "desc" {
+		return fmt.Errorf("%w direction: %s", ErrInvalidSort, sortDirection)
+	}
+	return nil
+}
+
+// checkSearchSort is checkSort for the searches, without both a sort field and a direction they use their default order
+func checkSearchSort(sortField string, sortDirection string, columns map[string]string) error {
+	if sortField == "" || sortDirection == "" {
+		return nil
+	}
+	return checkSort(sortField, sortDirection, columns)
+}
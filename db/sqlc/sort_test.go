@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSort(t *testing.T) {
+	require.NoError(t, checkSort("price", "desc", saleSortColumns))
+	require.ErrorIs(t, checkSort("price; DROP TABLE sale", "desc", saleSortColumns), ErrInvalidSort)
+	require.ErrorIs(t, checkSort("price", "desc, id", saleSortColumns), ErrInvalidSort)
+	require.ErrorIs(t, checkSort("", "", saleSortColumns), ErrInvalidSort)
+
+	require.NoError(t, checkSearchSort("", "", clientSortColumns))
+	require.NoError(t, checkSearchSort("full_name", "", clientSortColumns))
+	require.ErrorIs(t, checkSearchSort("address_street", "asc", clientSortColumns), ErrInvalidSort)
+}
+
+func TestSearchTotals(t *testing.T) {
+	store := NewSortableStore(testDB)
+	client := createRandomClient(t)
+
+	// the total counts every client the search matches, not only the ones in the page
+	_, total, err := store.SearchClients(context.Background(), client.FullName, 1, 5, "", "")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, total, int64(1))
+
+	clients, total, err := store.SearchClients(context.Background(), "no client is called like this", 1, 5, "", "")
+	require.NoError(t, err)
+	require.Empty(t, clients)
+	require.Zero(t, total)
+
+	_, _, err = store.SearchClients(context.Background(), client.FullName, 1, 5, "full_name; DROP TABLE client", "asc")
+	require.ErrorIs(t, err, ErrInvalidSort)
+
+	sale := createRandomSale(t)
+	sales, total, err := store.SearchSales(context.Background(), sale.Product, 1, 5, "created_at", "desc")
+	require.NoError(t, err)
+	require.Equal(t, int64(len(sales)), total)
+	require.Equal(t, sale.ID, sales[0].ID)
+
+	image := createRandomImage(t)
+	images, total, err := store.SearchImages(context.Background(), image.Name, 1, 5, "", "")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, image.ID, images[0].ID)
+}
+
+func TestFindLimit(t *testing.T) {
+	store := NewSortableStore(testDB)
+	client := createRandomClient(t)
+	createRandomClient(t)
+
+	clients, err := store.FindClients(context.Background(), client.FullName, 5)
+	require.NoError(t, err)
+	require.Equal(t, client.ID, clients[0].ID)
+
+	clients, err = store.FindClients(context.Background(), "", 1)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+
+	image := createRandomImage(t)
+	images, err := store.FindImages(context.Background(), image.Name, 5)
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Equal(t, image.ID, images[0].ID)
+}
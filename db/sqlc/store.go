@@ -10,19 +10,54 @@ import (
 
 type Store interface {
 	Querier
+	ExecTx(ctx context.Context, fn func(*Queries) error) error
+	CreateSubscriptionTx(ctx context.Context, arg CreateSubscriptionTxParams) (SubscriptionTxResult, error)
+	UpdateSubscriptionTx(ctx context.Context, arg UpdateSubscriptionTxParams) (SubscriptionTxResult, error)
+	GenerateSubscriptionSaleTx(ctx context.Context, arg GenerateSubscriptionSaleTxParams) (GenerateSubscriptionSaleTxResult, error)
+	CreateSaleTx(ctx context.Context, arg CreateSaleTxParams) (CreateSaleTxResult, error)
+	UpdateSaleTx(ctx context.Context, arg UpdateSaleTxParams) (Sale, error)
+	DeleteSaleTx(ctx context.Context, id int64) error
+	DeleteSalesTx(ctx context.Context, ids []int32) error
+	StartScheduledPriceTx(ctx context.Context, id int64) (ScheduledPriceTxResult, error)
+	FinishScheduledPriceTx(ctx context.Context, arg FinishScheduledPriceTxParams) (ScheduledPriceTxResult, error)
+	CreateProductTx(ctx context.Context, arg CreateProductTxParams) (Product, error)
+	UpdateProductTx(ctx context.Context, arg UpdateProductTxParams) (Product, error)
+	AssociateCategoriesTx(ctx context.Context, arg ProductCategoriesTxParams) (Product, error)
+	DisassociateCategoriesTx(ctx context.Context, arg ProductCategoriesTxParams) (Product, error)
+	SetProductCategoriesTx(ctx context.Context, arg ProductCategoriesTxParams) (Product, error)
+	AssociateImagesTx(ctx context.Context, arg ProductImagesTxParams) (Product, error)
+	DisassociateImagesTx(ctx context.Context, productID int64, imageIDs []int64) (Product, error)
+	EditImageOrderTx(ctx context.Context, arg ProductImagesTxParams) (EditImageOrderTxResult, error)
+	DeleteImageTx(ctx context.Context, imageID int64) error
+	CreatePurchaseOrderTx(ctx context.Context, arg CreatePurchaseOrderTxParams) (PurchaseOrderTxResult, error)
+	UpdatePurchaseOrderTx(ctx context.Context, arg UpdatePurchaseOrderTxParams) (PurchaseOrderTxResult, error)
+	SendPurchaseOrderTx(ctx context.Context, id int64) (PurchaseOrder, error)
+	ReceivePurchaseOrderTx(ctx context.Context, arg ReceivePurchaseOrderTxParams) (PurchaseOrderTxResult, error)
+	DeleteClientTx(ctx context.Context, arg DeleteClientTxParams) error
+	DeleteCategoryTx(ctx context.Context, id int64) (DeleteCategoryTxResult, error)
 }
 
 type SortableStore interface {
 	Store
 	ListClientsSorted(ctx context.Context, arg ListClientsParams, sortField string, sortDirection string) ([]Client, error)
-	SearchClients(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Client, error)
+	SearchClients(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Client, int64, error)
+	FindClients(ctx context.Context, search string, limit int) ([]Client, error)
 	ListSalesSorted(ctx context.Context, arg ListSalesParams, sortField string, sortDirection string) ([]Sale, error)
-	SearchSales(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Sale, error)
-	ListProductsSorted(ctx context.Context, arg ListProductsParams, sortField string, sortDirection string) ([]Product, int64, error)
-	SearchProducts(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Product, int64, error)
-	FilterProducts(ctx context.Context, categoryIds []int64, pageId int, pageSize int, sortField string, sortDirection string, search string) ([]Product, int64, error)
+	SearchSales(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Sale, int64, error)
+	FindSales(ctx context.Context, search string, limit int) ([]Sale, error)
+	FilterSales(ctx context.Context, filter SaleFilter, pageId int, pageSize int, sortField string, sortDirection string, search string) ([]Sale, int64, error)
+	ListProductsSorted(ctx context.Context, arg ListProductsParams, sortField string, sortDirection string, publicOnly bool) ([]Product, int64, error)
+	SearchProducts(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string, publicOnly bool) ([]Product, int64, error)
+	FindProducts(ctx context.Context, search string, limit int, publicOnly bool) ([]Product, error)
+	FilterProducts(ctx context.Context, categoryIds []int64, brandIds []int64, pageId int, pageSize int, sortField string, sortDirection string, search string, publicOnly bool) ([]Product, int64, error)
+	ProductFacets(ctx context.Context, categoryIds []int64, brandIds []int64, search string, publicOnly bool) (ProductFacets, error)
 	ListImagesSorted(ctx context.Context, arg ListImagesParams, sortField string, sortDirection string) ([]Image, error)
-	SearchImages(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Image, error)
+	SearchImages(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Image, int64, error)
+	FindImages(ctx context.Context, search string, limit int) ([]Image, error)
+	ListSalesPage(ctx context.Context, arg SalePageParams) ([]Sale, string, error)
+	ListClientsPage(ctx context.Context, arg PageParams) ([]Client, string, error)
+	ListProductsPage(ctx context.Context, arg ProductPageParams) ([]Product, string, error)
+	ListImagesPage(ctx context.Context, arg PageParams) ([]Image, string, error)
 }
 
 // Store provides all functions to execute db queries and transaction
@@ -48,413 +83,201 @@ func NewSortableStore(db *sql.DB) SortableStore {
 	}
 }
 
-func (store *SortableSQLStore) ListClientsSorted(ctx context.Context, arg ListClientsParams, sortField string, sortDirection string) ([]Client, error) {
-	// Define a map of valid sort fields and directions
-	validSortFields := map[string]bool{"full_name": true, "pet_name": true, "phone_whatsapp": true}
-	validSortDirections := map[string]bool{"asc": true, "desc": true}
-
-	// Validate the sort field and direction
-	if !validSortFields[sortField] {
-		return nil, fmt.Errorf("invalid sort field: %s", sortField)
-	}
-	if !validSortDirections[sortDirection] {
-		return nil, fmt.Errorf("invalid sort direction: %s", sortDirection)
+// ExecTx executes a function within a database transaction.
+// The queries run by fn are committed together, or rolled back when it returns an error.
+func (store *SQLStore) ExecTx(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
 
-	// Create the SQL query
-	query := fmt.Sprintf("SELECT * FROM client ORDER BY %s %s LIMIT $1 OFFSET $2", sortField, sortDirection)
-	fmt.Println(query)
-	// Execute the query
-	rows, err := store.db.QueryContext(ctx, query, arg.Limit, arg.Offset)
+	q := New(tx)
+	err = fn(q)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	// Scan the results into a Client slice
-	var clients []Client
-	for rows.Next() {
-		var client Client
-		if err := rows.Scan(&client.ID, &client.FullName, &client.PhoneWhatsapp, &client.PhoneLine, &client.PetName, &client.PetBreed, &client.AddressStreet, &client.AddressCity, &client.AddressNumber, &client.AddressNeighborhood, &client.AddressReference, &client.CreatedAt, &client.ChangedAt); err != nil {
-			return nil, err
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
 		}
-		clients = append(clients, client)
+		return err
 	}
 
-	// Check for errors from iterating over rows
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return clients, nil
+	return tx.Commit()
 }
 
-func (store *SortableSQLStore) SearchClients(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Client, error) {
-	offset := (pageId - 1) * pageSize
-
-	query := `SELECT * FROM client WHERE 
-        LOWER(full_name) LIKE LOWER($1) OR 
-        LOWER(phone_whatsapp) LIKE LOWER($1) OR 
-        LOWER(phone_line) LIKE LOWER($1) OR 
-        LOWER(pet_name) LIKE LOWER($1) OR 
-        LOWER(pet_breed) LIKE LOWER($1) OR 
-        LOWER(address_street) LIKE LOWER($1) OR 
-		LOWER(address_city) LIKE LOWER($1) OR
-        LOWER(address_number) LIKE LOWER($1) OR 
-        LOWER(address_neighborhood) LIKE LOWER($1) OR 
-        LOWER(address_reference) LIKE LOWER($1) OR
-		CAST(id AS TEXT) LIKE LOWER($1)`
-
-	if sortField != "" && sortDirection != "" {
-		query += fmt.Sprintf(" ORDER BY %s %s", sortField, sortDirection)
+func (store *SortableSQLStore) ListClientsSorted(ctx context.Context, arg ListClientsParams, sortField string, sortDirection string) ([]Client, error) {
+	q := newListQuery("client", clientColumns, clientSortColumns).page(arg.Limit, arg.Offset)
+	if err := q.sort(sortField, sortDirection); err != nil {
+		return nil, err
 	}
 
-	query += " LIMIT $2 OFFSET $3"
+	return listRows(ctx, store.db, q, scanClient)
+}
 
-	rows, err := store.db.QueryContext(ctx, query, "%"+search+"%", pageSize, offset)
-	if err != nil {
-		return nil, err
+// SearchClients lists the clients matching the search and counts all of them
+func (store *SortableSQLStore) SearchClients(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Client, int64, error) {
+	q := newListQuery("client", clientColumns, clientSortColumns).
+		search(search, clientSearchColumns...).
+		page(int32(pageSize), int32((pageId-1)*pageSize))
+	if err := q.searchSort(sortField, sortDirection); err != nil {
+		return nil, 0, err
 	}
-	defer rows.Close()
 
-	var clients []Client
-	for rows.Next() {
-		var c Client
-		if err = rows.Scan(&c.ID, &c.FullName, &c.PhoneWhatsapp, &c.PhoneLine, &c.PetName, &c.PetBreed, &c.AddressStreet, &c.AddressCity, &c.AddressNumber, &c.AddressNeighborhood, &c.AddressReference, &c.CreatedAt, &c.ChangedAt); err != nil {
-			return nil, err
-		}
-		clients = append(clients, c)
-	}
+	return listAndCount(ctx, store.db, q, scanClient)
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
-	}
+// FindClients lists the first clients matching the search, without counting them
+func (store *SortableSQLStore) FindClients(ctx context.Context, search string, limit int) ([]Client, error) {
+	q := newListQuery("client", clientColumns, clientSortColumns).
+		search(search, clientSearchColumns...).
+		page(int32(limit), 0)
 
-	return clients, nil
+	return listRows(ctx, store.db, q, scanClient)
 }
 
 func (store *SortableSQLStore) ListSalesSorted(ctx context.Context, arg ListSalesParams, sortField string, sortDirection string) ([]Sale, error) {
-	// Define a map of valid sort fields and directions
-	validSortFields := map[string]bool{"product": true, "price": true, "created_at": true, "client_name": true}
-	validSortDirections := map[string]bool{"asc": true, "desc": true}
-
-	// If sortField is provided, validate it
-	if sortField != "" {
-		if !validSortFields[sortField] {
-			return nil, fmt.Errorf("invalid sort field: %s", sortField)
-		}
-	} else {
-		// If sortField is not provided, use a default value
+	// If sortField or sortDirection are not provided, use a default value
+	if sortField == "" {
 		sortField = "product"
 	}
-
-	// If sortDirection is provided, validate it
-	if sortDirection != "" {
-		if !validSortDirections[sortDirection] {
-			return nil, fmt.Errorf("invalid sort direction: %s", sortDirection)
-		}
-	} else {
-		// If sortDirection is not provided, use a default value
+	if sortDirection == "" {
 		sortDirection = "asc"
 	}
 
-	// Create the SQL query
-	query := fmt.Sprintf("SELECT * FROM sale ORDER BY %s %s LIMIT $1 OFFSET $2", sortField, sortDirection)
-
-	// Execute the query
-	rows, err := store.db.QueryContext(ctx, query, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	// Scan the results into a Sale slice
-	var sales []Sale
-	for rows.Next() {
-		var sale Sale
-		if err := rows.Scan(&sale.ID, &sale.ClientID, &sale.ClientName, &sale.Product, &sale.Price, &sale.Observation, &sale.CreatedAt, &sale.ChangedAt, &sale.PdfGeneratedAt); err != nil {
-			return nil, err
-		}
-		sales = append(sales, sale)
-	}
-
-	// Check for errors from iterating over rows
-	if err := rows.Err(); err != nil {
+	q := newListQuery("sale", saleColumns, saleSortColumns).page(arg.Limit, arg.Offset)
+	if err := q.sort(sortField, sortDirection); err != nil {
 		return nil, err
 	}
 
-	return sales, nil
+	return listRows(ctx, store.db, q, scanSale)
 }
 
-func (store *SortableSQLStore) SearchSales(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Sale, error) {
-	offset := (pageId - 1) * pageSize
-
-	query := `SELECT * FROM sale WHERE 
-        LOWER(product) LIKE LOWER($1) OR 
-		LOWER(client_name) LIKE LOWER($1) OR
-        LOWER(observation) LIKE LOWER($1) OR
-        CAST(price AS TEXT) LIKE LOWER($1) OR
-		CAST(id AS TEXT) LIKE LOWER ($1)`
-
-	if sortField != "" && sortDirection != "" {
-		query += fmt.Sprintf(" ORDER BY %s %s", sortField, sortDirection)
+// SearchSales lists the sales matching the search and counts all of them
+func (store *SortableSQLStore) SearchSales(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Sale, int64, error) {
+	q := newListQuery("sale", saleColumns, saleSortColumns).
+		search(search, saleSearchColumns...).
+		page(int32(pageSize), int32((pageId-1)*pageSize))
+	if err := q.searchSort(sortField, sortDirection); err != nil {
+		return nil, 0, err
 	}
 
-	query += " LIMIT $2 OFFSET $3"
-
-	rows, err := store.db.QueryContext(ctx, query, "%"+search+"%", pageSize, offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	return listAndCount(ctx, store.db, q, scanSale)
+}
 
-	var sales []Sale
-	for rows.Next() {
-		var s Sale
-		if err = rows.Scan(&s.ID, &s.ClientID, &s.ClientName, &s.Product, &s.Price, &s.Observation, &s.CreatedAt, &s.ChangedAt, &s.PdfGeneratedAt); err != nil {
-			return nil, err
-		}
-		sales = append(sales, s)
-	}
+// FindSales lists the first sales matching the search, without counting them
+func (store *SortableSQLStore) FindSales(ctx context.Context, search string, limit int) ([]Sale, error) {
+	q := newListQuery("sale", saleColumns, saleSortColumns).
+		search(search, saleSearchColumns...).
+		page(int32(limit), 0)
 
-	if err = rows.Err(); err != nil {
-		return nil, err
-	}
+	return listRows(ctx, store.db, q, scanSale)
+}
 
-	return sales, nil
+// categoryTree expands the category ids in the parameter to the categories and all their descendants.
+// The products of a category are found through product_categories.
+func categoryTree(categoryIds string) string {
+	return fmt.Sprintf(`
+	WITH RECURSIVE category_tree AS (
+		SELECT id FROM categories WHERE id = ANY(%s::bigint[])
+		UNION
+		SELECT c.id FROM categories c JOIN category_tree t ON c.parent_id = t.id
+	) `, categoryIds)
 }
 
-func (store *SortableSQLStore) FilterProducts(ctx context.Context, categoryIds []int64, pageId int, pageSize int, sortField string, sortDirection string, search string) ([]Product, int64, error) {
-	offset := (pageId - 1) * pageSize
-
-	query := `
-	SELECT id, name, description, user_id, username, price, old_price, sku, images, categories, url, created_at, changed_at 
-	FROM products 
-	WHERE ($1::bigint[] IS NOT NULL AND categories && $1) AND
-	(LOWER(name) LIKE LOWER($2) OR
-	LOWER(description) LIKE LOWER($2) OR
-	CAST(price AS TEXT) LIKE LOWER($2) OR
-	LOWER(username) LIKE LOWER($2) OR
-	LOWER(sku) LIKE LOWER($2))
-	`
-
-	if sortField != "" && sortDirection != "" {
-		query += fmt.Sprintf(" ORDER BY %s %s", sortField, sortDirection)
+// productListQuery is the query of the product lists, publicOnly leaves out the products that aren't published or were deleted
+func productListQuery(publicOnly bool) *listQuery {
+	q := newListQuery("products", productColumns, productSortColumns)
+	if publicOnly {
+		q.where(publicProductFilter)
 	}
+	return q
+}
 
-	query += " LIMIT $3 OFFSET $4"
-
-	rows, err := store.db.QueryContext(ctx, query, pq.Array(categoryIds), "%"+search+"%", pageSize, offset)
-	if err != nil {
-		return nil, 0, err
+// filterProducts keeps the products in the categories, including their subcategories, and brands
+func filterProducts(q *listQuery, categoryIds []int64, brandIds []int64) {
+	if len(categoryIds) != 0 {
+		q.withCTE(categoryTree(q.arg(pq.Array(categoryIds))))
+		q.where("EXISTS (SELECT 1 FROM product_categories pc WHERE pc.product_id = products.id AND pc.category_id IN (SELECT id FROM category_tree))")
 	}
-	defer rows.Close()
-
-	var products []Product
-	for rows.Next() {
-		var p Product
-		if err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.UserID, &p.Username, &p.Price, &p.OldPrice, &p.Sku, pq.Array(&p.Images), pq.Array(&p.Categories), &p.Url, &p.CreatedAt, &p.ChangedAt); err != nil {
-			return nil, 0, err
-		}
-		products = append(products, p)
+	if len(brandIds) != 0 {
+		q.where("brand_id = ANY(" + q.arg(pq.Array(brandIds)) + "::bigint[])")
 	}
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, 0, err
+// searchProducts keeps the products found by the search, ranked by relevance unless a sort was chosen
+func searchProducts(q *listQuery, search string) {
+	if search != "" {
+		p := q.arg(search)
+		q.where(productSearchCondition(p)).defaultOrder(productSearchRank(p))
 	}
+}
 
-	countQuery := `
-    SELECT COUNT(*) 
-    FROM products 
-    WHERE ($1::bigint[] IS NOT NULL AND categories && $1) AND
-    (LOWER(name) LIKE LOWER($2) OR
-    LOWER(description) LIKE LOWER($2) OR
-    CAST(price AS TEXT) LIKE LOWER($2) OR
-    LOWER(username) LIKE LOWER($2) OR
-    LOWER(sku) LIKE LOWER($2))
-    `
-	var totalCount int64
-	if err := store.db.QueryRowContext(ctx, countQuery, pq.Array(categoryIds), "%"+search+"%").Scan(&totalCount); err != nil {
+// FilterProducts lists the products in the given categories, including their subcategories, and brands.
+// publicOnly leaves out the products that aren't published or were deleted.
+func (store *SortableSQLStore) FilterProducts(ctx context.Context, categoryIds []int64, brandIds []int64, pageId int, pageSize int, sortField string, sortDirection string, search string, publicOnly bool) ([]Product, int64, error) {
+	q := productListQuery(publicOnly).page(int32(pageSize), int32((pageId-1)*pageSize))
+	if err := q.searchSort(sortField, sortDirection); err != nil {
 		return nil, 0, err
 	}
+	filterProducts(q, categoryIds, brandIds)
+	searchProducts(q, search)
 
-	return products, totalCount, nil
+	return listAndCount(ctx, store.db, q, scanProduct)
 }
 
-func (store *SortableSQLStore) ListProductsSorted(ctx context.Context, arg ListProductsParams, sortField string, sortDirection string) ([]Product, int64, error) {
-	// Define a map of valid sort fields and directions
-	validSortFields := map[string]bool{"name": true, "description": true, "price": true, "username": true, "sku": true, "created_at": true}
-	validSortDirections := map[string]bool{"asc": true, "desc": true}
-
-	// Validate the sort field and direction
-	if !validSortFields[sortField] {
-		return nil, 0, fmt.Errorf("invalid sort field: %s", sortField)
-	}
-	if !validSortDirections[sortDirection] {
-		return nil, 0, fmt.Errorf("invalid sort direction: %s", sortDirection)
-	}
-
-	// Create the SQL query
-	var query string
-	if sortField == "price" {
-		query = fmt.Sprintf("SELECT id, name, description, user_id, username, price, old_price, sku, images, categories, url, created_at, changed_at FROM products ORDER BY CAST(%s AS FLOAT) %s LIMIT $1 OFFSET $2", sortField, sortDirection)
-	} else {
-		query = fmt.Sprintf("SELECT id, name, description, user_id, username, price, old_price, sku, images, categories, url, created_at, changed_at FROM products ORDER BY %s %s LIMIT $1 OFFSET $2", sortField, sortDirection)
-	}
-
-	// Execute the query
-	rows, err := store.db.QueryContext(ctx, query, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, 0, err
-	}
-	defer rows.Close()
-
-	// Scan the results into a Product slice
-	var products []Product
-	for rows.Next() {
-		var product Product
-		if err := rows.Scan(&product.ID, &product.Name, &product.Description, &product.UserID, &product.Username, &product.Price, &product.OldPrice, &product.Sku, pq.Array(&product.Images), pq.Array(&product.Categories), &product.Url, &product.CreatedAt, &product.ChangedAt); err != nil {
-			return nil, 0, err
-		}
-		products = append(products, product)
-	}
-
-	// Check for errors from iterating over rows
-	if err := rows.Err(); err != nil {
-		return nil, 0, err
-	}
-	countQuery := "SELECT COUNT(*) FROM products"
-	var totalCount int64
-	if err := store.db.QueryRowContext(ctx, countQuery).Scan(&totalCount); err != nil {
+func (store *SortableSQLStore) ListProductsSorted(ctx context.Context, arg ListProductsParams, sortField string, sortDirection string, publicOnly bool) ([]Product, int64, error) {
+	q := productListQuery(publicOnly).page(arg.Limit, arg.Offset)
+	if err := q.sort(sortField, sortDirection); err != nil {
 		return nil, 0, err
 	}
 
-	return products, totalCount, nil
+	return listAndCount(ctx, store.db, q, scanProduct)
 }
 
-func (store *SortableSQLStore) SearchProducts(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Product, int64, error) {
-	offset := (pageId - 1) * pageSize
-
-	query := `SELECT id, name, description, user_id, username, price, old_price, sku, images, categories, url, created_at, changed_at FROM products WHERE
-        LOWER(name) LIKE LOWER($1) OR
-        LOWER(description) LIKE LOWER($1) OR
-        CAST(price AS TEXT) LIKE LOWER($1) OR
-        LOWER(username) LIKE LOWER($1) OR
-        LOWER(sku) LIKE LOWER($1)`
-
-	if sortField != "" && sortDirection != "" {
-		query += fmt.Sprintf(" ORDER BY %s %s", sortField, sortDirection)
-	}
-
-	query += " LIMIT $2 OFFSET $3"
-
-	rows, err := store.db.QueryContext(ctx, query, "%"+search+"%", pageSize, offset)
-	if err != nil {
+// SearchProducts finds the products by the full text search of their name, sku and description,
+// ranked by relevance unless a sort is given. publicOnly leaves out the products that aren't public.
+func (store *SortableSQLStore) SearchProducts(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string, publicOnly bool) ([]Product, int64, error) {
+	q := productListQuery(publicOnly).page(int32(pageSize), int32((pageId-1)*pageSize))
+	if err := q.searchSort(sortField, sortDirection); err != nil {
 		return nil, 0, err
 	}
-	defer rows.Close()
+	searchProducts(q, search)
 
-	var products []Product
-	for rows.Next() {
-		var p Product
-		if err = rows.Scan(&p.ID, &p.Name, &p.Description, &p.UserID, &p.Username, &p.Price, &p.OldPrice, &p.Sku, pq.Array(&p.Images), pq.Array(&p.Categories), &p.Url, &p.CreatedAt, &p.ChangedAt); err != nil {
-			return nil, 0, err
-		}
-		products = append(products, p)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, 0, err
-	}
+	return listAndCount(ctx, store.db, q, scanProduct)
+}
 
-	countQuery := `SELECT COUNT(*) FROM products WHERE
-    LOWER(name) LIKE LOWER($1) OR
-    LOWER(description) LIKE LOWER($1) OR
-    CAST(price AS TEXT) LIKE LOWER($1) OR
-    LOWER(username) LIKE LOWER($1) OR
-    LOWER(sku) LIKE LOWER($1)`
-	var totalCount int64
-	if err := store.db.QueryRowContext(ctx, countQuery, "%"+search+"%").Scan(&totalCount); err != nil {
-		return nil, 0, err
-	}
+// FindProducts lists the most relevant products found by the search, without counting them
+func (store *SortableSQLStore) FindProducts(ctx context.Context, search string, limit int, publicOnly bool) ([]Product, error) {
+	q := productListQuery(publicOnly).page(int32(limit), 0)
+	searchProducts(q, search)
 
-	return products, totalCount, nil
+	return listRows(ctx, store.db, q, scanProduct)
 }
 
 func (store *SortableSQLStore) ListImagesSorted(ctx context.Context, arg ListImagesParams, sortField string, sortDirection string) ([]Image, error) {
-	// Define a map of valid sort fields and directions
-	validSortFields := map[string]bool{"name": true, "description": true, "alt": true, "image_path": true}
-	validSortDirections := map[string]bool{"asc": true, "desc": true}
-
-	// Validate the sort field and direction
-	if !validSortFields[sortField] {
-		return nil, fmt.Errorf("invalid sort field: %s", sortField)
-	}
-	if !validSortDirections[sortDirection] {
-		return nil, fmt.Errorf("invalid sort direction: %s", sortDirection)
-	}
-
-	// Create the SQL query
-	query := fmt.Sprintf("SELECT * FROM images ORDER BY %s %s LIMIT $1 OFFSET $2", sortField, sortDirection)
-
-	// Execute the query
-	rows, err := store.db.QueryContext(ctx, query, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	// Scan the results into an Image slice
-	var images []Image
-	for rows.Next() {
-		var image Image
-		if err := rows.Scan(&image.ID, &image.Name, &image.Description, &image.Alt, &image.ImagePath, &image.CreatedAt, &image.ChangedAt); err != nil {
-			return nil, err
-		}
-		images = append(images, image)
-	}
-
-	// Check for errors from iterating over rows
-	if err := rows.Err(); err != nil {
+	q := newListQuery("images", imageColumns, imageSortColumns).page(arg.Limit, arg.Offset)
+	if err := q.sort(sortField, sortDirection); err != nil {
 		return nil, err
 	}
 
-	return images, nil
+	return listRows(ctx, store.db, q, scanImage)
 }
 
-func (store *SortableSQLStore) SearchImages(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Image, error) {
-	offset := (pageId - 1) * pageSize
-
-	query := `SELECT * FROM images WHERE
-        LOWER(name) LIKE LOWER($1) OR
-        LOWER(description) LIKE LOWER($1) OR
-        LOWER(alt) LIKE LOWER($1) OR
-        LOWER(image_path) LIKE LOWER($1)`
-
-	if sortField != "" && sortDirection != "" {
-		query += fmt.Sprintf(" ORDER BY %s %s", sortField, sortDirection)
-	}
-
-	query += " LIMIT $2 OFFSET $3"
-
-	rows, err := store.db.QueryContext(ctx, query, "%"+search+"%", pageSize, offset)
-	if err != nil {
-		return nil, err
+// SearchImages lists the images matching the search and counts all of them
+func (store *SortableSQLStore) SearchImages(ctx context.Context, search string, pageId int, pageSize int, sortField string, sortDirection string) ([]Image, int64, error) {
+	q := newListQuery("images", imageColumns, imageSortColumns).
+		search(search, imageSearchColumns...).
+		page(int32(pageSize), int32((pageId-1)*pageSize))
+	if err := q.searchSort(sortField, sortDirection); err != nil {
+		return nil, 0, err
 	}
-	defer rows.Close()
 
-	var images []Image
-	for rows.Next() {
-		var i Image
-		if err = rows.Scan(&i.ID, &i.Name, &i.Description, &i.Alt, &i.ImagePath, &i.CreatedAt, &i.ChangedAt); err != nil {
-			return nil, err
-		}
-		images = append(images, i)
-	}
+	return listAndCount(ctx, store.db, q, scanImage)
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, err
-	}
+// FindImages lists the first images matching the search, without counting them
+func (store *SortableSQLStore) FindImages(ctx context.Context, search string, limit int) ([]Image, error) {
+	q := newListQuery("images", imageColumns, imageSortColumns).
+		search(search, imageSearchColumns...).
+		page(int32(limit), 0)
 
-	return images, nil
+	return listRows(ctx, store.db, q, scanImage)
 }
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSubscription(t *testing.T, nextRunDate time.Time) SubscriptionTxResult {
+	client := createRandomClient(t)
+	store := NewStore(testDB)
+
+	arg := CreateSubscriptionTxParams{
+		CreateSubscriptionParams: CreateSubscriptionParams{
+			ClientID:     client.ID,
+			IntervalDays: 30,
+			NextRunDate:  nextRunDate,
+			Observation:  util.RandomString(9),
+		},
+		Items: []SubscriptionItemParams{
+			{Product: util.RandomString(9), Quantity: 2, Price: 100},
+			{Product: util.RandomString(9), Quantity: 1, Price: 50},
+		},
+	}
+
+	result, err := store.CreateSubscriptionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, result.Subscription.ID)
+	require.Equal(t, client.ID, result.Subscription.ClientID)
+	require.False(t, result.Subscription.Paused)
+	require.Len(t, result.Items, 2)
+
+	return result
+}
+
+func TestCreateSubscriptionTx(t *testing.T) {
+	createRandomSubscription(t, time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC))
+}
+
+func TestUpdateSubscriptionTx(t *testing.T) {
+	created := createRandomSubscription(t, time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC))
+	store := NewStore(testDB)
+
+	arg := UpdateSubscriptionTxParams{
+		UpdateSubscriptionParams: UpdateSubscriptionParams{
+			ID:           created.Subscription.ID,
+			IntervalDays: 15,
+			NextRunDate:  created.Subscription.NextRunDate,
+			Paused:       true,
+			Observation:  created.Subscription.Observation,
+		},
+	}
+
+	// items are kept when they are not replaced
+	result, err := store.UpdateSubscriptionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(15), result.Subscription.IntervalDays)
+	require.True(t, result.Subscription.Paused)
+	require.Len(t, result.Items, 2)
+
+	arg.ReplaceItems = true
+	arg.Items = []SubscriptionItemParams{{Product: util.RandomString(9), Quantity: 3, Price: 10}}
+
+	result, err = store.UpdateSubscriptionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	require.Equal(t, arg.Items[0].Product, result.Items[0].Product)
+}
+
+func TestGenerateSubscriptionSaleTx(t *testing.T) {
+	runDate := time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC)
+	created := createRandomSubscription(t, runDate)
+	store := NewStore(testDB)
+
+	arg := GenerateSubscriptionSaleTxParams{
+		SubscriptionID: created.Subscription.ID,
+		RunDate:        runDate,
+		NextRunDate:    runDate.AddDate(0, 0, 30),
+		Sale: CreateSaleParams{
+			ClientID:    created.Subscription.ClientID,
+			Product:     util.RandomString(9),
+			Price:       250,
+			Observation: util.RandomString(9),
+		},
+	}
+
+	result, err := store.GenerateSubscriptionSaleTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, result.Skipped)
+	require.Equal(t, SaleStatusPending, result.Sale.Status)
+	require.Equal(t, result.Sale.ID, result.Run.SaleID)
+
+	subscription, err := testQueries.GetSubscription(context.Background(), created.Subscription.ID)
+	require.NoError(t, err)
+	require.WithinDuration(t, arg.NextRunDate, subscription.NextRunDate, 24*time.Hour)
+
+	// running the same date again must not create a second sale
+	result, err = store.GenerateSubscriptionSaleTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.Skipped)
+
+	runs, err := testQueries.ListSubscriptionRuns(context.Background(), created.Subscription.ID)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+}
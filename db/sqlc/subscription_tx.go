@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// SubscriptionItemParams contains one product of a subscription
+type SubscriptionItemParams struct {
+	Product  string  `json:"product"`
+	Quantity int32   `json:"quantity"`
+	Price    float64 `json:"price"`
+}
+
+// CreateSubscriptionTxParams contains the input parameters of the create subscription transaction
+type CreateSubscriptionTxParams struct {
+	CreateSubscriptionParams
+	Items []SubscriptionItemParams `json:"items"`
+}
+
+// UpdateSubscriptionTxParams contains the input parameters of the update subscription transaction.
+// Items are only replaced when ReplaceItems is set.
+type UpdateSubscriptionTxParams struct {
+	UpdateSubscriptionParams
+	ReplaceItems bool                     `json:"replace_items"`
+	Items        []SubscriptionItemParams `json:"items"`
+}
+
+// SubscriptionTxResult is the result of the create/update subscription transactions
+type SubscriptionTxResult struct {
+	Subscription Subscription       `json:"subscription"`
+	Items        []SubscriptionItem `json:"items"`
+}
+
+// CreateSubscriptionTx creates a subscription together with its items
+func (store *SQLStore) CreateSubscriptionTx(ctx context.Context, arg CreateSubscriptionTxParams) (SubscriptionTxResult, error) {
+	var result SubscriptionTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Subscription, err = q.CreateSubscription(ctx, arg.CreateSubscriptionParams)
+		if err != nil {
+			return err
+		}
+
+		result.Items, err = createSubscriptionItems(ctx, q, result.Subscription.ID, arg.Items)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateSubscriptionTx updates a subscription and, if requested, replaces its items
+func (store *SQLStore) UpdateSubscriptionTx(ctx context.Context, arg UpdateSubscriptionTxParams) (SubscriptionTxResult, error) {
+	var result SubscriptionTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Subscription, err = q.UpdateSubscription(ctx, arg.UpdateSubscriptionParams)
+		if err != nil {
+			return err
+		}
+
+		if !arg.ReplaceItems {
+			result.Items, err = q.ListSubscriptionItems(ctx, arg.ID)
+			return err
+		}
+
+		if err = q.DeleteSubscriptionItems(ctx, arg.ID); err != nil {
+			return err
+		}
+
+		result.Items, err = createSubscriptionItems(ctx, q, arg.ID, arg.Items)
+		return err
+	})
+
+	return result, err
+}
+
+func createSubscriptionItems(ctx context.Context, q *Queries, subscriptionID int64, items []SubscriptionItemParams) ([]SubscriptionItem, error) {
+	created := []SubscriptionItem{}
+	for _, item := range items {
+		i, err := q.CreateSubscriptionItem(ctx, CreateSubscriptionItemParams{
+			SubscriptionID: subscriptionID,
+			Product:        item.Product,
+			Quantity:       item.Quantity,
+			Price:          item.Price,
+		})
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, i)
+	}
+	return created, nil
+}
+
+// GenerateSubscriptionSaleTxParams contains the input parameters of the generate subscription sale transaction.
+// RunDate is the next_run_date the caller saw when it decided the subscription was due.
+type GenerateSubscriptionSaleTxParams struct {
+	SubscriptionID int64            `json:"subscription_id"`
+	RunDate        time.Time        `json:"run_date"`
+	NextRunDate    time.Time        `json:"next_run_date"`
+	Sale           CreateSaleParams `json:"sale"`
+}
+
+// GenerateSubscriptionSaleTxResult is the result of the generate subscription sale transaction.
+// Skipped is true when another run already handled RunDate.
+type GenerateSubscriptionSaleTxResult struct {
+	Sale    Sale            `json:"sale"`
+	Run     SubscriptionRun `json:"run"`
+	Skipped bool            `json:"skipped"`
+}
+
+// GenerateSubscriptionSaleTx creates the pending sale of a due subscription, records the run
+// and advances next_run_date. The subscription row is locked and the (subscription_id, run_date)
+// unique key guarantees that a run date never produces two sales, even across restarts.
+func (store *SQLStore) GenerateSubscriptionSaleTx(ctx context.Context, arg GenerateSubscriptionSaleTxParams) (GenerateSubscriptionSaleTxResult, error) {
+	var result GenerateSubscriptionSaleTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		subscription, err := q.GetSubscriptionForUpdate(ctx, arg.SubscriptionID)
+		if err != nil {
+			return err
+		}
+
+		if subscription.Paused || !sameDate(subscription.NextRunDate, arg.RunDate) {
+			result.Skipped = true
+			return nil
+		}
+
+		sale := arg.Sale
+		sale.Status = SaleStatusPending
+		result.Sale, err = q.CreateSale(ctx, sale)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateSaleStatusChange(ctx, CreateSaleStatusChangeParams{
+			SaleID: result.Sale.ID,
+			Status: result.Sale.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Run, err = q.CreateSubscriptionRun(ctx, CreateSubscriptionRunParams{
+			SubscriptionID: arg.SubscriptionID,
+			RunDate:        arg.RunDate,
+			SaleID:         result.Sale.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.UpdateSubscriptionNextRun(ctx, UpdateSubscriptionNextRunParams{
+			ID:          arg.SubscriptionID,
+			NextRunDate: arg.NextRunDate,
+		})
+	})
+
+	return result, err
+}
+
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
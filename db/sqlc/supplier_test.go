@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSupplier(t *testing.T) Supplier {
+	arg := CreateSupplierParams{
+		Name:  util.RandomFullName(),
+		Email: util.RandomEmail(),
+	}
+
+	supplier, err := testQueries.CreateSupplier(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, supplier.Name)
+	require.Equal(t, arg.Email, supplier.Email)
+	require.NotZero(t, supplier.ID)
+
+	return supplier
+}
+
+func TestUpdateSupplier(t *testing.T) {
+	supplier := createRandomSupplier(t)
+
+	updated, err := testQueries.UpdateSupplier(context.Background(), UpdateSupplierParams{
+		ID:    supplier.ID,
+		Name:  supplier.Name,
+		Email: supplier.Email,
+		Phone: "11988887777",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "11988887777", updated.Phone)
+	require.False(t, updated.ChangedAt.IsZero())
+}
+
+func TestMarginBySaleItems(t *testing.T) {
+	client := createRandomClient(t)
+	store := NewStore(testDB)
+	from := time.Now().Add(-24 * time.Hour)
+
+	result, err := store.CreateSaleTx(context.Background(), CreateSaleTxParams{
+		CreateSaleParams: CreateSaleParams{
+			ClientID:    client.ID,
+			ClientName:  client.FullName,
+			Product:     "Ração 15kg",
+			Price:       150,
+			Observation: util.RandomString(9),
+			Status:      SaleStatusConfirmed,
+		},
+		Items: []SaleItemParams{{ProductID: -client.ID, ProductName: "Ração 15kg", Quantity: 1, UnitPrice: 150, UnitCost: 90}},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+
+	items, err := testQueries.ListSaleItems(context.Background(), result.Sale.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Items, items)
+
+	rows, err := testQueries.GetMarginByProduct(context.Background(), GetMarginByProductParams{
+		FromDate: from,
+		ToDate:   time.Now().Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	// the negative product id only exists in this test
+	var found bool
+	for _, row := range rows {
+		if row.ProductID == -client.ID {
+			found = true
+			require.Equal(t, 150.0, row.Revenue)
+			require.Equal(t, 90.0, row.Cost)
+		}
+	}
+	require.True(t, found)
+}
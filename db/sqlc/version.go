@@ -0,0 +1,7 @@
+package db
+
+import "errors"
+
+// ErrVersionConflict is returned when a record is updated with an older version than its current one,
+// it was changed by someone else since it was read
+var ErrVersionConflict = errors.New("the record was changed since it was read")
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateVersion(t *testing.T) {
+	sale := createRandomSale(t)
+	require.Equal(t, int64(1), sale.Version)
+
+	arg := UpdateSaleParams{
+		ID:          sale.ID,
+		ClientID:    sale.ClientID,
+		ClientName:  sale.ClientName,
+		Product:     sale.Product,
+		Price:       sale.Price + 10,
+		Observation: sale.Observation,
+		Status:      sale.Status,
+		Version:     sale.Version,
+	}
+	updated, err := testQueries.UpdateSale(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updated.Version)
+	require.WithinDuration(t, time.Now(), updated.ChangedAt, time.Minute)
+
+	// a second admin still editing the first version doesn't overwrite the change
+	arg.Price = sale.Price + 20
+	_, err = testQueries.UpdateSale(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	got, err := testQueries.GetSale(context.Background(), sale.ID)
+	require.NoError(t, err)
+	require.Equal(t, updated.Price, got.Price)
+
+	client := createRandomClient(t)
+	_, err = testQueries.UpdateClient(context.Background(), UpdateClientParams{ID: client.ID, FullName: client.FullName, Version: client.Version + 1})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpdateProductTxVersion(t *testing.T) {
+	store := NewStore(testDB)
+	product := createRandomProduct(t)
+
+	arg := UpdateProductTxParams{
+		UpdateProductParams: UpdateProductParams{
+			ID:          product.ID,
+			Name:        product.Name,
+			Description: product.Description,
+			UserID:      product.UserID,
+			Username:    product.Username,
+			Price:       product.Price,
+			Url:         product.Url,
+			Version:     product.Version,
+		},
+	}
+	updated, err := store.UpdateProductTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, product.Version+1, updated.Version)
+
+	_, err = store.UpdateProductTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrVersionConflict)
+
+	// the price changes made by the system also change the version
+	repriced, err := testQueries.UpdateProductPricing(context.Background(), UpdateProductPricingParams{ID: product.ID, Price: 10})
+	require.NoError(t, err)
+	require.Equal(t, updated.Version+1, repriced.Version)
+}
+
+func TestSystemUpdatesVersion(t *testing.T) {
+	product := createRandomProduct(t)
+
+	// every change bumps the version and the change time
+	err := testQueries.UpdateProductLastCost(context.Background(), UpdateProductLastCostParams{ID: product.ID, LastCost: 12.5})
+	require.NoError(t, err)
+	got, err := testQueries.GetProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, product.Version+1, got.Version)
+	require.True(t, got.ChangedAt.After(product.ChangedAt))
+
+	changed := got.ChangedAt
+	got, err = testQueries.TouchProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, product.Version+2, got.Version)
+	require.False(t, got.ChangedAt.Before(changed))
+
+	changed = got.ChangedAt
+	got, err = testQueries.SoftDeleteProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, product.Version+3, got.Version)
+	require.False(t, got.ChangedAt.Before(changed))
+
+	changed = got.ChangedAt
+	got, err = testQueries.RestoreProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, product.Version+4, got.Version)
+	require.False(t, got.ChangedAt.Before(changed))
+}
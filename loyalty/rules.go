@@ -0,0 +1,85 @@
+package loyalty
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/util"
+)
+
+const (
+	defaultPointsPerReal = 1
+	defaultPointValue    = 0.05
+)
+
+// Rules decide how many points a sale earns and how much a redeemed point is worth
+type Rules struct {
+	PointsPerReal float64
+	PointValue    float64
+	// CategoryMultipliers are keyed by lower case category name
+	CategoryMultipliers map[string]float64
+}
+
+// RulesFromConfig builds the rules set in the configuration, using defaults for the empty values
+func RulesFromConfig(config util.Config) (Rules, error) {
+	rules := Rules{
+		PointsPerReal:       defaultPointsPerReal,
+		PointValue:          defaultPointValue,
+		CategoryMultipliers: make(map[string]float64),
+	}
+
+	if config.LoyaltyPointsPerReal > 0 {
+		rules.PointsPerReal = config.LoyaltyPointsPerReal
+	}
+	if config.LoyaltyPointValue > 0 {
+		rules.PointValue = config.LoyaltyPointValue
+	}
+
+	for _, pair := range strings.Split(config.LoyaltyCategoryMultipliers, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(pair, ":")
+		if !ok {
+			return Rules{}, fmt.Errorf("invalid loyalty category multiplier: %q", pair)
+		}
+
+		multiplier, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || multiplier <= 0 {
+			return Rules{}, fmt.Errorf("invalid loyalty category multiplier: %q", pair)
+		}
+
+		rules.CategoryMultipliers[categoryKey(name)] = multiplier
+	}
+
+	return rules, nil
+}
+
+// Earned returns the points a sale of price earns. When the product belongs to several
+// categories the highest multiplier applies.
+func (rules Rules) Earned(price float64, categories []db.Category) int64 {
+	if price <= 0 {
+		return 0
+	}
+
+	multiplier := 1.0
+	for _, category := range categories {
+		if m, ok := rules.CategoryMultipliers[categoryKey(category.Name)]; ok && m > multiplier {
+			multiplier = m
+		}
+	}
+
+	return int64(math.Floor(price * rules.PointsPerReal * multiplier))
+}
+
+// Discount returns the value in reais of the redeemed points
+func (rules Rules) Discount(points int64) float64 {
+	return math.Round(float64(points)*rules.PointValue*100) / 100
+}
+
+func categoryKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,85 @@
+package loyalty
+
+import (
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesFromConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   util.Config
+		expected Rules
+		hasError bool
+	}{
+		{
+			name:   "Defaults",
+			config: util.Config{},
+			expected: Rules{
+				PointsPerReal:       defaultPointsPerReal,
+				PointValue:          defaultPointValue,
+				CategoryMultipliers: map[string]float64{},
+			},
+		},
+		{
+			name: "Custom",
+			config: util.Config{
+				LoyaltyPointsPerReal:       2,
+				LoyaltyPointValue:          0.1,
+				LoyaltyCategoryMultipliers: "Ração:2, Farmácia : 1.5",
+			},
+			expected: Rules{
+				PointsPerReal:       2,
+				PointValue:          0.1,
+				CategoryMultipliers: map[string]float64{"ração": 2, "farmácia": 1.5},
+			},
+		},
+		{
+			name:     "MissingMultiplier",
+			config:   util.Config{LoyaltyCategoryMultipliers: "Ração"},
+			hasError: true,
+		},
+		{
+			name:     "InvalidMultiplier",
+			config:   util.Config{LoyaltyCategoryMultipliers: "Ração:dois"},
+			hasError: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			rules, err := RulesFromConfig(tc.config)
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, rules)
+		})
+	}
+}
+
+func TestEarned(t *testing.T) {
+	rules := Rules{
+		PointsPerReal:       1,
+		PointValue:          0.05,
+		CategoryMultipliers: map[string]float64{"ração": 2, "farmácia": 1.5},
+	}
+
+	require.Equal(t, int64(99), rules.Earned(99.9, nil))
+	require.Equal(t, int64(0), rules.Earned(-10, nil))
+	require.Equal(t, int64(150), rules.Earned(100, []db.Category{{Name: "Farmácia"}}))
+	require.Equal(t, int64(200), rules.Earned(100, []db.Category{{Name: "Farmácia"}, {Name: "Ração"}}))
+	require.Equal(t, int64(100), rules.Earned(100, []db.Category{{Name: "Brinquedos"}}))
+}
+
+func TestDiscount(t *testing.T) {
+	rules := Rules{PointsPerReal: 1, PointValue: 0.05}
+
+	require.Equal(t, 5.0, rules.Discount(100))
+	require.Equal(t, 0.35, rules.Discount(7))
+}
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"super-pet-delivery/api"
+	"super-pet-delivery/audit"
 	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/notification"
+	"super-pet-delivery/pricing"
+	"super-pet-delivery/subscription"
 	"super-pet-delivery/util"
 
 	_ "github.com/lib/pq"
@@ -23,7 +28,20 @@ func main() {
 	}
 
 	store := db.NewSortableStore(conn)
-	server, err := api.NewServer(config, store)
+
+	// deliver queued notifications in the background
+	notifier := notification.NewDispatcher(store, notification.NotifiersFromConfig(config)...)
+	go notifier.Start(context.Background(), config.NotificationInterval)
+
+	// generate the pending sales of due subscriptions in the background
+	go subscription.NewScheduler(store).Start(context.Background(), config.SubscriptionInterval)
+
+	// apply scheduled prices and restore expired promotions in the background
+	go pricing.NewScheduler(store).Start(context.Background(), config.PriceSchedulerInterval)
+
+	go audit.NewPurger(store, config.AuditRetention).Start(context.Background(), config.AuditPurgeInterval)
+
+	server, err := api.NewServer(config, store, notifier)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
 	}
@@ -0,0 +1,206 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"sort"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/mail"
+	"super-pet-delivery/util"
+	"time"
+)
+
+// Statuses of a notification in the outbox
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+	StatusFailed  = "failed"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultBaseBackoff = time.Minute
+	maxBackoff         = 6 * time.Hour
+	outboxBatchSize    = 50
+	// how long a claimed batch is hidden from the other dispatchers while it is sent
+	outboxLease = 10 * time.Minute
+)
+
+// Dispatcher stores notifications in the outbox and delivers them through the registered notifiers
+type Dispatcher struct {
+	store       db.Store
+	notifiers   map[string]Notifier
+	maxAttempts int32
+	baseBackoff time.Duration
+}
+
+// NewDispatcher creates a dispatcher delivering through the given notifiers
+func NewDispatcher(store db.Store, notifiers ...Notifier) *Dispatcher {
+	dispatcher := &Dispatcher{
+		store:       store,
+		notifiers:   make(map[string]Notifier),
+		maxAttempts: defaultMaxAttempts,
+		baseBackoff: defaultBaseBackoff,
+	}
+
+	for _, notifier := range notifiers {
+		dispatcher.notifiers[notifier.Channel()] = notifier
+	}
+
+	return dispatcher
+}
+
+// NotifiersFromConfig returns the notifiers enabled in the configuration
+func NotifiersFromConfig(config util.Config) []Notifier {
+	var notifiers []Notifier
+
+	if config.NotificationProviderURL != "" {
+		notifiers = append(notifiers, NewHTTPNotifier("whatsapp", config.NotificationProviderURL, config.NotificationProviderToken))
+	}
+
+	if config.NotificationEmailTo != "" {
+		sender := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
+		notifiers = append(notifiers, NewEmailNotifier(sender, config.NotificationEmailTo))
+	}
+
+	if config.NotificationFakeProvider {
+		notifiers = append(notifiers, NewFakeNotifier())
+	}
+
+	return notifiers
+}
+
+// Enqueue renders the message for an event and stores it in the outbox
+// once for every channel through which the client can be reached
+func (dispatcher *Dispatcher) Enqueue(ctx context.Context, event Event) error {
+	if len(dispatcher.notifiers) == 0 {
+		return nil
+	}
+
+	msg, err := NewMessage(event)
+	if err != nil {
+		return err
+	}
+
+	for channel, notifier := range dispatcher.notifiers {
+		recipient := notifier.Recipient(event.Client)
+		if recipient == "" {
+			continue
+		}
+
+		_, err := dispatcher.store.CreateNotification(ctx, db.CreateNotificationParams{
+			Channel:   channel,
+			Event:     msg.Event,
+			SaleID:    event.Sale.ID,
+			Recipient: recipient,
+			Subject:   msg.Subject,
+			Body:      msg.Body,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProcessOutbox tries to deliver every due notification once and returns how many were sent.
+// Failed deliveries are rescheduled with exponential backoff until maxAttempts is reached.
+// The batch is claimed by moving it forward by outboxLease with the rows locked, so two dispatchers
+// never send the same notification. Notifications of disabled channels wait in the outbox until
+// the channel is enabled again, without holding up the others.
+func (dispatcher *Dispatcher) ProcessOutbox(ctx context.Context) (int, error) {
+	if len(dispatcher.notifiers) == 0 {
+		return 0, nil
+	}
+
+	notifications, err := dispatcher.store.ClaimDueNotifications(ctx, db.ClaimDueNotificationsParams{
+		LockedUntil: time.Now().Add(outboxLease),
+		Channels:    dispatcher.channels(),
+		LimitCount:  outboxBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, notification := range notifications {
+		notifier := dispatcher.notifiers[notification.Channel]
+
+		msg := Message{
+			Event:     notification.Event,
+			Recipient: notification.Recipient,
+			Subject:   notification.Subject,
+			Body:      notification.Body,
+		}
+
+		sendErr := notifier.Send(ctx, msg)
+		if sendErr == nil {
+			if _, err := dispatcher.store.MarkNotificationSent(ctx, notification.ID); err != nil {
+				return sent, err
+			}
+			sent++
+			continue
+		}
+
+		status := StatusPending
+		if notification.Attempts+1 >= dispatcher.maxAttempts {
+			status = StatusFailed
+		}
+
+		_, err := dispatcher.store.MarkNotificationFailed(ctx, db.MarkNotificationFailedParams{
+			ID:            notification.ID,
+			Status:        status,
+			LastError:     sendErr.Error(),
+			NextAttemptAt: time.Now().Add(backoff(dispatcher.baseBackoff, notification.Attempts)),
+		})
+		if err != nil {
+			return sent, err
+		}
+	}
+
+	return sent, nil
+}
+
+// channels are the names of the enabled channels
+func (dispatcher *Dispatcher) channels() []string {
+	channels := make([]string, 0, len(dispatcher.notifiers))
+	for channel := range dispatcher.notifiers {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// Start processes the outbox every interval until the context is cancelled
+func (dispatcher *Dispatcher) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := dispatcher.ProcessOutbox(ctx); err != nil {
+				log.Println("cannot process notification outbox:", err)
+			}
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt, doubling on every failed attempt
+func backoff(base time.Duration, attempts int32) time.Duration {
+	wait := base
+	for i := int32(0); i < attempts; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return wait
+}
@@ -0,0 +1,191 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomEvent(eventType string) Event {
+	client := db.Client{
+		ID:            util.RandomInt(1, 1000),
+		FullName:      util.RandomString(8),
+		PhoneWhatsapp: util.RandomString(11),
+	}
+
+	return Event{
+		Type: eventType,
+		Sale: db.Sale{
+			ID:       util.RandomInt(1, 1000),
+			ClientID: client.ID,
+			Product:  util.RandomString(10),
+			Price:    float64(util.RandomInt(10, 300)),
+		},
+		Client: client,
+	}
+}
+
+func TestNewMessage(t *testing.T) {
+	event := randomEvent(EventSaleOutForDelivery)
+
+	msg, err := NewMessage(event)
+	require.NoError(t, err)
+	require.Equal(t, EventSaleOutForDelivery, msg.Event)
+	require.Contains(t, msg.Body, event.Client.FullName)
+	require.Contains(t, msg.Body, event.Sale.Product)
+
+	_, err = NewMessage(randomEvent("unknown"))
+	require.Error(t, err)
+}
+
+func TestEnqueue(t *testing.T) {
+	event := randomEvent(EventSaleCreated)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CreateNotification(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateNotificationParams) (db.NotificationOutbox, error) {
+			require.Equal(t, "fake", arg.Channel)
+			require.Equal(t, EventSaleCreated, arg.Event)
+			require.Equal(t, event.Sale.ID, arg.SaleID)
+			require.NotEmpty(t, arg.Recipient)
+			return db.NotificationOutbox{ID: 1}, nil
+		})
+
+	dispatcher := NewDispatcher(store, NewFakeNotifier())
+	require.NoError(t, dispatcher.Enqueue(context.Background(), event))
+}
+
+func TestEnqueueWithoutNotifiers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(0)
+
+	dispatcher := NewDispatcher(store)
+	require.NoError(t, dispatcher.Enqueue(context.Background(), randomEvent(EventSaleCreated)))
+}
+
+func TestProcessOutbox(t *testing.T) {
+	notification := db.NotificationOutbox{
+		ID:        util.RandomInt(1, 1000),
+		Channel:   "fake",
+		Event:     EventSaleCreated,
+		Recipient: "client:1",
+		Subject:   util.RandomString(10),
+		Body:      util.RandomString(20),
+		Status:    StatusPending,
+	}
+
+	testCases := []struct {
+		name       string
+		attempts   int32
+		sendErr    error
+		buildStubs func(store *mockdb.MockStore)
+		wantSent   int
+	}{
+		{
+			name: "Sent",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().MarkNotificationSent(gomock.Any(), gomock.Eq(notification.ID)).Times(1)
+				store.EXPECT().MarkNotificationFailed(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantSent: 1,
+		},
+		{
+			name:    "Retry",
+			sendErr: errors.New("provider unavailable"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().MarkNotificationSent(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					MarkNotificationFailed(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.MarkNotificationFailedParams) (db.NotificationOutbox, error) {
+						require.Equal(t, StatusPending, arg.Status)
+						require.Equal(t, "provider unavailable", arg.LastError)
+						require.True(t, arg.NextAttemptAt.After(time.Now()))
+						return db.NotificationOutbox{}, nil
+					})
+			},
+			wantSent: 0,
+		},
+		{
+			name:     "GiveUp",
+			attempts: defaultMaxAttempts - 1,
+			sendErr:  errors.New("provider unavailable"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MarkNotificationFailed(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.MarkNotificationFailedParams) (db.NotificationOutbox, error) {
+						require.Equal(t, StatusFailed, arg.Status)
+						return db.NotificationOutbox{}, nil
+					})
+			},
+			wantSent: 0,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			due := notification
+			due.Attempts = tc.attempts
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ClaimDueNotifications(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, arg db.ClaimDueNotificationsParams) ([]db.NotificationOutbox, error) {
+					// only the enabled channels are claimed, for long enough to send them
+					require.Equal(t, []string{"fake"}, arg.Channels)
+					require.True(t, arg.LockedUntil.After(time.Now()))
+					return []db.NotificationOutbox{due}, nil
+				})
+			tc.buildStubs(store)
+
+			fake := NewFakeNotifier()
+			fake.Err = tc.sendErr
+
+			sent, err := NewDispatcher(store, fake).ProcessOutbox(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.wantSent, sent)
+			require.Len(t, fake.Messages(), tc.wantSent)
+		})
+	}
+}
+
+func TestProcessOutboxWithoutNotifiers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ClaimDueNotifications(gomock.Any(), gomock.Any()).Times(0)
+
+	sent, err := NewDispatcher(store).ProcessOutbox(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, sent)
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, time.Minute, backoff(time.Minute, 0))
+	require.Equal(t, 2*time.Minute, backoff(time.Minute, 1))
+	require.Equal(t, 8*time.Minute, backoff(time.Minute, 3))
+	require.Equal(t, maxBackoff, backoff(time.Minute, 30))
+}
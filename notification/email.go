@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"context"
+	"html"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/mail"
+)
+
+// EmailNotifier sends notifications through a mail.EmailSender
+type EmailNotifier struct {
+	sender mail.EmailSender
+	to     string
+}
+
+// NewEmailNotifier creates a notifier that e-mails every message to a fixed address.
+// Clients have no e-mail on file, so this is used to copy notifications to the store's inbox.
+func NewEmailNotifier(sender mail.EmailSender, to string) Notifier {
+	return &EmailNotifier{
+		sender: sender,
+		to:     to,
+	}
+}
+
+func (notifier *EmailNotifier) Channel() string {
+	return "email"
+}
+
+func (notifier *EmailNotifier) Recipient(client db.Client) string {
+	return notifier.to
+}
+
+func (notifier *EmailNotifier) Send(ctx context.Context, msg Message) error {
+	content := "<html><body><p>" + html.EscapeString(msg.Body) + "</p></body></html>"
+	return notifier.sender.SendEmail(msg.Subject, content, []string{msg.Recipient}, nil, nil, nil)
+}
@@ -0,0 +1,51 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	db "super-pet-delivery/db/sqlc"
+	"sync"
+)
+
+// FakeNotifier keeps the messages in memory instead of delivering them.
+// It is used in tests and to run the notifications locally without a provider.
+type FakeNotifier struct {
+	mu       sync.Mutex
+	messages []Message
+	// Err is returned by Send when set, to simulate a failing provider
+	Err error
+}
+
+func NewFakeNotifier() *FakeNotifier {
+	return &FakeNotifier{}
+}
+
+func (notifier *FakeNotifier) Channel() string {
+	return "fake"
+}
+
+func (notifier *FakeNotifier) Recipient(client db.Client) string {
+	return fmt.Sprintf("client:%d", client.ID)
+}
+
+func (notifier *FakeNotifier) Send(ctx context.Context, msg Message) error {
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+
+	if notifier.Err != nil {
+		return notifier.Err
+	}
+
+	log.Printf("fake notification to %s: %s", msg.Recipient, msg.Subject)
+	notifier.messages = append(notifier.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far
+func (notifier *FakeNotifier) Messages() []Message {
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+
+	return append([]Message{}, notifier.messages...)
+}
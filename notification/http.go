@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	db "super-pet-delivery/db/sqlc"
+	"time"
+)
+
+// HTTPNotifier posts notifications as JSON to a provider endpoint,
+// such as a WhatsApp gateway or a generic webhook
+type HTTPNotifier struct {
+	channel string
+	url     string
+	token   string
+	client  *http.Client
+}
+
+// NewHTTPNotifier creates a notifier that posts messages to url.
+// When token is not empty it is sent as a bearer token.
+func NewHTTPNotifier(channel string, url string, token string) Notifier {
+	return &HTTPNotifier{
+		channel: channel,
+		url:     url,
+		token:   token,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (notifier *HTTPNotifier) Channel() string {
+	return notifier.channel
+}
+
+func (notifier *HTTPNotifier) Recipient(client db.Client) string {
+	return client.PhoneWhatsapp
+}
+
+func (notifier *HTTPNotifier) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notifier.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if notifier.token != "" {
+		req.Header.Set("Authorization", "Bearer "+notifier.token)
+	}
+
+	resp, err := notifier.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification provider failed with status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
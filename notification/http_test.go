@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPNotifier(t *testing.T) {
+	msg := Message{
+		Event:     EventSaleCreated,
+		Recipient: "5511999999999",
+		Subject:   "Pedido #1 recebido",
+		Body:      "Olá!",
+	}
+
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var got Message
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		require.Equal(t, msg, got)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer provider.Close()
+
+	notifier := NewHTTPNotifier("whatsapp", provider.URL, "secret")
+	require.Equal(t, "whatsapp", notifier.Channel())
+	require.NoError(t, notifier.Send(context.Background(), msg))
+}
+
+func TestHTTPNotifierProviderError(t *testing.T) {
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer provider.Close()
+
+	notifier := NewHTTPNotifier("webhook", provider.URL, "")
+	require.Error(t, notifier.Send(context.Background(), Message{}))
+}
@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	db "super-pet-delivery/db/sqlc"
+)
+
+// Sale events that trigger a notification to the client
+const (
+	EventSaleCreated        = "sale_created"
+	EventSaleOutForDelivery = "sale_out_for_delivery"
+)
+
+// Notifier is an interface for delivering messages through one channel
+type Notifier interface {
+	// Channel returns the name stored in the outbox for this notifier
+	Channel() string
+	// Recipient returns the address of the client on this channel, or "" if the client can't be reached
+	Recipient(client db.Client) string
+	// Send delivers a message to its recipient
+	Send(ctx context.Context, msg Message) error
+}
+
+// Message is what gets delivered by a Notifier
+type Message struct {
+	Event     string `json:"event"`
+	Recipient string `json:"to"`
+	Subject   string `json:"subject"`
+	Body      string `json:"message"`
+}
+
+// Event describes something that happened to a sale
+type Event struct {
+	Type   string
+	Sale   db.Sale
+	Client db.Client
+}
+
+// NewMessage renders the subject and body for a sale event
+func NewMessage(event Event) (Message, error) {
+	var subject, body string
+
+	switch event.Type {
+	case EventSaleCreated:
+		subject = fmt.Sprintf("Pedido #%d recebido", event.Sale.ID)
+		body = fmt.Sprintf("Olá %s! Recebemos o seu pedido #%d (%s) no valor de R$ %.2f. Avisaremos quando ele sair para entrega.",
+			event.Client.FullName, event.Sale.ID, event.Sale.Product, event.Sale.Price)
+	case EventSaleOutForDelivery:
+		subject = fmt.Sprintf("Pedido #%d saiu para entrega", event.Sale.ID)
+		body = fmt.Sprintf("Olá %s! O seu pedido #%d (%s) saiu para entrega e chegará em breve.",
+			event.Client.FullName, event.Sale.ID, event.Sale.Product)
+	default:
+		return Message{}, fmt.Errorf("unknown notification event: %s", event.Type)
+	}
+
+	return Message{
+		Event:   event.Type,
+		Subject: subject,
+		Body:    body,
+	}, nil
+}
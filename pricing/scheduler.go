@@ -0,0 +1,151 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"log"
+	db "super-pet-delivery/db/sqlc"
+	"time"
+)
+
+// ErrOverlap is returned when a scheduled price overlaps another pending or active entry of the same product
+var ErrOverlap = errors.New("scheduled price overlaps another scheduled price of this product")
+
+// Scheduler applies scheduled prices when they start and restores the previous prices when they end
+type Scheduler struct {
+	store db.Store
+}
+
+// NewScheduler creates a new price scheduler
+func NewScheduler(store db.Store) *Scheduler {
+	return &Scheduler{store: store}
+}
+
+// ApplyDue finishes promotions that ended on or before now and then starts the entries that are due.
+// Ending first matters when a promotion is followed right away by another one on the same product:
+// the second one must see the restored price, not the promotional one.
+// It returns how many entries were started and finished.
+func (scheduler *Scheduler) ApplyDue(ctx context.Context, now time.Time) (started int, finished int, err error) {
+	expired, err := scheduler.store.ListExpiredScheduledPrices(ctx, now)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range expired {
+		result, err := scheduler.finish(ctx, entry.ID)
+		if err != nil {
+			// keep going so one broken entry does not block the others
+			log.Printf("cannot finish scheduled price %d: %v", entry.ID, err)
+			continue
+		}
+		if !result.Skipped {
+			finished++
+		}
+	}
+
+	due, err := scheduler.store.ListDueScheduledPrices(ctx, now)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range due {
+		if Expired(entry, now) {
+			// the job was down for the whole window, never apply it
+			result, err := scheduler.finish(ctx, entry.ID)
+			if err != nil {
+				log.Printf("cannot finish scheduled price %d: %v", entry.ID, err)
+				continue
+			}
+			if !result.Skipped {
+				finished++
+			}
+			continue
+		}
+
+		result, err := scheduler.store.StartScheduledPriceTx(ctx, entry.ID)
+		if err != nil {
+			log.Printf("cannot start scheduled price %d: %v", entry.ID, err)
+			continue
+		}
+		if !result.Skipped {
+			started++
+		}
+	}
+
+	return started, finished, nil
+}
+
+func (scheduler *Scheduler) finish(ctx context.Context, id int64) (db.ScheduledPriceTxResult, error) {
+	result, err := scheduler.store.FinishScheduledPriceTx(ctx, db.FinishScheduledPriceTxParams{
+		ID:        id,
+		Status:    db.ScheduledPriceFinished,
+		ChangedBy: db.PriceChangedByScheduler,
+	})
+	if err == nil && result.PriceKept {
+		log.Printf("scheduled price %d finished without restoring the prices of product %d, its price changed to %.2f while it was active",
+			id, result.Product.ID, result.Product.Price)
+	}
+	return result, err
+}
+
+// Start applies due prices right away and then every interval until the context is cancelled
+func (scheduler *Scheduler) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, _, err := scheduler.ApplyDue(ctx, time.Now()); err != nil {
+			log.Println("cannot apply scheduled prices:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Expired reports whether the entry has an end date that is already past
+func Expired(entry db.ScheduledPrice, now time.Time) bool {
+	return !entry.EndsAt.IsZero() && !entry.EndsAt.After(now)
+}
+
+// Overlaps reports whether two entries would step on each other.
+// A promotion covers [starts, ends) while a permanent change (zero end) happens at a single instant,
+// so two permanent changes only conflict when they start at the same time.
+func Overlaps(startsA, endsA, startsB, endsB time.Time) bool {
+	if endsA.IsZero() {
+		endsA = startsA
+	}
+	if endsB.IsZero() {
+		endsB = startsB
+	}
+	if startsA.Equal(endsA) && startsB.Equal(endsB) {
+		return startsA.Equal(startsB)
+	}
+	if startsA.Equal(endsA) {
+		return !startsA.Before(startsB) && startsA.Before(endsB)
+	}
+	if startsB.Equal(endsB) {
+		return !startsB.Before(startsA) && startsB.Before(endsA)
+	}
+	return startsA.Before(endsB) && startsB.Before(endsA)
+}
+
+// CheckOverlap returns ErrOverlap when the new entry overlaps a scheduled or active entry of the product
+func CheckOverlap(existing []db.ScheduledPrice, startsAt, endsAt time.Time) error {
+	for _, entry := range existing {
+		if entry.Status != db.ScheduledPriceScheduled && entry.Status != db.ScheduledPriceActive {
+			continue
+		}
+		if Overlaps(entry.StartsAt, entry.EndsAt, startsAt, endsAt) {
+			return ErrOverlap
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func at(month time.Month, d int) time.Time {
+	return time.Date(2023, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestOverlaps(t *testing.T) {
+	testCases := []struct {
+		name     string
+		startsA  time.Time
+		endsA    time.Time
+		startsB  time.Time
+		endsB    time.Time
+		expected bool
+	}{
+		{
+			name:     "Disjoint",
+			startsA:  at(5, 1),
+			endsA:    at(5, 10),
+			startsB:  at(5, 10),
+			endsB:    at(5, 20),
+			expected: false,
+		},
+		{
+			name:     "Overlapping",
+			startsA:  at(5, 1),
+			endsA:    at(5, 10),
+			startsB:  at(5, 5),
+			endsB:    at(5, 20),
+			expected: true,
+		},
+		{
+			name:     "PermanentInsidePromotion",
+			startsA:  at(5, 1),
+			endsA:    at(5, 10),
+			startsB:  at(5, 3),
+			expected: true,
+		},
+		{
+			name:     "PermanentAfterPromotion",
+			startsA:  at(5, 1),
+			endsA:    at(5, 10),
+			startsB:  at(5, 10),
+			expected: false,
+		},
+		{
+			name:     "PermanentChangesOnDifferentDates",
+			startsA:  at(5, 1),
+			startsB:  at(6, 1),
+			expected: false,
+		},
+		{
+			name:     "PermanentChangesOnSameDate",
+			startsA:  at(5, 1),
+			startsB:  at(5, 1),
+			expected: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Overlaps(tc.startsA, tc.endsA, tc.startsB, tc.endsB))
+			require.Equal(t, tc.expected, Overlaps(tc.startsB, tc.endsB, tc.startsA, tc.endsA))
+		})
+	}
+}
+
+func TestCheckOverlap(t *testing.T) {
+	existing := []db.ScheduledPrice{
+		{ID: 1, StartsAt: at(5, 1), EndsAt: at(5, 10), Status: db.ScheduledPriceFinished},
+		{ID: 2, StartsAt: at(6, 1), EndsAt: at(6, 10), Status: db.ScheduledPriceScheduled},
+	}
+
+	require.NoError(t, CheckOverlap(existing, at(5, 5), at(5, 8)))
+	require.ErrorIs(t, CheckOverlap(existing, at(6, 5), at(6, 20)), ErrOverlap)
+}
+
+func TestApplyDue(t *testing.T) {
+	now := time.Date(2023, 5, 10, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		started    int
+		finished   int
+	}{
+		{
+			name: "FinishesBeforeStarting",
+			buildStubs: func(store *mockdb.MockStore) {
+				expired := db.ScheduledPrice{ID: 1, ProductID: 5, StartsAt: at(5, 1), EndsAt: at(5, 10), Status: db.ScheduledPriceActive}
+				due := db.ScheduledPrice{ID: 2, ProductID: 5, StartsAt: at(5, 10), EndsAt: at(5, 20), Status: db.ScheduledPriceScheduled}
+				gomock.InOrder(
+					store.EXPECT().ListExpiredScheduledPrices(gomock.Any(), gomock.Eq(now)).Return([]db.ScheduledPrice{expired}, nil),
+					store.EXPECT().FinishScheduledPriceTx(gomock.Any(), gomock.Eq(db.FinishScheduledPriceTxParams{ID: expired.ID, Status: db.ScheduledPriceFinished, ChangedBy: db.PriceChangedByScheduler})).
+						Return(db.ScheduledPriceTxResult{}, nil),
+					store.EXPECT().ListDueScheduledPrices(gomock.Any(), gomock.Eq(now)).Return([]db.ScheduledPrice{due}, nil),
+					store.EXPECT().StartScheduledPriceTx(gomock.Any(), gomock.Eq(due.ID)).Return(db.ScheduledPriceTxResult{}, nil),
+				)
+			},
+			started:  1,
+			finished: 1,
+		},
+		{
+			name: "MissedWindowIsNotApplied",
+			buildStubs: func(store *mockdb.MockStore) {
+				missed := db.ScheduledPrice{ID: 3, StartsAt: at(5, 1), EndsAt: at(5, 5), Status: db.ScheduledPriceScheduled}
+				store.EXPECT().ListExpiredScheduledPrices(gomock.Any(), gomock.Any()).Times(1).Return([]db.ScheduledPrice{}, nil)
+				store.EXPECT().ListDueScheduledPrices(gomock.Any(), gomock.Any()).Times(1).Return([]db.ScheduledPrice{missed}, nil)
+				store.EXPECT().StartScheduledPriceTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().FinishScheduledPriceTx(gomock.Any(), gomock.Eq(db.FinishScheduledPriceTxParams{ID: missed.ID, Status: db.ScheduledPriceFinished, ChangedBy: db.PriceChangedByScheduler})).Times(1).
+					Return(db.ScheduledPriceTxResult{}, nil)
+			},
+			started:  0,
+			finished: 1,
+		},
+		{
+			name: "FailureDoesNotStopOthers",
+			buildStubs: func(store *mockdb.MockStore) {
+				first := db.ScheduledPrice{ID: 4, StartsAt: at(5, 9), Status: db.ScheduledPriceScheduled}
+				second := db.ScheduledPrice{ID: 5, StartsAt: at(5, 9), Status: db.ScheduledPriceScheduled}
+				store.EXPECT().ListExpiredScheduledPrices(gomock.Any(), gomock.Any()).Times(1).Return([]db.ScheduledPrice{}, nil)
+				store.EXPECT().ListDueScheduledPrices(gomock.Any(), gomock.Any()).Times(1).Return([]db.ScheduledPrice{first, second}, nil)
+				gomock.InOrder(
+					store.EXPECT().StartScheduledPriceTx(gomock.Any(), gomock.Eq(first.ID)).Return(db.ScheduledPriceTxResult{}, errors.New("boom")),
+					store.EXPECT().StartScheduledPriceTx(gomock.Any(), gomock.Eq(second.ID)).Return(db.ScheduledPriceTxResult{}, nil),
+				)
+			},
+			started:  1,
+			finished: 0,
+		},
+		{
+			name: "AlreadyHandled",
+			buildStubs: func(store *mockdb.MockStore) {
+				due := db.ScheduledPrice{ID: 6, StartsAt: at(5, 9), Status: db.ScheduledPriceScheduled}
+				store.EXPECT().ListExpiredScheduledPrices(gomock.Any(), gomock.Any()).Times(1).Return([]db.ScheduledPrice{}, nil)
+				store.EXPECT().ListDueScheduledPrices(gomock.Any(), gomock.Any()).Times(1).Return([]db.ScheduledPrice{due}, nil)
+				store.EXPECT().StartScheduledPriceTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ScheduledPriceTxResult{Skipped: true}, nil)
+			},
+			started:  0,
+			finished: 0,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			started, finished, err := NewScheduler(store).ApplyDue(context.Background(), now)
+			require.NoError(t, err)
+			require.Equal(t, tc.started, started)
+			require.Equal(t, tc.finished, finished)
+		})
+	}
+}
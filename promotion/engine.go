@@ -0,0 +1,178 @@
+package promotion
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	db "super-pet-delivery/db/sqlc"
+	"time"
+)
+
+// Discount types of a coupon
+const (
+	DiscountPercentage = "percentage"
+	DiscountFixed      = "fixed"
+)
+
+// Reasons a coupon cannot be applied to a cart
+var (
+	ErrCouponInactive       = errors.New("coupon is not active")
+	ErrCouponNotStarted     = errors.New("coupon is not valid yet")
+	ErrCouponExpired        = errors.New("coupon has expired")
+	ErrUsageLimit           = db.ErrCouponUsageLimit
+	ErrClientUsageLimit     = errors.New("coupon usage limit reached for this client")
+	ErrMinimumOrderValue    = errors.New("order value is below the coupon minimum")
+	ErrNoEligibleItems      = errors.New("coupon does not apply to any item of the order")
+	ErrInvalidDiscountType  = errors.New("invalid coupon discount type")
+	ErrInvalidDiscountValue = errors.New("invalid coupon discount value")
+)
+
+// CartItem is one line of a draft order
+type CartItem struct {
+	ProductID   int64   `json:"product_id"`
+	CategoryIDs []int64 `json:"category_ids"`
+	Price       float64 `json:"price"`
+	Quantity    int32   `json:"quantity"`
+}
+
+// Total returns the value of the line
+func (item CartItem) Total() float64 {
+	quantity := item.Quantity
+	if quantity < 1 {
+		quantity = 1
+	}
+	return item.Price * float64(quantity)
+}
+
+// Cart is a draft order a coupon is evaluated against
+type Cart struct {
+	ClientID int64      `json:"client_id"`
+	Items    []CartItem `json:"items"`
+}
+
+// Total returns the value of the whole cart
+func (cart Cart) Total() float64 {
+	var total float64
+	for _, item := range cart.Items {
+		total += item.Total()
+	}
+	return total
+}
+
+// Usage is how many times a coupon was already used, overall and by the cart's client
+type Usage struct {
+	Total    int64 `json:"total"`
+	ByClient int64 `json:"by_client"`
+}
+
+// Result is the outcome of applying a coupon to a cart
+type Result struct {
+	Total         float64 `json:"total"`
+	EligibleTotal float64 `json:"eligible_total"`
+	Discount      float64 `json:"discount"`
+}
+
+// Evaluate checks whether coupon can be applied to cart at now and computes the discount.
+// Scoped coupons only discount the items of the selected products or categories, while the
+// minimum order value is compared against the whole cart.
+func Evaluate(coupon db.Coupon, cart Cart, usage Usage, now time.Time) (Result, error) {
+	result := Result{Total: round(cart.Total())}
+
+	if !coupon.Active {
+		return result, ErrCouponInactive
+	}
+	if now.Before(coupon.StartsAt) {
+		return result, ErrCouponNotStarted
+	}
+	if !coupon.EndsAt.IsZero() && now.After(coupon.EndsAt) {
+		return result, ErrCouponExpired
+	}
+	if err := CheckUsage(coupon, usage); err != nil {
+		return result, err
+	}
+	if result.Total < coupon.MinOrderValue {
+		return result, fmt.Errorf("%w (R$ %.2f)", ErrMinimumOrderValue, coupon.MinOrderValue)
+	}
+
+	for _, item := range cart.Items {
+		if Applies(coupon, item) {
+			result.EligibleTotal += item.Total()
+		}
+	}
+	result.EligibleTotal = round(result.EligibleTotal)
+	if result.EligibleTotal <= 0 {
+		return result, ErrNoEligibleItems
+	}
+
+	switch coupon.DiscountType {
+	case DiscountPercentage:
+		result.Discount = round(result.EligibleTotal * coupon.DiscountValue / 100)
+	case DiscountFixed:
+		result.Discount = math.Min(coupon.DiscountValue, result.EligibleTotal)
+	default:
+		return result, ErrInvalidDiscountType
+	}
+
+	return result, nil
+}
+
+// CheckUsage checks the global and per client usage limits of a coupon. Zero means unlimited.
+func CheckUsage(coupon db.Coupon, usage Usage) error {
+	if coupon.MaxUses > 0 && usage.Total >= int64(coupon.MaxUses) {
+		return ErrUsageLimit
+	}
+	if coupon.MaxUsesPerClient > 0 && usage.ByClient >= int64(coupon.MaxUsesPerClient) {
+		return ErrClientUsageLimit
+	}
+	return nil
+}
+
+// Applies reports whether item is in the scope of coupon. Coupons without
+// products or categories apply to every item.
+func Applies(coupon db.Coupon, item CartItem) bool {
+	if len(coupon.ProductIds) == 0 && len(coupon.CategoryIds) == 0 {
+		return true
+	}
+
+	for _, id := range coupon.ProductIds {
+		if id == item.ProductID {
+			return true
+		}
+	}
+
+	for _, id := range coupon.CategoryIds {
+		for _, categoryID := range item.CategoryIDs {
+			if id == categoryID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ValidateCoupon checks the values of a coupon before it is stored
+func ValidateCoupon(coupon db.Coupon) error {
+	switch coupon.DiscountType {
+	case DiscountPercentage:
+		if coupon.DiscountValue <= 0 || coupon.DiscountValue > 100 {
+			return ErrInvalidDiscountValue
+		}
+	case DiscountFixed:
+		if coupon.DiscountValue <= 0 {
+			return ErrInvalidDiscountValue
+		}
+	default:
+		return ErrInvalidDiscountType
+	}
+
+	if !coupon.EndsAt.IsZero() && coupon.EndsAt.Before(coupon.StartsAt) {
+		return errors.New("coupon ends before it starts")
+	}
+
+	return nil
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
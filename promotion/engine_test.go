@@ -0,0 +1,179 @@
+package promotion
+
+import (
+	db "super-pet-delivery/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func validCoupon() db.Coupon {
+	return db.Coupon{
+		ID:            1,
+		Code:          "PET10",
+		DiscountType:  DiscountPercentage,
+		DiscountValue: 10,
+		StartsAt:      now.AddDate(0, 0, -1),
+		EndsAt:        now.AddDate(0, 0, 1),
+		Active:        true,
+		CategoryIds:   []int64{},
+		ProductIds:    []int64{},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	cart := Cart{
+		ClientID: 1,
+		Items: []CartItem{
+			{ProductID: 10, CategoryIDs: []int64{1}, Price: 100, Quantity: 2},
+			{ProductID: 20, CategoryIDs: []int64{2}, Price: 50, Quantity: 1},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		coupon   func(coupon *db.Coupon)
+		usage    Usage
+		err      error
+		discount float64
+		eligible float64
+	}{
+		{
+			name:     "Percentage",
+			coupon:   func(coupon *db.Coupon) {},
+			discount: 25,
+			eligible: 250,
+		},
+		{
+			name: "Fixed",
+			coupon: func(coupon *db.Coupon) {
+				coupon.DiscountType = DiscountFixed
+				coupon.DiscountValue = 30
+			},
+			discount: 30,
+			eligible: 250,
+		},
+		{
+			name: "FixedCappedAtEligibleTotal",
+			coupon: func(coupon *db.Coupon) {
+				coupon.DiscountType = DiscountFixed
+				coupon.DiscountValue = 80
+				coupon.ProductIds = []int64{20}
+			},
+			discount: 50,
+			eligible: 50,
+		},
+		{
+			name: "ScopedToCategory",
+			coupon: func(coupon *db.Coupon) {
+				coupon.CategoryIds = []int64{1}
+			},
+			discount: 20,
+			eligible: 200,
+		},
+		{
+			name: "ScopedToProduct",
+			coupon: func(coupon *db.Coupon) {
+				coupon.ProductIds = []int64{20}
+			},
+			discount: 5,
+			eligible: 50,
+		},
+		{
+			name: "NoEligibleItems",
+			coupon: func(coupon *db.Coupon) {
+				coupon.CategoryIds = []int64{99}
+			},
+			err: ErrNoEligibleItems,
+		},
+		{
+			name: "Inactive",
+			coupon: func(coupon *db.Coupon) {
+				coupon.Active = false
+			},
+			err: ErrCouponInactive,
+		},
+		{
+			name: "NotStarted",
+			coupon: func(coupon *db.Coupon) {
+				coupon.StartsAt = now.Add(time.Hour)
+			},
+			err: ErrCouponNotStarted,
+		},
+		{
+			name: "Expired",
+			coupon: func(coupon *db.Coupon) {
+				coupon.EndsAt = now.Add(-time.Hour)
+			},
+			err: ErrCouponExpired,
+		},
+		{
+			name: "NoEndDate",
+			coupon: func(coupon *db.Coupon) {
+				coupon.EndsAt = time.Time{}
+			},
+			discount: 25,
+			eligible: 250,
+		},
+		{
+			name: "GlobalLimit",
+			coupon: func(coupon *db.Coupon) {
+				coupon.MaxUses = 100
+			},
+			usage: Usage{Total: 100},
+			err:   ErrUsageLimit,
+		},
+		{
+			name: "ClientLimit",
+			coupon: func(coupon *db.Coupon) {
+				coupon.MaxUses = 100
+				coupon.MaxUsesPerClient = 1
+			},
+			usage: Usage{Total: 10, ByClient: 1},
+			err:   ErrClientUsageLimit,
+		},
+		{
+			name: "MinimumOrderValue",
+			coupon: func(coupon *db.Coupon) {
+				coupon.MinOrderValue = 300
+			},
+			err: ErrMinimumOrderValue,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			coupon := validCoupon()
+			tc.coupon(&coupon)
+
+			result, err := Evaluate(coupon, cart, tc.usage, now)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 250.0, result.Total)
+			require.Equal(t, tc.eligible, result.EligibleTotal)
+			require.Equal(t, tc.discount, result.Discount)
+		})
+	}
+}
+
+func TestValidateCoupon(t *testing.T) {
+	coupon := validCoupon()
+	require.NoError(t, ValidateCoupon(coupon))
+
+	coupon.DiscountValue = 120
+	require.ErrorIs(t, ValidateCoupon(coupon), ErrInvalidDiscountValue)
+
+	coupon.DiscountType = "bogus"
+	require.ErrorIs(t, ValidateCoupon(coupon), ErrInvalidDiscountType)
+
+	coupon = validCoupon()
+	coupon.EndsAt = coupon.StartsAt.Add(-time.Hour)
+	require.Error(t, ValidateCoupon(coupon))
+}
@@ -0,0 +1,142 @@
+package reminder
+
+import (
+	"sort"
+	"strings"
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/util"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// Options controls how consumption cycles are estimated
+type Options struct {
+	// MinPurchases is how many purchases of a product are needed before a cycle is estimated
+	MinPurchases int
+	// Tolerance is the fraction of the cycle a client may be late before the product is
+	// considered abandoned and no longer reminded about
+	Tolerance float64
+}
+
+// DefaultOptions are used when the configuration leaves the values empty
+var DefaultOptions = Options{
+	MinPurchases: 3,
+	Tolerance:    0.5,
+}
+
+// Reminder is a product a client is likely to run out of
+type Reminder struct {
+	ClientID     int64     `json:"client_id"`
+	ClientName   string    `json:"client_name"`
+	Product      string    `json:"product"`
+	Purchases    int       `json:"purchases"`
+	CycleDays    float64   `json:"cycle_days"`
+	LastPurchase time.Time `json:"last_purchase"`
+	ExpectedAt   time.Time `json:"expected_at"`
+	DaysLeft     int       `json:"days_left"`
+}
+
+// Estimate returns the reminders of one client whose expected repurchase date falls before now plus
+// days. sales are the client's purchases as returned by GetSalesByClientID. Each product's cycle is the
+// median number of days between consecutive purchases, which keeps a single late order from skewing it.
+func Estimate(sales []db.Sale, now time.Time, days int, options Options) []Reminder {
+	if options.MinPurchases < 2 {
+		options.MinPurchases = 2
+	}
+
+	purchases := make(map[string][]db.Sale)
+	for _, sale := range sales {
+		if sale.Status == db.SaleStatusPending {
+			continue
+		}
+		key := productKey(sale.Product)
+		if key == "" {
+			continue
+		}
+		purchases[key] = append(purchases[key], sale)
+	}
+
+	until := now.Add(time.Duration(days) * day)
+	reminders := []Reminder{}
+
+	for _, productSales := range purchases {
+		if len(productSales) < options.MinPurchases {
+			continue
+		}
+
+		sort.Slice(productSales, func(i, j int) bool {
+			return productSales[i].CreatedAt.Before(productSales[j].CreatedAt)
+		})
+
+		cycle := medianInterval(productSales)
+		if cycle < day {
+			// several purchases on the same day say nothing about consumption
+			continue
+		}
+
+		last := productSales[len(productSales)-1]
+		expected := last.CreatedAt.Add(cycle)
+		lapsed := expected.Add(time.Duration(float64(cycle) * options.Tolerance))
+
+		if expected.After(until) || now.After(lapsed) {
+			continue
+		}
+
+		reminders = append(reminders, Reminder{
+			ClientID:     last.ClientID,
+			ClientName:   last.ClientName,
+			Product:      last.Product,
+			Purchases:    len(productSales),
+			CycleDays:    float64(cycle) / float64(day),
+			LastPurchase: last.CreatedAt,
+			ExpectedAt:   expected,
+			DaysLeft:     int(expected.Sub(now).Hours() / 24),
+		})
+	}
+
+	Sort(reminders)
+	return reminders
+}
+
+// Sort orders reminders by the date the products are expected to run out
+func Sort(reminders []Reminder) {
+	sort.SliceStable(reminders, func(i, j int) bool {
+		if reminders[i].ExpectedAt.Equal(reminders[j].ExpectedAt) {
+			return reminders[i].ClientID < reminders[j].ClientID
+		}
+		return reminders[i].ExpectedAt.Before(reminders[j].ExpectedAt)
+	})
+}
+
+func productKey(product string) string {
+	return strings.ToLower(strings.Join(strings.Fields(product), " "))
+}
+
+func medianInterval(sales []db.Sale) time.Duration {
+	intervals := make([]time.Duration, 0, len(sales)-1)
+	for i := 1; i < len(sales); i++ {
+		intervals = append(intervals, sales[i].CreatedAt.Sub(sales[i-1].CreatedAt))
+	}
+
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+
+	middle := len(intervals) / 2
+	if len(intervals)%2 == 0 {
+		return (intervals[middle-1] + intervals[middle]) / 2
+	}
+	return intervals[middle]
+}
+
+// OptionsFromConfig returns the estimation options set in the configuration,
+// falling back to DefaultOptions for the values left empty
+func OptionsFromConfig(config util.Config) Options {
+	options := DefaultOptions
+	if config.ReminderMinPurchases > 0 {
+		options.MinPurchases = config.ReminderMinPurchases
+	}
+	if config.ReminderTolerance > 0 {
+		options.Tolerance = config.ReminderTolerance
+	}
+	return options
+}
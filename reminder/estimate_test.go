@@ -0,0 +1,165 @@
+package reminder
+
+import (
+	db "super-pet-delivery/db/sqlc"
+	"super-pet-delivery/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// purchases returns one sale of product for every given number of days before now
+func purchases(product string, daysAgo ...int) []db.Sale {
+	sales := make([]db.Sale, len(daysAgo))
+	for i, d := range daysAgo {
+		sales[i] = db.Sale{
+			ID:         int64(i + 1),
+			ClientID:   1,
+			ClientName: "Maria Silva",
+			Product:    product,
+			Status:     db.SaleStatusConfirmed,
+			CreatedAt:  now.Add(-time.Duration(d) * day),
+		}
+	}
+	return sales
+}
+
+func TestEstimate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		sales     []db.Sale
+		days      int
+		options   Options
+		checkList func(t *testing.T, reminders []Reminder)
+	}{
+		{
+			name:    "DueSoon",
+			sales:   purchases("Ração 15kg", 85, 55, 25),
+			days:    7,
+			options: DefaultOptions,
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Len(t, reminders, 1)
+				require.Equal(t, "Ração 15kg", reminders[0].Product)
+				require.Equal(t, 3, reminders[0].Purchases)
+				require.Equal(t, 30.0, reminders[0].CycleDays)
+				require.Equal(t, 5, reminders[0].DaysLeft)
+			},
+		},
+		{
+			name:    "NotDueYet",
+			sales:   purchases("Ração 15kg", 70, 40, 10),
+			days:    7,
+			options: DefaultOptions,
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Empty(t, reminders)
+			},
+		},
+		{
+			name:    "NotEnoughHistory",
+			sales:   purchases("Ração 15kg", 55, 25),
+			days:    7,
+			options: DefaultOptions,
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Empty(t, reminders)
+			},
+		},
+		{
+			name:    "LowerMinimumHistory",
+			sales:   purchases("Ração 15kg", 55, 25),
+			days:    7,
+			options: Options{MinPurchases: 2, Tolerance: 0.5},
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Len(t, reminders, 1)
+			},
+		},
+		{
+			name:    "LateWithinTolerance",
+			sales:   purchases("Areia", 65, 45, 25),
+			days:    7,
+			options: DefaultOptions,
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Len(t, reminders, 1)
+				require.Equal(t, -5, reminders[0].DaysLeft)
+			},
+		},
+		{
+			name:    "LateBeyondTolerance",
+			sales:   purchases("Areia", 65, 45, 25),
+			days:    7,
+			options: Options{MinPurchases: 3, Tolerance: 0.2},
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Empty(t, reminders)
+			},
+		},
+		{
+			name:    "MedianIgnoresOutlier",
+			sales:   purchases("Ração 15kg", 130, 100, 70, 25),
+			days:    7,
+			options: DefaultOptions,
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Len(t, reminders, 1)
+				require.Equal(t, 30.0, reminders[0].CycleDays)
+			},
+		},
+		{
+			name:    "ProductNamesNormalized",
+			sales:   append(purchases("Ração  15kg", 85, 55), purchases("ração 15kg", 25)...),
+			days:    7,
+			options: DefaultOptions,
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Len(t, reminders, 1)
+				require.Equal(t, 3, reminders[0].Purchases)
+			},
+		},
+		{
+			name: "PendingSalesIgnored",
+			sales: func() []db.Sale {
+				sales := purchases("Ração 15kg", 85, 55, 25)
+				sales[2].Status = db.SaleStatusPending
+				return sales
+			}(),
+			days:    7,
+			options: DefaultOptions,
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Empty(t, reminders)
+			},
+		},
+		{
+			name:    "SameDayPurchases",
+			sales:   purchases("Petisco", 3, 3, 3),
+			days:    7,
+			options: DefaultOptions,
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Empty(t, reminders)
+			},
+		},
+		{
+			name:    "SortedByExpectedDate",
+			sales:   append(purchases("Ração 15kg", 85, 55, 25), purchases("Areia", 30, 20, 10)...),
+			days:    7,
+			options: DefaultOptions,
+			checkList: func(t *testing.T, reminders []Reminder) {
+				require.Len(t, reminders, 2)
+				require.Equal(t, "Areia", reminders[0].Product)
+				require.Equal(t, "Ração 15kg", reminders[1].Product)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			tc.checkList(t, Estimate(tc.sales, now, tc.days, tc.options))
+		})
+	}
+}
+
+func TestOptionsFromConfig(t *testing.T) {
+	require.Equal(t, DefaultOptions, OptionsFromConfig(util.Config{}))
+
+	options := OptionsFromConfig(util.Config{ReminderMinPurchases: 5, ReminderTolerance: 0.2})
+	require.Equal(t, Options{MinPurchases: 5, Tolerance: 0.2}, options)
+}
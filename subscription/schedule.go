@@ -0,0 +1,65 @@
+package subscription
+
+import (
+	"fmt"
+	"strings"
+	db "super-pet-delivery/db/sqlc"
+	"time"
+)
+
+// Date returns the calendar day of t as a UTC midnight, the same shape lib/pq uses for date columns
+func Date(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// NextRunDate returns the first run date after today following from in steps of intervalDays.
+// Periods missed while the scheduler was down are skipped, so they only produce a single order.
+func NextRunDate(from time.Time, intervalDays int32, today time.Time) time.Time {
+	if intervalDays < 1 {
+		intervalDays = 1
+	}
+
+	next := Date(from).AddDate(0, 0, int(intervalDays))
+	today = Date(today)
+	for !next.After(today) {
+		next = next.AddDate(0, 0, int(intervalDays))
+	}
+	return next
+}
+
+// UpcomingRunDates lists the run dates of a subscription up to and including until
+func UpcomingRunDates(subscription db.Subscription, until time.Time) []time.Time {
+	dates := []time.Time{}
+	if subscription.Paused || subscription.IntervalDays < 1 {
+		return dates
+	}
+
+	until = Date(until)
+	for next := Date(subscription.NextRunDate); !next.After(until); next = next.AddDate(0, 0, int(subscription.IntervalDays)) {
+		dates = append(dates, next)
+	}
+	return dates
+}
+
+// DescribeItems returns the product description and total price of the sale generated for the items
+func DescribeItems(items []db.SubscriptionItem) (string, float64) {
+	products := make([]string, 0, len(items))
+	var total float64
+
+	for _, item := range items {
+		products = append(products, fmt.Sprintf("%dx %s", item.Quantity, item.Product))
+		total += float64(item.Quantity) * item.Price
+	}
+
+	return strings.Join(products, ", "), total
+}
+
+// Observation is the observation of the sales generated for a subscription
+func Observation(subscription db.Subscription) string {
+	observation := fmt.Sprintf("Assinatura #%d", subscription.ID)
+	if subscription.Observation != "" {
+		observation += " - " + subscription.Observation
+	}
+	return observation
+}
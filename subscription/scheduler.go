@@ -0,0 +1,113 @@
+package subscription
+
+import (
+	"context"
+	"log"
+	db "super-pet-delivery/db/sqlc"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Scheduler turns due subscriptions into pending sales
+type Scheduler struct {
+	store db.Store
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(store db.Store) *Scheduler {
+	return &Scheduler{store: store}
+}
+
+// RunDue creates a pending sale for every subscription due on or before now and returns how many were created.
+// Running it twice for the same day is safe: already generated runs are skipped.
+func (scheduler *Scheduler) RunDue(ctx context.Context, now time.Time) (int, error) {
+	today := Date(now)
+
+	subscriptions, err := scheduler.store.ListDueSubscriptions(ctx, today)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, subscription := range subscriptions {
+		ok, err := scheduler.generate(ctx, subscription, today)
+		if err != nil {
+			// keep going so one broken subscription does not block the others
+			log.Printf("cannot generate sale for subscription %d: %v", subscription.ID, err)
+			continue
+		}
+		if ok {
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+func (scheduler *Scheduler) generate(ctx context.Context, subscription db.Subscription, today time.Time) (bool, error) {
+	items, err := scheduler.store.ListSubscriptionItems(ctx, subscription.ID)
+	if err != nil {
+		return false, err
+	}
+	nextRunDate := NextRunDate(subscription.NextRunDate, subscription.IntervalDays, today)
+	if len(items) == 0 {
+		// nothing to sell, skip the run so the subscription doesn't stay due on every tick
+		return false, scheduler.store.UpdateSubscriptionNextRun(ctx, db.UpdateSubscriptionNextRunParams{
+			ID:          subscription.ID,
+			NextRunDate: nextRunDate,
+		})
+	}
+
+	client, err := scheduler.store.GetClient(ctx, subscription.ClientID)
+	if err != nil {
+		return false, err
+	}
+
+	product, total := DescribeItems(items)
+
+	result, err := scheduler.store.GenerateSubscriptionSaleTx(ctx, db.GenerateSubscriptionSaleTxParams{
+		SubscriptionID: subscription.ID,
+		RunDate:        Date(subscription.NextRunDate),
+		NextRunDate:    nextRunDate,
+		Sale: db.CreateSaleParams{
+			ClientID:    client.ID,
+			ClientName:  client.FullName,
+			Product:     product,
+			Price:       total,
+			Observation: Observation(subscription),
+			Status:      db.SaleStatusPending,
+		},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			// another instance already generated this run
+			return false, nil
+		}
+		return false, err
+	}
+
+	return !result.Skipped, nil
+}
+
+// Start runs due subscriptions right away and then every interval until the context is cancelled
+func (scheduler *Scheduler) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := scheduler.RunDue(ctx, time.Now()); err != nil {
+			log.Println("cannot run due subscriptions:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
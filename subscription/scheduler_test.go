@@ -0,0 +1,175 @@
+package subscription
+
+import (
+	"context"
+	"database/sql"
+	mockdb "super-pet-delivery/db/mock"
+	db "super-pet-delivery/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNextRunDate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     time.Time
+		interval int32
+		today    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "OnTime",
+			from:     day(2023, 5, 10),
+			interval: 30,
+			today:    day(2023, 5, 10),
+			expected: day(2023, 6, 9),
+		},
+		{
+			name:     "MissedPeriods",
+			from:     day(2023, 1, 1),
+			interval: 7,
+			today:    day(2023, 1, 20),
+			expected: day(2023, 1, 22),
+		},
+		{
+			name:     "LandsOnToday",
+			from:     day(2023, 1, 1),
+			interval: 7,
+			today:    day(2023, 1, 8),
+			expected: day(2023, 1, 15),
+		},
+		{
+			name:     "InvalidInterval",
+			from:     day(2023, 1, 1),
+			interval: 0,
+			today:    day(2023, 1, 1),
+			expected: day(2023, 1, 2),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, NextRunDate(tc.from, tc.interval, tc.today))
+		})
+	}
+}
+
+func TestUpcomingRunDates(t *testing.T) {
+	sub := db.Subscription{ID: 1, IntervalDays: 10, NextRunDate: day(2023, 5, 1)}
+
+	dates := UpcomingRunDates(sub, day(2023, 5, 21))
+	require.Equal(t, []time.Time{day(2023, 5, 1), day(2023, 5, 11), day(2023, 5, 21)}, dates)
+
+	sub.Paused = true
+	require.Empty(t, UpcomingRunDates(sub, day(2023, 5, 21)))
+}
+
+func TestDescribeItems(t *testing.T) {
+	product, total := DescribeItems([]db.SubscriptionItem{
+		{Product: "Ração", Quantity: 2, Price: 120},
+		{Product: "Areia", Quantity: 1, Price: 30.5},
+	})
+	require.Equal(t, "2x Ração, 1x Areia", product)
+	require.Equal(t, 270.5, total)
+}
+
+func TestRunDue(t *testing.T) {
+	now := time.Date(2023, 5, 10, 9, 0, 0, 0, time.UTC)
+	due := db.Subscription{ID: 7, ClientID: 3, IntervalDays: 30, NextRunDate: day(2023, 5, 10)}
+	client := db.Client{ID: 3, FullName: "Maria Silva"}
+	items := []db.SubscriptionItem{{SubscriptionID: 7, Product: "Ração", Quantity: 1, Price: 100}}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		created    int
+	}{
+		{
+			name: "CreatesPendingSale",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListDueSubscriptions(gomock.Any(), gomock.Eq(day(2023, 5, 10))).Times(1).Return([]db.Subscription{due}, nil)
+				store.EXPECT().ListSubscriptionItems(gomock.Any(), gomock.Eq(due.ID)).Times(1).Return(items, nil)
+				store.EXPECT().GetClient(gomock.Any(), gomock.Eq(client.ID)).Times(1).Return(client, nil)
+				arg := db.GenerateSubscriptionSaleTxParams{
+					SubscriptionID: due.ID,
+					RunDate:        day(2023, 5, 10),
+					NextRunDate:    day(2023, 6, 9),
+					Sale: db.CreateSaleParams{
+						ClientID:    client.ID,
+						ClientName:  client.FullName,
+						Product:     "1x Ração",
+						Price:       100,
+						Observation: "Assinatura #7",
+						Status:      db.SaleStatusPending,
+					},
+				}
+				store.EXPECT().GenerateSubscriptionSaleTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.GenerateSubscriptionSaleTxResult{Sale: db.Sale{ID: 1}}, nil)
+			},
+			created: 1,
+		},
+		{
+			name: "AlreadyGenerated",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListDueSubscriptions(gomock.Any(), gomock.Any()).Times(1).Return([]db.Subscription{due}, nil)
+				store.EXPECT().ListSubscriptionItems(gomock.Any(), gomock.Any()).Times(1).Return(items, nil)
+				store.EXPECT().GetClient(gomock.Any(), gomock.Any()).Times(1).Return(client, nil)
+				store.EXPECT().GenerateSubscriptionSaleTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.GenerateSubscriptionSaleTxResult{Skipped: true}, nil)
+			},
+			created: 0,
+		},
+		{
+			name: "NoItems",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListDueSubscriptions(gomock.Any(), gomock.Any()).Times(1).Return([]db.Subscription{due}, nil)
+				store.EXPECT().ListSubscriptionItems(gomock.Any(), gomock.Any()).Times(1).Return([]db.SubscriptionItem{}, nil)
+				store.EXPECT().UpdateSubscriptionNextRun(gomock.Any(), gomock.Eq(db.UpdateSubscriptionNextRunParams{
+					ID:          due.ID,
+					NextRunDate: day(2023, 6, 9),
+				})).Times(1).Return(nil)
+				store.EXPECT().GenerateSubscriptionSaleTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			created: 0,
+		},
+		{
+			name: "ClientMissingDoesNotStopOthers",
+			buildStubs: func(store *mockdb.MockStore) {
+				other := due
+				other.ID = 8
+				store.EXPECT().ListDueSubscriptions(gomock.Any(), gomock.Any()).Times(1).Return([]db.Subscription{due, other}, nil)
+				store.EXPECT().ListSubscriptionItems(gomock.Any(), gomock.Any()).Times(2).Return(items, nil)
+				gomock.InOrder(
+					store.EXPECT().GetClient(gomock.Any(), gomock.Any()).Return(db.Client{}, sql.ErrNoRows),
+					store.EXPECT().GetClient(gomock.Any(), gomock.Any()).Return(client, nil),
+				)
+				store.EXPECT().GenerateSubscriptionSaleTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.GenerateSubscriptionSaleTxResult{Sale: db.Sale{ID: 2}}, nil)
+			},
+			created: 1,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			created, err := NewScheduler(store).RunDue(context.Background(), now)
+			require.NoError(t, err)
+			require.Equal(t, tc.created, created)
+		})
+	}
+}
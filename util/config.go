@@ -19,6 +19,29 @@ type Config struct {
 	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
 	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
 	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	// Outbound notifications sent to clients about their sales
+	NotificationInterval      time.Duration `mapstructure:"NOTIFICATION_INTERVAL"`
+	NotificationProviderURL   string        `mapstructure:"NOTIFICATION_PROVIDER_URL"`
+	NotificationProviderToken string        `mapstructure:"NOTIFICATION_PROVIDER_TOKEN"`
+	NotificationEmailTo       string        `mapstructure:"NOTIFICATION_EMAIL_TO"`
+	NotificationFakeProvider  bool          `mapstructure:"NOTIFICATION_FAKE_PROVIDER"`
+	// How often due subscriptions are turned into pending sales
+	SubscriptionInterval time.Duration `mapstructure:"SUBSCRIPTION_INTERVAL"`
+	// Reorder reminders estimated from the purchase history
+	ReminderMinPurchases int     `mapstructure:"REMINDER_MIN_PURCHASES"`
+	ReminderTolerance    float64 `mapstructure:"REMINDER_TOLERANCE"`
+	// Loyalty program: points earned per real, value of a redeemed point in reais
+	// and per category multipliers written as "Ração:2,Farmácia:1.5"
+	LoyaltyPointsPerReal       float64 `mapstructure:"LOYALTY_POINTS_PER_REAL"`
+	LoyaltyPointValue          float64 `mapstructure:"LOYALTY_POINT_VALUE"`
+	LoyaltyCategoryMultipliers string  `mapstructure:"LOYALTY_CATEGORY_MULTIPLIERS"`
+	// How often scheduled product prices are applied and expired promotions restored
+	PriceSchedulerInterval time.Duration `mapstructure:"PRICE_SCHEDULER_INTERVAL"`
+	// Time limit shared by the queries of the admin search
+	SearchTimeout time.Duration `mapstructure:"SEARCH_TIMEOUT"`
+	// How long the audit log of the admin changes is kept, zero keeps it forever, and how often the old entries are purged
+	AuditRetention     time.Duration `mapstructure:"AUDIT_RETENTION"`
+	AuditPurgeInterval time.Duration `mapstructure:"AUDIT_PURGE_INTERVAL"`
 }
 
 // LoadConfig reads configuration from file or enviroment variables.
@@ -48,5 +71,12 @@ func LoadConfig(path string) (config Config, err error) {
 	config.EmailSenderAddress = viper.GetString("EMAIL_SENDER_ADDRESS")
 	config.EmailSenderPassword = viper.GetString("EMAIL_SENDER_PASSWORD")
 
+	config.NotificationInterval = viper.GetDuration("NOTIFICATION_INTERVAL")
+	config.SubscriptionInterval = viper.GetDuration("SUBSCRIPTION_INTERVAL")
+	config.PriceSchedulerInterval = viper.GetDuration("PRICE_SCHEDULER_INTERVAL")
+	config.SearchTimeout = viper.GetDuration("SEARCH_TIMEOUT")
+	config.AuditRetention = viper.GetDuration("AUDIT_RETENTION")
+	config.AuditPurgeInterval = viper.GetDuration("AUDIT_PURGE_INTERVAL")
+
 	return
 }